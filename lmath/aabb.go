@@ -0,0 +1,140 @@
+package lmath
+
+import (
+	"math"
+)
+
+// An axis aligned bounding box spanning from Min to Max (inclusive).
+type AABB struct {
+	Min, Max Vec3
+}
+
+// Return the smallest box containing all the points.
+// Returns the zero box if no points are given.
+func AABBFromPoints(points ...Vec3) AABB {
+	if len(points) == 0 {
+		return AABB{}
+	}
+	out := AABB{points[0], points[0]}
+	for _, p := range points[1:] {
+		out = out.Expand(p)
+	}
+	return out
+}
+
+// Return the center of the box.
+func (this AABB) Center() Vec3 {
+	return this.Min.Add(this.Max).MultScalar(0.5)
+}
+
+// Return the half-size of the box along each axis.
+func (this AABB) Extents() Vec3 {
+	return this.Max.Sub(this.Min).MultScalar(0.5)
+}
+
+// Return the size of the box along each axis.
+func (this AABB) Size() Vec3 {
+	return this.Max.Sub(this.Min)
+}
+
+// Return the 8 corners of the box.
+// Bit 0 of the index picks Max.X, bit 1 Max.Y and bit 2 Max.Z.
+func (this AABB) Corners() (out [8]Vec3) {
+	for k := range out {
+		out[k] = this.Min
+		if k&1 != 0 {
+			out[k].X = this.Max.X
+		}
+		if k&2 != 0 {
+			out[k].Y = this.Max.Y
+		}
+		if k&4 != 0 {
+			out[k].Z = this.Max.Z
+		}
+	}
+	return
+}
+
+// Return true if the point is inside or on the box.
+func (this AABB) Contains(p Vec3) bool {
+	return p.X >= this.Min.X && p.X <= this.Max.X &&
+		p.Y >= this.Min.Y && p.Y <= this.Max.Y &&
+		p.Z >= this.Min.Z && p.Z <= this.Max.Z
+}
+
+// Return true if the other box is completely inside this box.
+func (this AABB) ContainsAABB(other AABB) bool {
+	return this.Contains(other.Min) && this.Contains(other.Max)
+}
+
+// Return the point in the box which is closest to p.
+// Points inside the box are returned unchanged.
+func (this AABB) ClosestPoint(p Vec3) Vec3 {
+	return Vec3{
+		Clamp(p.X, this.Min.X, this.Max.X),
+		Clamp(p.Y, this.Min.Y, this.Max.Y),
+		Clamp(p.Z, this.Min.Z, this.Max.Z),
+	}
+}
+
+// Return the distance from p to the box. Zero when p is inside.
+func (this AABB) Distance(p Vec3) float64 {
+	return p.Sub(this.ClosestPoint(p)).Length()
+}
+
+// Return the smallest box containing both boxes.
+func (this AABB) Merge(other AABB) AABB {
+	return AABB{minVec3(this.Min, other.Min), maxVec3(this.Max, other.Max)}
+}
+
+// Return the smallest box containing this box and the point.
+func (this AABB) Expand(p Vec3) AABB {
+	return AABB{minVec3(this.Min, p), maxVec3(this.Max, p)}
+}
+
+// Return the axis aligned box which bounds this box transformed by the matrix.
+func (this AABB) Transform(m Mat4) AABB {
+	// Reference : Jim Arvo, "Transforming Axis-Aligned Bounding Boxes",
+	// Graphics Gems 1990
+	// The extents are transformed by the absolute value of the upper 3x3.
+	c := m.MultVec3(this.Center())
+	e := this.Extents()
+	var out Vec3
+	out.Set(
+		math.Abs(m.Get(0, 0))*e.X+math.Abs(m.Get(0, 1))*e.Y+math.Abs(m.Get(0, 2))*e.Z,
+		math.Abs(m.Get(1, 0))*e.X+math.Abs(m.Get(1, 1))*e.Y+math.Abs(m.Get(1, 2))*e.Z,
+		math.Abs(m.Get(2, 0))*e.X+math.Abs(m.Get(2, 1))*e.Y+math.Abs(m.Get(2, 2))*e.Z,
+	)
+	return AABB{c.Sub(out), c.Add(out)}
+}
+
+// Return the axis aligned box which bounds this box rotated about the origin
+// by the quaternion.
+func (this AABB) Rotate(q Quat) AABB {
+	return this.Transform(q.Mat4())
+}
+
+// Return the component-wise minimum of the two vectors
+func minVec3(a, b Vec3) Vec3 {
+	return Vec3{math.Min(a.X, b.X), math.Min(a.Y, b.Y), math.Min(a.Z, b.Z)}
+}
+
+// Return the component-wise maximum of the two vectors
+func maxVec3(a, b Vec3) Vec3 {
+	return Vec3{math.Max(a.X, b.X), math.Max(a.Y, b.Y), math.Max(a.Z, b.Z)}
+}
+
+// Return true if no element is NaN or infinite.
+func (this AABB) IsFinite() bool {
+	return this.Min.IsFinite() && this.Max.IsFinite()
+}
+
+// Return true if any element is NaN.
+func (this AABB) HasNaN() bool {
+	return this.Min.HasNaN() || this.Max.HasNaN()
+}
+
+// Equal if both corners are equal within the tolerance.
+func (this AABB) ApproxEq(other AABB, tol Tolerance) bool {
+	return this.Min.ApproxEq(other.Min, tol) && this.Max.ApproxEq(other.Max, tol)
+}
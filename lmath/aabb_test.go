@@ -0,0 +1,102 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFromPointsAABB(t *testing.T) {
+	get := AABBFromPoints(Vec3{1, 2, 3}, Vec3{-1, 5, 0}, Vec3{0, 0, 4})
+	if !get.Min.Eq(Vec3{-1, 0, 0}) || !get.Max.Eq(Vec3{1, 5, 4}) {
+		t.Errorf("TestFromPointsAABB %v", get)
+	}
+	if !get.Center().Eq(Vec3{0, 2.5, 2}) || !get.Extents().Eq(Vec3{1, 2.5, 2}) ||
+		!get.Size().Eq(Vec3{2, 5, 4}) {
+		t.Errorf("TestFromPointsAABB center %v", get)
+	}
+
+	corners := get.Corners()
+	if !corners[0].Eq(get.Min) || !corners[7].Eq(get.Max) || !corners[5].Eq(Vec3{1, 0, 4}) {
+		t.Errorf("TestFromPointsAABB corners %v", corners)
+	}
+	if get := AABBFromPoints(); get != (AABB{}) {
+		t.Errorf("TestFromPointsAABB empty %v", get)
+	}
+}
+
+func TestContainsAABB(t *testing.T) {
+	box := AABB{Vec3{-1, -1, -1}, Vec3{1, 2, 3}}
+	cases := []struct {
+		p       Vec3
+		inside  bool
+		dist    float64
+		closest Vec3
+	}{
+		{Vec3{0, 0, 0}, true, 0, Vec3{0, 0, 0}},
+		{Vec3{1, 2, 3}, true, 0, Vec3{1, 2, 3}},
+		{Vec3{3, 0, 0}, false, 2, Vec3{1, 0, 0}},
+		{Vec3{0, -3, 0}, false, 2, Vec3{0, -1, 0}},
+		{Vec3{4, 6, 3}, false, 5, Vec3{1, 2, 3}},
+	}
+
+	for testIndex, c := range cases {
+		if get := box.Contains(c.p); get != c.inside {
+			t.Errorf("TestContainsAABB %d %v", testIndex, get)
+		}
+		if get := box.Distance(c.p); !closeEq(get, c.dist, epsilon) {
+			t.Errorf("TestContainsAABB dist %d %v", testIndex, get)
+		}
+		if get := box.ClosestPoint(c.p); !get.Eq(c.closest) {
+			t.Errorf("TestContainsAABB closest %d %v", testIndex, get)
+		}
+	}
+
+	if !box.ContainsAABB(AABB{Vec3{0, 0, 0}, Vec3{1, 1, 1}}) ||
+		box.ContainsAABB(AABB{Vec3{0, 0, 0}, Vec3{2, 1, 1}}) {
+		t.Errorf("TestContainsAABB box")
+	}
+}
+
+func TestMergeAABB(t *testing.T) {
+	a := AABB{Vec3{0, 0, 0}, Vec3{1, 1, 1}}
+	b := AABB{Vec3{-2, 0.5, 0.5}, Vec3{0.5, 3, 0.7}}
+	get := a.Merge(b)
+	if !get.Min.Eq(Vec3{-2, 0, 0}) || !get.Max.Eq(Vec3{1, 3, 1}) {
+		t.Errorf("TestMergeAABB %v", get)
+	}
+	if get := a.Expand(Vec3{2, -1, 0.5}); !get.Min.Eq(Vec3{0, -1, 0}) || !get.Max.Eq(Vec3{2, 1, 1}) {
+		t.Errorf("TestMergeAABB expand %v", get)
+	}
+}
+
+func TestTransformAABB(t *testing.T) {
+	box := AABB{Vec3{-1, -2, -3}, Vec3{1, 2, 3}}
+
+	var m, r, tr Mat4
+	tr.ToTranslate(5, 0, 0)
+	m.ToScale(2, 1, 1)
+	get := box.Transform(tr.Mult(m))
+	if !get.Min.Eq(Vec3{3, -2, -3}) || !get.Max.Eq(Vec3{7, 2, 3}) {
+		t.Errorf("TestTransformAABB %v", get)
+	}
+
+	// The result must bound every transformed corner and touch the bounds
+	r.FromEuler(0.3, 0.8, -1.1)
+	m = tr.Mult(r)
+	get = box.Transform(m)
+	var points []Vec3
+	for _, c := range box.Corners() {
+		points = append(points, m.MultVec3(c))
+	}
+	want := AABBFromPoints(points...)
+	if !get.Min.Eq(want.Min) || !get.Max.Eq(want.Max) {
+		t.Errorf("TestTransformAABB rotate %v %v", get, want)
+	}
+
+	var q Quat
+	q.FromAxisAngle(math.Pi/2, 0, 0, 1)
+	get = box.Rotate(q)
+	if !get.Min.Eq(Vec3{-2, -1, -3}) || !get.Max.Eq(Vec3{2, 1, 3}) {
+		t.Errorf("TestTransformAABB quat %v", get)
+	}
+}
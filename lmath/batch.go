@@ -0,0 +1,114 @@
+package lmath
+
+import (
+	"runtime"
+	"sync"
+)
+
+// This file holds the batch versions of the vector transforms. The loops
+// don't allocate and take the matrix by pointer so it is only read once.
+//
+// dst must be at least as long as src and may be the same slice as src to
+// transform in place. The Parallel versions split large inputs across
+// goroutines and fall back to the serial loop for small ones.
+
+// Inputs shorter than this are not worth splitting across goroutines
+const parallelChunk = 8192
+
+// Transform every point in src by the matrix, storing the results in dst.
+// Each point is treated as [x,y,z,1] and divided by w, see TransformPoint.
+func (this *Mat4) TransformPoints(dst, src []Vec3) {
+	m := &this.mat
+	dst = dst[:len(src)]
+	for i, p := range src {
+		x := m[0]*p.X + m[1]*p.Y + m[2]*p.Z + m[3]
+		y := m[4]*p.X + m[5]*p.Y + m[6]*p.Z + m[7]
+		z := m[8]*p.X + m[9]*p.Y + m[10]*p.Z + m[11]
+		w := m[12]*p.X + m[13]*p.Y + m[14]*p.Z + m[15]
+		if w != 1 {
+			x, y, z = x/w, y/w, z/w
+		}
+		dst[i] = Vec3{x, y, z}
+	}
+}
+
+// Multiply every vector in src by the matrix ( ie. result = Matrix * Vec),
+// storing the results in dst.
+func (this *Mat4) MultVec4s(dst, src []Vec4) {
+	m := &this.mat
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = Vec4{
+			m[0]*v.X + m[1]*v.Y + m[2]*v.Z + m[3]*v.W,
+			m[4]*v.X + m[5]*v.Y + m[6]*v.Z + m[7]*v.W,
+			m[8]*v.X + m[9]*v.Y + m[10]*v.Z + m[11]*v.W,
+			m[12]*v.X + m[13]*v.Y + m[14]*v.Z + m[15]*v.W,
+		}
+	}
+}
+
+// Rotate every vector in src by the quaternion, storing the results in dst.
+// Gives the same result as RotateVec3 but builds the rotation matrix once
+// instead of doing two quaternion multiplications per vector.
+func (this Quat) RotateVec3s(dst, src []Vec3) {
+	q := this
+	q.ToUnit()
+	m := q.mat()
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = Vec3{
+			m[0]*v.X + m[1]*v.Y + m[2]*v.Z,
+			m[4]*v.X + m[5]*v.Y + m[6]*v.Z,
+			m[8]*v.X + m[9]*v.Y + m[10]*v.Z,
+		}
+	}
+}
+
+// Same as TransformPoints but splits large inputs across goroutines.
+func (this *Mat4) TransformPointsParallel(dst, src []Vec3) {
+	parallelFor(len(src), func(lo, hi int) {
+		this.TransformPoints(dst[lo:hi], src[lo:hi])
+	})
+}
+
+// Same as MultVec4s but splits large inputs across goroutines.
+func (this *Mat4) MultVec4sParallel(dst, src []Vec4) {
+	parallelFor(len(src), func(lo, hi int) {
+		this.MultVec4s(dst[lo:hi], src[lo:hi])
+	})
+}
+
+// Same as RotateVec3s but splits large inputs across goroutines.
+func (this Quat) RotateVec3sParallel(dst, src []Vec3) {
+	parallelFor(len(src), func(lo, hi int) {
+		this.RotateVec3s(dst[lo:hi], src[lo:hi])
+	})
+}
+
+// Call fn over [0,n) split into contiguous ranges, one per goroutine, and
+// wait for them all to finish. Small inputs are run on the calling goroutine.
+func parallelFor(n int, fn func(lo, hi int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if limit := n / parallelChunk; limit < workers {
+		workers = limit
+	}
+	if workers <= 1 {
+		fn(0, n)
+		return
+	}
+
+	var wg sync.WaitGroup
+	size := (n + workers - 1) / workers
+	for lo := 0; lo < n; lo += size {
+		hi := lo + size
+		if hi > n {
+			hi = n
+		}
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			fn(lo, hi)
+		}(lo, hi)
+	}
+	wg.Wait()
+}
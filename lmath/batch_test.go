@@ -0,0 +1,153 @@
+package lmath
+
+import (
+	"testing"
+)
+
+func batchTestVec3s(n int) []Vec3 {
+	out := make([]Vec3, n)
+	for k := range out {
+		f := float64(k)
+		out[k] = Vec3{f*0.5 - 3, 2 - f*0.25, f * 0.125}
+	}
+	return out
+}
+
+func TestTransformPointsBatch(t *testing.T) {
+	persp := Mat4{}
+	persp.ToPerspective(1, 1.5, 0.1, 100)
+	view := Mat4{}
+	view.ToLookAt(Vec3{1, 2, 30}, Vec3{0, 0, 0}, Vec3{0, 1, 0})
+	trans := Mat4{}
+	trans.ToTranslate(1, 2, 3)
+	cases := []Mat4{trans, persp.Mult(view)}
+
+	src := batchTestVec3s(100)
+	for testIndex, m := range cases {
+		dst := make([]Vec3, len(src))
+		m.TransformPoints(dst, src)
+		for k, p := range src {
+			if !dst[k].Eq(m.TransformPoint(p)) {
+				t.Errorf("TestTransformPointsBatch %d %d %v %v", testIndex, k, dst[k], m.TransformPoint(p))
+				break
+			}
+		}
+
+		// in place
+		inPlace := append([]Vec3{}, src...)
+		m.TransformPoints(inPlace, inPlace)
+		for k := range src {
+			if !inPlace[k].Eq(dst[k]) {
+				t.Errorf("TestTransformPointsBatch in place %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestMultVec4sBatch(t *testing.T) {
+	m := Mat4{}
+	m.Load([16]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	src := []Vec4{{1, 0, 0, 0}, {1, 2, 3, 4}, {-1, 0.5, 2, 1}}
+	dst := make([]Vec4, len(src))
+	m.MultVec4s(dst, src)
+	for k, v := range src {
+		if !dst[k].Eq(m.MultVec4(v)) {
+			t.Errorf("TestMultVec4sBatch %d %v %v", k, dst[k], m.MultVec4(v))
+		}
+	}
+}
+
+func TestRotateVec3sBatch(t *testing.T) {
+	q := Quat{}
+	q.FromEuler(0.3, -1.2, 2)
+	// not unit length, RotateVec3 handles this through the inverse
+	scaled := q.MultScalar(3)
+	src := batchTestVec3s(100)
+
+	for testIndex, c := range []Quat{q, scaled, QuatIdentity} {
+		dst := make([]Vec3, len(src))
+		c.RotateVec3s(dst, src)
+		for k, v := range src {
+			if !dst[k].CloseEq(c.RotateVec3(v), 1e-9) {
+				t.Errorf("TestRotateVec3sBatch %d %d %v %v", testIndex, k, dst[k], c.RotateVec3(v))
+				break
+			}
+		}
+	}
+}
+
+func TestParallelBatch(t *testing.T) {
+	m := Mat4{}
+	m.ToPerspective(1, 1.5, 0.1, 100)
+	q := Quat{}
+	q.FromEuler(0.3, -1.2, 2)
+
+	// large enough to be split, and not a multiple of the chunk size
+	src := batchTestVec3s(5*parallelChunk + 17)
+	want := make([]Vec3, len(src))
+	get := make([]Vec3, len(src))
+
+	m.TransformPoints(want, src)
+	m.TransformPointsParallel(get, src)
+	for k := range want {
+		if get[k] != want[k] {
+			t.Errorf("TestParallelBatch TransformPoints %d", k)
+			break
+		}
+	}
+
+	q.RotateVec3s(want, src)
+	q.RotateVec3sParallel(get, src)
+	for k := range want {
+		if get[k] != want[k] {
+			t.Errorf("TestParallelBatch RotateVec3s %d", k)
+			break
+		}
+	}
+
+	src4 := make([]Vec4, len(src))
+	for k, v := range src {
+		src4[k] = Vec4{v.X, v.Y, v.Z, 1}
+	}
+	want4 := make([]Vec4, len(src))
+	get4 := make([]Vec4, len(src))
+	m.MultVec4s(want4, src4)
+	m.MultVec4sParallel(get4, src4)
+	for k := range want4 {
+		if get4[k] != want4[k] {
+			t.Errorf("TestParallelBatch MultVec4s %d", k)
+			break
+		}
+	}
+
+	// small inputs run inline
+	small := src[:10]
+	m.TransformPointsParallel(get[:10], small)
+	m.TransformPoints(want[:10], small)
+	for k := range small {
+		if get[k] != want[k] {
+			t.Errorf("TestParallelBatch small %d", k)
+		}
+	}
+}
+
+func TestNoAllocBatch(t *testing.T) {
+	m := Mat4{}
+	m.ToPerspective(1, 1.5, 0.1, 100)
+	q := Quat{}
+	q.FromEuler(0.3, -1.2, 2)
+	src := batchTestVec3s(1000)
+	dst := make([]Vec3, len(src))
+	src4 := make([]Vec4, len(src))
+	dst4 := make([]Vec4, len(src))
+
+	allocs := testing.AllocsPerRun(10, func() {
+		m.TransformPoints(dst, src)
+		m.MultVec4s(dst4, src4)
+		q.RotateVec3s(dst, src)
+	})
+	if allocs != 0 {
+		t.Errorf("TestNoAllocBatch %v", allocs)
+	}
+}
@@ -0,0 +1,380 @@
+package lmath
+
+import (
+	"encoding/binary"
+	"math"
+	"unsafe"
+)
+
+// This file holds bulk conversions between slices of vectors and the flat
+// float32 and byte buffers uploaded to the GPU.
+//
+// The Put functions write into a pre-allocated destination and the Get
+// functions read back out of one, so neither allocates. Every element is
+// written at offset + i*stride, which allows interleaved layouts. For example
+// a vertex made of a position, normal and uv is 8 float32s (32 bytes), so the
+// positions are written with offset 0, the normals with offset 3 (12 bytes) and
+// the uvs with offset 6 (24 bytes), all with a stride of 8 (32 bytes). A stride
+// of 0 means the elements are tightly packed.
+//
+// Byte buffers hold float32 values in the given byte order, usually
+// binary.LittleEndian (or binary.NativeEndian on Go 1.21 and newer).
+
+// =============================================================================
+
+// Write the vectors into dst as float32 values, starting at offset and
+// advancing by stride float32s for each vector. dst must be large enough.
+func PutVec2sFloat32(dst []float32, offset, stride int, src []Vec2) {
+	if stride == 0 {
+		stride = 2
+	}
+	for i, v := range src {
+		k := offset + i*stride
+		dst[k] = float32(v.X)
+		dst[k+1] = float32(v.Y)
+	}
+}
+
+// Read len(dst) vectors out of the float32 values in src, starting at offset
+// and advancing by stride float32s for each vector.
+func GetVec2sFloat32(dst []Vec2, src []float32, offset, stride int) {
+	if stride == 0 {
+		stride = 2
+	}
+	for i := range dst {
+		k := offset + i*stride
+		dst[i] = Vec2{float64(src[k]), float64(src[k+1])}
+	}
+}
+
+// Append the vectors to dst as tightly packed float32 values.
+// Returns the extended slice, which only allocates when dst is out of capacity.
+func AppendVec2sFloat32(dst []float32, src []Vec2) []float32 {
+	for _, v := range src {
+		dst = append(dst, float32(v.X), float32(v.Y))
+	}
+	return dst
+}
+
+// Write the vectors into dst as float32 values in the byte order, starting
+// at the byte offset and advancing by stride bytes for each vector.
+// dst must be large enough.
+func PutVec2sBytes(dst []byte, order binary.ByteOrder, offset, stride int, src []Vec2) {
+	if stride == 0 {
+		stride = 8
+	}
+	for i, v := range src {
+		k := offset + i*stride
+		order.PutUint32(dst[k:], math.Float32bits(float32(v.X)))
+		order.PutUint32(dst[k+4:], math.Float32bits(float32(v.Y)))
+	}
+}
+
+// Read len(dst) vectors out of the float32 values in src stored in the byte
+// order, starting at the byte offset and advancing by stride bytes for each
+// vector.
+func GetVec2sBytes(dst []Vec2, src []byte, order binary.ByteOrder, offset, stride int) {
+	if stride == 0 {
+		stride = 8
+	}
+	for i := range dst {
+		k := offset + i*stride
+		dst[i] = Vec2{
+			float64(math.Float32frombits(order.Uint32(src[k:]))),
+			float64(math.Float32frombits(order.Uint32(src[k+4:]))),
+		}
+	}
+}
+
+// Append the vectors to dst as tightly packed float32 values in the byte order.
+// Returns the extended slice, which only allocates when dst is out of capacity.
+func AppendVec2sBytes(dst []byte, order binary.ByteOrder, src []Vec2) []byte {
+	k := len(dst)
+	dst = growBytes(dst, len(src)*8)
+	PutVec2sBytes(dst, order, k, 8, src)
+	return dst
+}
+
+// =============================================================================
+
+// Write the vectors into dst as float32 values, starting at offset and
+// advancing by stride float32s for each vector. dst must be large enough.
+func PutVec3sFloat32(dst []float32, offset, stride int, src []Vec3) {
+	if stride == 0 {
+		stride = 3
+	}
+	for i, v := range src {
+		k := offset + i*stride
+		dst[k] = float32(v.X)
+		dst[k+1] = float32(v.Y)
+		dst[k+2] = float32(v.Z)
+	}
+}
+
+// Read len(dst) vectors out of the float32 values in src, starting at offset
+// and advancing by stride float32s for each vector.
+func GetVec3sFloat32(dst []Vec3, src []float32, offset, stride int) {
+	if stride == 0 {
+		stride = 3
+	}
+	for i := range dst {
+		k := offset + i*stride
+		dst[i] = Vec3{float64(src[k]), float64(src[k+1]), float64(src[k+2])}
+	}
+}
+
+// Append the vectors to dst as tightly packed float32 values.
+// Returns the extended slice, which only allocates when dst is out of capacity.
+func AppendVec3sFloat32(dst []float32, src []Vec3) []float32 {
+	for _, v := range src {
+		dst = append(dst, float32(v.X), float32(v.Y), float32(v.Z))
+	}
+	return dst
+}
+
+// Write the vectors into dst as float32 values in the byte order, starting
+// at the byte offset and advancing by stride bytes for each vector.
+// dst must be large enough.
+func PutVec3sBytes(dst []byte, order binary.ByteOrder, offset, stride int, src []Vec3) {
+	if stride == 0 {
+		stride = 12
+	}
+	for i, v := range src {
+		k := offset + i*stride
+		order.PutUint32(dst[k:], math.Float32bits(float32(v.X)))
+		order.PutUint32(dst[k+4:], math.Float32bits(float32(v.Y)))
+		order.PutUint32(dst[k+8:], math.Float32bits(float32(v.Z)))
+	}
+}
+
+// Read len(dst) vectors out of the float32 values in src stored in the byte
+// order, starting at the byte offset and advancing by stride bytes for each
+// vector.
+func GetVec3sBytes(dst []Vec3, src []byte, order binary.ByteOrder, offset, stride int) {
+	if stride == 0 {
+		stride = 12
+	}
+	for i := range dst {
+		k := offset + i*stride
+		dst[i] = Vec3{
+			float64(math.Float32frombits(order.Uint32(src[k:]))),
+			float64(math.Float32frombits(order.Uint32(src[k+4:]))),
+			float64(math.Float32frombits(order.Uint32(src[k+8:]))),
+		}
+	}
+}
+
+// Append the vectors to dst as tightly packed float32 values in the byte order.
+// Returns the extended slice, which only allocates when dst is out of capacity.
+func AppendVec3sBytes(dst []byte, order binary.ByteOrder, src []Vec3) []byte {
+	k := len(dst)
+	dst = growBytes(dst, len(src)*12)
+	PutVec3sBytes(dst, order, k, 12, src)
+	return dst
+}
+
+// =============================================================================
+
+// Write the vectors into dst as float32 values, starting at offset and
+// advancing by stride float32s for each vector. dst must be large enough.
+func PutVec4sFloat32(dst []float32, offset, stride int, src []Vec4) {
+	if stride == 0 {
+		stride = 4
+	}
+	for i, v := range src {
+		k := offset + i*stride
+		dst[k] = float32(v.X)
+		dst[k+1] = float32(v.Y)
+		dst[k+2] = float32(v.Z)
+		dst[k+3] = float32(v.W)
+	}
+}
+
+// Read len(dst) vectors out of the float32 values in src, starting at offset
+// and advancing by stride float32s for each vector.
+func GetVec4sFloat32(dst []Vec4, src []float32, offset, stride int) {
+	if stride == 0 {
+		stride = 4
+	}
+	for i := range dst {
+		k := offset + i*stride
+		dst[i] = Vec4{float64(src[k]), float64(src[k+1]), float64(src[k+2]), float64(src[k+3])}
+	}
+}
+
+// Append the vectors to dst as tightly packed float32 values.
+// Returns the extended slice, which only allocates when dst is out of capacity.
+func AppendVec4sFloat32(dst []float32, src []Vec4) []float32 {
+	for _, v := range src {
+		dst = append(dst, float32(v.X), float32(v.Y), float32(v.Z), float32(v.W))
+	}
+	return dst
+}
+
+// Write the vectors into dst as float32 values in the byte order, starting
+// at the byte offset and advancing by stride bytes for each vector.
+// dst must be large enough.
+func PutVec4sBytes(dst []byte, order binary.ByteOrder, offset, stride int, src []Vec4) {
+	if stride == 0 {
+		stride = 16
+	}
+	for i, v := range src {
+		k := offset + i*stride
+		order.PutUint32(dst[k:], math.Float32bits(float32(v.X)))
+		order.PutUint32(dst[k+4:], math.Float32bits(float32(v.Y)))
+		order.PutUint32(dst[k+8:], math.Float32bits(float32(v.Z)))
+		order.PutUint32(dst[k+12:], math.Float32bits(float32(v.W)))
+	}
+}
+
+// Read len(dst) vectors out of the float32 values in src stored in the byte
+// order, starting at the byte offset and advancing by stride bytes for each
+// vector.
+func GetVec4sBytes(dst []Vec4, src []byte, order binary.ByteOrder, offset, stride int) {
+	if stride == 0 {
+		stride = 16
+	}
+	for i := range dst {
+		k := offset + i*stride
+		dst[i] = Vec4{
+			float64(math.Float32frombits(order.Uint32(src[k:]))),
+			float64(math.Float32frombits(order.Uint32(src[k+4:]))),
+			float64(math.Float32frombits(order.Uint32(src[k+8:]))),
+			float64(math.Float32frombits(order.Uint32(src[k+12:]))),
+		}
+	}
+}
+
+// Append the vectors to dst as tightly packed float32 values in the byte order.
+// Returns the extended slice, which only allocates when dst is out of capacity.
+func AppendVec4sBytes(dst []byte, order binary.ByteOrder, src []Vec4) []byte {
+	k := len(dst)
+	dst = growBytes(dst, len(src)*16)
+	PutVec4sBytes(dst, order, k, 16, src)
+	return dst
+}
+
+// Extend the length of the byte slice by n, reusing the capacity when possible.
+func growBytes(b []byte, n int) []byte {
+	if len(b)+n <= cap(b) {
+		return b[:len(b)+n]
+	}
+	return append(b, make([]byte, n)...)
+}
+
+// =============================================================================
+// The float32 vector types have the same layout as a flat float32 array, so
+// they can be viewed as one without copying. The views share memory with the
+// original slice. They are built with unsafe.Slice, which needs Go 1.17 or
+// newer.
+
+// Return a view of the vectors as a flat []float32, without copying.
+func Vec2fsAsFloat32(v []Vec2f) []float32 {
+	if len(v) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*float32)(unsafe.Pointer(&v[0])), len(v)*2)
+}
+
+// Return a view of the vectors as []byte in the native byte order, without
+// copying.
+func Vec2fsAsBytes(v []Vec2f) []byte {
+	if len(v) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&v[0])), len(v)*8)
+}
+
+// Return a view of the flat float32 values as vectors, without copying.
+// Trailing values which don't make up a whole vector are left out.
+func Float32sAsVec2fs(f []float32) []Vec2f {
+	if len(f) < 2 {
+		return nil
+	}
+	return unsafe.Slice((*Vec2f)(unsafe.Pointer(&f[0])), len(f)/2)
+}
+
+// Return a view of the bytes as vectors, without copying. The bytes must hold
+// float32 values in the native byte order and start on a 4 byte boundary,
+// otherwise nil is returned and GetVec2sBytes has to be used instead.
+// Trailing bytes which don't make up a whole vector are left out.
+func BytesAsVec2fs(b []byte) []Vec2f {
+	if len(b) < 8 || uintptr(unsafe.Pointer(&b[0]))%4 != 0 {
+		return nil
+	}
+	return unsafe.Slice((*Vec2f)(unsafe.Pointer(&b[0])), len(b)/8)
+}
+
+// Return a view of the vectors as a flat []float32, without copying.
+func Vec3fsAsFloat32(v []Vec3f) []float32 {
+	if len(v) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*float32)(unsafe.Pointer(&v[0])), len(v)*3)
+}
+
+// Return a view of the vectors as []byte in the native byte order, without
+// copying.
+func Vec3fsAsBytes(v []Vec3f) []byte {
+	if len(v) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&v[0])), len(v)*12)
+}
+
+// Return a view of the flat float32 values as vectors, without copying.
+// Trailing values which don't make up a whole vector are left out.
+func Float32sAsVec3fs(f []float32) []Vec3f {
+	if len(f) < 3 {
+		return nil
+	}
+	return unsafe.Slice((*Vec3f)(unsafe.Pointer(&f[0])), len(f)/3)
+}
+
+// Return a view of the bytes as vectors, without copying. The bytes must hold
+// float32 values in the native byte order and start on a 4 byte boundary,
+// otherwise nil is returned and GetVec3sBytes has to be used instead.
+// Trailing bytes which don't make up a whole vector are left out.
+func BytesAsVec3fs(b []byte) []Vec3f {
+	if len(b) < 12 || uintptr(unsafe.Pointer(&b[0]))%4 != 0 {
+		return nil
+	}
+	return unsafe.Slice((*Vec3f)(unsafe.Pointer(&b[0])), len(b)/12)
+}
+
+// Return a view of the vectors as a flat []float32, without copying.
+func Vec4fsAsFloat32(v []Vec4f) []float32 {
+	if len(v) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*float32)(unsafe.Pointer(&v[0])), len(v)*4)
+}
+
+// Return a view of the vectors as []byte in the native byte order, without
+// copying.
+func Vec4fsAsBytes(v []Vec4f) []byte {
+	if len(v) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&v[0])), len(v)*16)
+}
+
+// Return a view of the flat float32 values as vectors, without copying.
+// Trailing values which don't make up a whole vector are left out.
+func Float32sAsVec4fs(f []float32) []Vec4f {
+	if len(f) < 4 {
+		return nil
+	}
+	return unsafe.Slice((*Vec4f)(unsafe.Pointer(&f[0])), len(f)/4)
+}
+
+// Return a view of the bytes as vectors, without copying. The bytes must hold
+// float32 values in the native byte order and start on a 4 byte boundary,
+// otherwise nil is returned and GetVec4sBytes has to be used instead.
+// Trailing bytes which don't make up a whole vector are left out.
+func BytesAsVec4fs(b []byte) []Vec4f {
+	if len(b) < 16 || uintptr(unsafe.Pointer(&b[0]))%4 != 0 {
+		return nil
+	}
+	return unsafe.Slice((*Vec4f)(unsafe.Pointer(&b[0])), len(b)/16)
+}
@@ -0,0 +1,164 @@
+package lmath
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestPutGetFloat32Buffer(t *testing.T) {
+	pos := []Vec3{{1, 2, 3}, {4, 5, 6}, {-1, 0.5, 0.25}}
+	normal := []Vec3{{0, 1, 0}, {1, 0, 0}, {0, 0, -1}}
+	uv := []Vec2{{0, 0}, {1, 0}, {0.5, 1}}
+
+	// interleaved position, normal, uv
+	buf := make([]float32, 8*len(pos))
+	PutVec3sFloat32(buf, 0, 8, pos)
+	PutVec3sFloat32(buf, 3, 8, normal)
+	PutVec2sFloat32(buf, 6, 8, uv)
+
+	want := []float32{
+		1, 2, 3, 0, 1, 0, 0, 0,
+		4, 5, 6, 1, 0, 0, 1, 0,
+		-1, 0.5, 0.25, 0, 0, -1, 0.5, 1,
+	}
+	for k := range want {
+		if buf[k] != want[k] {
+			t.Errorf("TestPutGetFloat32Buffer %d %v\n%v", k, buf, want)
+			break
+		}
+	}
+
+	getPos := make([]Vec3, len(pos))
+	getNormal := make([]Vec3, len(normal))
+	getUV := make([]Vec2, len(uv))
+	GetVec3sFloat32(getPos, buf, 0, 8)
+	GetVec3sFloat32(getNormal, buf, 3, 8)
+	GetVec2sFloat32(getUV, buf, 6, 8)
+	for k := range pos {
+		if !getPos[k].Eq(pos[k]) || !getNormal[k].Eq(normal[k]) || !getUV[k].Eq(uv[k]) {
+			t.Errorf("TestPutGetFloat32Buffer %d %v %v %v", k, getPos[k], getNormal[k], getUV[k])
+		}
+	}
+
+	// stride 0 is tightly packed, the same as append
+	colors := []Vec4{{1, 0, 0, 1}, {0, 0.5, 1, 0}}
+	packed := make([]float32, 8)
+	PutVec4sFloat32(packed, 0, 0, colors)
+	appended := AppendVec4sFloat32(nil, colors)
+	getColors := make([]Vec4, 2)
+	GetVec4sFloat32(getColors, appended, 0, 0)
+	for k := range packed {
+		if packed[k] != appended[k] {
+			t.Errorf("TestPutGetFloat32Buffer packed %v %v", packed, appended)
+			break
+		}
+	}
+	if !getColors[0].Eq(colors[0]) || !getColors[1].Eq(colors[1]) {
+		t.Errorf("TestPutGetFloat32Buffer colors %v", getColors)
+	}
+}
+
+func TestPutGetBytesBuffer(t *testing.T) {
+	pos := []Vec3{{1, 2, 3}, {4, 5, 6}}
+	uv := []Vec2{{0.5, 1}, {0, 0.25}}
+	orders := []binary.ByteOrder{binary.LittleEndian, binary.BigEndian}
+
+	for testIndex, order := range orders {
+		// interleaved position, uv
+		buf := make([]byte, 20*len(pos))
+		PutVec3sBytes(buf, order, 0, 20, pos)
+		PutVec2sBytes(buf, order, 12, 20, uv)
+
+		for k, v := range []float32{1, 2, 3, 0.5, 1, 4, 5, 6, 0, 0.25} {
+			if get := math.Float32frombits(order.Uint32(buf[k*4:])); get != v {
+				t.Errorf("TestPutGetBytesBuffer %d %d %v %v", testIndex, k, get, v)
+			}
+		}
+
+		getPos := make([]Vec3, len(pos))
+		getUV := make([]Vec2, len(uv))
+		GetVec3sBytes(getPos, buf, order, 0, 20)
+		GetVec2sBytes(getUV, buf, order, 12, 20)
+		for k := range pos {
+			if !getPos[k].Eq(pos[k]) || !getUV[k].Eq(uv[k]) {
+				t.Errorf("TestPutGetBytesBuffer %d %v %v", testIndex, getPos[k], getUV[k])
+			}
+		}
+
+		colors := []Vec4{{1, 0, 0, 1}, {0, 0.5, 1, 0}}
+		appended := AppendVec4sBytes([]byte{9}, order, colors)
+		getColors := make([]Vec4, 2)
+		GetVec4sBytes(getColors, appended, order, 1, 0)
+		if len(appended) != 33 || appended[0] != 9 || !getColors[0].Eq(colors[0]) || !getColors[1].Eq(colors[1]) {
+			t.Errorf("TestPutGetBytesBuffer %d append %v", testIndex, getColors)
+		}
+	}
+}
+
+func TestAppendNoAllocBuffer(t *testing.T) {
+	pos := make([]Vec3, 100)
+	floats := make([]float32, 0, 300)
+	bytes := make([]byte, 0, 1200)
+	allocs := testing.AllocsPerRun(10, func() {
+		floats = AppendVec3sFloat32(floats[:0], pos)
+		bytes = AppendVec3sBytes(bytes[:0], binary.LittleEndian, pos)
+		PutVec3sFloat32(floats, 0, 0, pos)
+		GetVec3sFloat32(pos, floats, 0, 0)
+	})
+	if allocs != 0 {
+		t.Errorf("TestAppendNoAllocBuffer %v", allocs)
+	}
+}
+
+func TestViewBuffer(t *testing.T) {
+	v := []Vec3f{{1, 2, 3}, {4, 5, 6}}
+	f := Vec3fsAsFloat32(v)
+	if len(f) != 6 || f[3] != 4 {
+		t.Errorf("TestViewBuffer %v", f)
+	}
+	// views share memory
+	f[4] = 10
+	if v[1].Y != 10 {
+		t.Errorf("TestViewBuffer shared %v", v)
+	}
+	b := Vec3fsAsBytes(v)
+	if len(b) != 24 {
+		t.Errorf("TestViewBuffer bytes %v", b)
+	}
+	// and back again without copying
+	if get := BytesAsVec3fs(b); len(get) != 2 || get[1] != v[1] {
+		t.Errorf("TestViewBuffer bytes back %v", get)
+	}
+	BytesAsVec3fs(b)[0].Z = 7
+	if v[0].Z != 7 {
+		t.Errorf("TestViewBuffer bytes shared %v", v)
+	}
+	// unaligned bytes can't be viewed as float32s
+	if BytesAsVec3fs(b[1:]) != nil || BytesAsVec2fs(b[:7]) != nil {
+		t.Errorf("TestViewBuffer bytes unaligned")
+	}
+
+	back := Float32sAsVec3fs([]float32{1, 2, 3, 4, 5, 6, 7})
+	if len(back) != 2 || back[1] != (Vec3f{4, 5, 6}) {
+		t.Errorf("TestViewBuffer back %v", back)
+	}
+	if Vec2fsAsFloat32(nil) != nil || Float32sAsVec4fs([]float32{1, 2}) != nil {
+		t.Errorf("TestViewBuffer empty")
+	}
+
+	v4 := []Vec4f{{1, 2, 3, 4}}
+	if get := Float32sAsVec4fs(Vec4fsAsFloat32(v4)); len(get) != 1 || get[0] != v4[0] {
+		t.Errorf("TestViewBuffer vec4 %v", get)
+	}
+	v2 := []Vec2f{{1, 2}, {3, 4}}
+	if get := Float32sAsVec2fs(Vec2fsAsFloat32(v2)); len(get) != 2 || get[1] != v2[1] || len(Vec2fsAsBytes(v2)) != 16 {
+		t.Errorf("TestViewBuffer vec2 %v", get)
+	}
+	if get := BytesAsVec4fs(Vec4fsAsBytes(v4)); len(get) != 1 || get[0] != v4[0] {
+		t.Errorf("TestViewBuffer vec4 bytes %v", get)
+	}
+	if get := BytesAsVec2fs(Vec2fsAsBytes(v2)); len(get) != 2 || get[0] != v2[0] {
+		t.Errorf("TestViewBuffer vec2 bytes %v", get)
+	}
+}
@@ -0,0 +1,93 @@
+package lmath
+
+import (
+	"math"
+)
+
+// A capsule is all the points within Radius of the segment from A to B.
+type Capsule struct {
+	A, B   Vec3
+	Radius float64
+}
+
+// Return the segment running through the middle of the capsule.
+func (this Capsule) Segment() Segment {
+	return Segment{this.A, this.B}
+}
+
+// Return true if the point is inside or on the capsule.
+func (this Capsule) Contains(p Vec3) bool {
+	return this.Segment().Distance(p) <= this.Radius
+}
+
+// Return true if the sphere is completely inside the capsule.
+func (this Capsule) ContainsSphere(s Sphere) bool {
+	return this.Segment().Distance(s.Center)+s.Radius <= this.Radius
+}
+
+// Return the point in the capsule which is closest to p.
+// Points inside the capsule are returned unchanged.
+func (this Capsule) ClosestPoint(p Vec3) Vec3 {
+	c := this.Segment().ClosestPoint(p)
+	return Sphere{c, this.Radius}.ClosestPoint(p)
+}
+
+// Return the distance from p to the capsule. Zero when p is inside.
+func (this Capsule) Distance(p Vec3) float64 {
+	return math.Max(0, this.Segment().Distance(p)-this.Radius)
+}
+
+// Return a capsule containing both capsules.
+// The segment joins the two end points furthest apart and the radius grows
+// to cover both capsules. The result is not guaranteed to be the smallest
+// such capsule.
+func (this Capsule) Merge(other Capsule) Capsule {
+	points := [4]Vec3{this.A, this.B, other.A, other.B}
+	out := Capsule{this.A, this.B, 0}
+	best := -1.0
+	for i := 0; i < len(points); i++ {
+		for j := i + 1; j < len(points); j++ {
+			if d := points[i].Sub(points[j]).LengthSq(); d > best {
+				best = d
+				out.A, out.B = points[i], points[j]
+			}
+		}
+	}
+
+	// The distance to a segment is convex, so covering the end points of
+	// each inner segment covers the whole capsule.
+	seg := out.Segment()
+	out.Radius = math.Max(
+		math.Max(seg.Distance(this.A), seg.Distance(this.B))+this.Radius,
+		math.Max(seg.Distance(other.A), seg.Distance(other.B))+other.Radius)
+	return out
+}
+
+// Return a new capsule transformed by the matrix.
+// With non-uniform scaling the radius grows by the largest scale so the
+// result still bounds the transformed capsule.
+func (this Capsule) Transform(m Mat4) Capsule {
+	return Capsule{m.MultVec3(this.A), m.MultVec3(this.B), this.Radius * maxScale(m)}
+}
+
+// Return a new capsule rotated about the origin by the quaternion.
+func (this Capsule) Rotate(q Quat) Capsule {
+	return Capsule{q.RotateVec3(this.A), q.RotateVec3(this.B), this.Radius}
+}
+
+// Return true if no element is NaN or infinite.
+func (this Capsule) IsFinite() bool {
+	return this.A.IsFinite() && this.B.IsFinite() && isFinite(this.Radius)
+}
+
+// Return true if any element is NaN.
+func (this Capsule) HasNaN() bool {
+	return this.A.HasNaN() || this.B.HasNaN() || math.IsNaN(this.Radius)
+}
+
+// Equal if the end points and radius are equal within the tolerance.
+func (this Capsule) ApproxEq(other Capsule, tol Tolerance) bool {
+	return this.A.ApproxEq(other.A, tol) &&
+		this.B.ApproxEq(other.B, tol) &&
+		tol.Eq(this.Radius, other.Radius)
+}
@@ -0,0 +1,87 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestContainsCapsule(t *testing.T) {
+	c := Capsule{Vec3{0, 0, 0}, Vec3{0, 4, 0}, 1}
+	cases := []struct {
+		p       Vec3
+		inside  bool
+		dist    float64
+		closest Vec3
+	}{
+		{Vec3{0, 2, 0}, true, 0, Vec3{0, 2, 0}},
+		{Vec3{1, 2, 0}, true, 0, Vec3{1, 2, 0}},
+		{Vec3{0, 5, 0}, true, 0, Vec3{0, 5, 0}},
+		{Vec3{3, 2, 0}, false, 2, Vec3{1, 2, 0}},
+		{Vec3{0, -3, 0}, false, 2, Vec3{0, -1, 0}},
+		{Vec3{0, 7, -4}, false, 4, Vec3{0, 4 + 0.6, -0.8}},
+	}
+
+	for testIndex, tc := range cases {
+		if get := c.Contains(tc.p); get != tc.inside {
+			t.Errorf("TestContainsCapsule %d %v", testIndex, get)
+		}
+		if get := c.Distance(tc.p); !closeEq(get, tc.dist, epsilon) {
+			t.Errorf("TestContainsCapsule dist %d %v", testIndex, get)
+		}
+		if get := c.ClosestPoint(tc.p); !get.Eq(tc.closest) {
+			t.Errorf("TestContainsCapsule closest %d %v", testIndex, get)
+		}
+	}
+
+	if !c.ContainsSphere(Sphere{Vec3{0, 4, 0}, 1}) || c.ContainsSphere(Sphere{Vec3{0, 4.5, 0}, 1}) {
+		t.Errorf("TestContainsCapsule sphere")
+	}
+	if s := c.Segment(); !s.A.Eq(c.A) || !s.B.Eq(c.B) {
+		t.Errorf("TestContainsCapsule segment %v", s)
+	}
+}
+
+func TestMergeCapsule(t *testing.T) {
+	cases := []struct {
+		a, b Capsule
+	}{
+		{Capsule{Vec3{0, 0, 0}, Vec3{0, 4, 0}, 1}, Capsule{Vec3{0, 6, 0}, Vec3{0, 8, 0}, 1}},
+		{Capsule{Vec3{0, 0, 0}, Vec3{0, 4, 0}, 1}, Capsule{Vec3{3, 0, 0}, Vec3{3, 1, 2}, 0.5}},
+		{Capsule{Vec3{-1, 2, 0}, Vec3{5, 0, 1}, 2}, Capsule{Vec3{1, 1, 1}, Vec3{1, 1, 1}, 3}},
+	}
+
+	for testIndex, c := range cases {
+		get := c.a.Merge(c.b)
+		for _, x := range []Capsule{c.a, c.b} {
+			for _, s := range []float64{0, 0.3, 0.7, 1} {
+				p := x.Segment().At(s)
+				if !get.ContainsSphere(Sphere{p, x.Radius - epsilon}) {
+					t.Errorf("TestMergeCapsule %d %v %v", testIndex, get, p)
+				}
+			}
+		}
+	}
+
+	// collinear capsules merge exactly
+	get := cases[0].a.Merge(cases[0].b)
+	if !get.A.Eq(Vec3{0, 0, 0}) || !get.B.Eq(Vec3{0, 8, 0}) || !closeEq(get.Radius, 1, epsilon) {
+		t.Errorf("TestMergeCapsule collinear %v", get)
+	}
+}
+
+func TestTransformCapsule(t *testing.T) {
+	c := Capsule{Vec3{0, 0, 0}, Vec3{0, 4, 0}, 1}
+	var m Mat4
+	m.ToScale(3, 1, 1)
+	get := c.Transform(m)
+	if !get.B.Eq(Vec3{0, 4, 0}) || !closeEq(get.Radius, 3, epsilon) {
+		t.Errorf("TestTransformCapsule %v", get)
+	}
+
+	var q Quat
+	q.FromAxisAngle(math.Pi/2, 0, 0, 1)
+	get = c.Rotate(q)
+	if !get.B.Eq(Vec3{-4, 0, 0}) || get.Radius != 1 {
+		t.Errorf("TestTransformCapsule rotate %v", get)
+	}
+}
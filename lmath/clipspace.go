@@ -0,0 +1,36 @@
+package lmath
+
+// ClipSpace describes the clip-space conventions expected by a graphics API.
+// It is used by the projection builders on Mat4 to decide how depth is
+// remapped and whether the Y axis is flipped.
+type ClipSpace struct {
+	// Map depth into [0,1] instead of the OpenGL [-1,1] range.
+	DepthZeroToOne bool
+	// Negate the clip-space Y axis (Vulkan has +Y pointing down).
+	FlipY bool
+	// Map the near plane to the far end of the depth range and the far plane
+	// to the near end. Gives much better precision with floating-point depth.
+	ReverseZ bool
+	// Ignore the far distance and push the far plane out to infinity.
+	// Only affects perspective projections.
+	InfiniteFar bool
+}
+
+var (
+	ClipSpaceOpenGL = ClipSpace{}
+	ClipSpaceVulkan = ClipSpace{DepthZeroToOne: true, FlipY: true}
+	ClipSpaceD3D    = ClipSpace{DepthZeroToOne: true}
+)
+
+// Return the normalized device depth values which the near and far planes
+// are mapped onto.
+func (this ClipSpace) depthRange() (near, far float64) {
+	near, far = -1, 1
+	if this.DepthZeroToOne {
+		near = 0
+	}
+	if this.ReverseZ {
+		near, far = far, near
+	}
+	return
+}
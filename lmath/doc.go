@@ -1,7 +1,17 @@
 /*
 lmath is a small 3D linear algebra library which provides support for
-Vec3/4, Mat3/4 and Quaternions. Supports rotations using euler angle,axis-angle,
+Vec2/3/4, Mat2/3/4 and Quaternions. Supports rotations using euler angle,axis-angle,
 rotation matrices, quaternions as well as conversions between the representations.
+Vec2f/3f/4f, Mat3f/4f and Quatf are float32 versions for GPU and bulk data.
+Also provides geometric primitives built on top of these types: Ray, Plane,
+Sphere, AABB, OBB, Triangle, Segment and Capsule, a Transform holding a
+position, rotation and scale, and DualQuat for blending rigid transformations.
+Mat3 and Mat4 provide LU, QR, Cholesky and symmetric eigen decompositions
+and Mat3 a singular value decomposition, which back Solve, PseudoInverse and
+the least squares solvers. ApproxEq compares any of the types using a
+Tolerance with absolute, relative or ULP limits.
+
+Requires Go 1.17 or newer, for the unsafe.Slice backed buffer views.
 */
 package lmath
 
@@ -9,7 +19,8 @@ package lmath
 //
 //  Right-Hand coordinate system
 //  All angles are specified in Radians
-//  Rotations are applied in Pitch => Yaw => Roll order
+//  Rotations are applied in Pitch => Yaw => Roll order (ExtrinsicXYZ) unless
+//  an EulerOrder is given
 var (
 	// I don't like that this is var and not a const.
 	Version = struct{ Major, Minor, Patch int }{0, 0, 0}
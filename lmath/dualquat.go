@@ -0,0 +1,223 @@
+package lmath
+
+import (
+	"math"
+)
+
+// A dual quaternion (Real + e*Dual) representing a rigid transformation, a
+// rotation followed by a translation. The rotation is stored in Real and the
+// Dual part holds 0.5 * t * Real where t is the translation as a pure
+// quaternion. Unlike Mat4 values, dual quaternions can be blended without
+// introducing scale, which avoids the candy-wrapper artifacts of linear blend
+// skinning.
+type DualQuat struct {
+	Real, Dual Quat
+}
+
+var (
+	DualQuatIdentity = DualQuat{QuatIdentity, QuatZero}
+)
+
+// Return the dual quaternion which rotates by r and then translates by t.
+// r must be unit length.
+func DualQuatFromRotationTranslation(r Quat, t Vec3) DualQuat {
+	return DualQuat{r, Quat{0, t.X, t.Y, t.Z}.Mult(r).MultScalar(0.5)}
+}
+
+// Return the dual quaternion of the rotation and translation of the transform.
+// The scale is ignored.
+func DualQuatFromTransform(t Transform) DualQuat {
+	return DualQuatFromRotationTranslation(t.Rotation, t.Position)
+}
+
+// Return the dual quaternion of the rigid transformation held in the matrix.
+// ok is false when the matrix can't be decomposed (see Mat4.Decompose) or
+// holds a scale, which a dual quaternion can't represent.
+func DualQuatFromMat4(m Mat4) (out DualQuat, ok bool) {
+	t, r, s, ok := m.Decompose()
+	if !closeEq(s.X, 1, decomposeEpsilon) || !closeEq(s.Y, 1, decomposeEpsilon) ||
+		!closeEq(s.Z, 1, decomposeEpsilon) {
+		ok = false
+	}
+	return DualQuatFromRotationTranslation(r, t), ok
+}
+
+// Return the rotation part of the dual quaternion.
+func (this DualQuat) Rotation() Quat {
+	return this.Real
+}
+
+// Return the translation part of the unit dual quaternion.
+func (this DualQuat) Translation() Vec3 {
+	t := this.Dual.Mult(this.Real.Conjugate()).MultScalar(2)
+	return Vec3{t.X, t.Y, t.Z}
+}
+
+// Return the transform of the unit dual quaternion, with a scale of 1.
+func (this DualQuat) Transform() Transform {
+	return Transform{this.Translation(), this.Real, Vec3{1, 1, 1}}
+}
+
+// Return the matrix of the unit dual quaternion.
+func (this DualQuat) Mat4() Mat4 {
+	m := Mat4{}
+	m.FromTRS(this.Translation(), this.Real, Vec3{1, 1, 1})
+	return m
+}
+
+// Multiply the dual quaternions ( ie. result = this * other ).
+// As with matrices the right hand side is applied first.
+// Returns a new dual quaternion with the result.
+func (this DualQuat) Mult(other DualQuat) DualQuat {
+	this.MultIn(other)
+	return this
+}
+
+// Multiply the dual quaternions ( ie. this = this * other ).
+// Return a pointer to 'this'
+func (this *DualQuat) MultIn(other DualQuat) *DualQuat {
+	r := this.Real.Mult(other.Real)
+	d := this.Real.Mult(other.Dual).Add(this.Dual.Mult(other.Real))
+	this.Real, this.Dual = r, d
+	return this
+}
+
+// Return the conjugate of both the real and dual parts.
+// For a unit dual quaternion this is the inverse transformation.
+func (this DualQuat) Conjugate() DualQuat {
+	this.ConjugateIn()
+	return this
+}
+
+// Conjugate both the real and dual parts. See Conjugate
+// Return a pointer to 'this'
+func (this *DualQuat) ConjugateIn() *DualQuat {
+	this.Real.ConjugateIn()
+	this.Dual.ConjugateIn()
+	return this
+}
+
+// Return a new unit dual quaternion.
+// See NormalizeIn
+func (this DualQuat) Normalize() DualQuat {
+	this.NormalizeIn()
+	return this
+}
+
+// Scale the dual quaternion so the real part is unit length and remove any
+// part of the dual which isn't orthogonal to the real part, so the result is
+// a valid rigid transformation.
+// Return a pointer to 'this'
+func (this *DualQuat) NormalizeIn() *DualQuat {
+	n := this.Real.Norm()
+	this.Real.DivInScalar(n)
+	this.Dual.DivInScalar(n)
+	this.Dual.SubIn(this.Real.MultScalar(this.Real.Dot(this.Dual)))
+	return this
+}
+
+// Return true if no element is NaN or infinite.
+func (this DualQuat) IsFinite() bool {
+	return this.Real.IsFinite() && this.Dual.IsFinite()
+}
+
+// Return true if any element is NaN.
+func (this DualQuat) HasNaN() bool {
+	return this.Real.HasNaN() || this.Dual.HasNaN()
+}
+
+// Equal if both parts are equal within the tolerance.
+func (this DualQuat) ApproxEq(other DualQuat, tol Tolerance) bool {
+	return this.Real.ApproxEq(other.Real, tol) && this.Dual.ApproxEq(other.Dual, tol)
+}
+
+// Return true if the dual quaternions represent the same transformation
+// within the tolerance. dq and -dq are the same so either sign is accepted.
+func (this DualQuat) ApproxEqRotation(other DualQuat, tol Tolerance) bool {
+	neg := DualQuat{other.Real.MultScalar(-1), other.Dual.MultScalar(-1)}
+	return this.ApproxEq(other, tol) || this.ApproxEq(neg, tol)
+}
+
+// Return the point rotated and translated by the unit dual quaternion.
+func (this DualQuat) TransformPoint(p Vec3) Vec3 {
+	return this.Real.RotateVec3(p).Add(this.Translation())
+}
+
+// Return the direction rotated by the unit dual quaternion.
+// The translation is ignored.
+func (this DualQuat) TransformDirection(d Vec3) Vec3 {
+	return this.Real.RotateVec3(d)
+}
+
+//==============================================================================
+
+// Screw linear interpolation between the unit dual quaternions a and b.
+// The result moves along the screw motion from a to b, rotating and
+// translating at a constant rate. The shortest path is taken, so b may be
+// negated internally. inc is specified between the range 0 - 1.
+//
+//	ScLerp(a,b,0) ==> a
+//	ScLerp(a,b,1) ==> b (or -b)
+func ScLerp(a, b DualQuat, inc float64) DualQuat {
+	if a.Real.Dot(b.Real) < 0 {
+		b.Real.MultInScalar(-1)
+		b.Dual.MultInScalar(-1)
+	}
+	return a.Mult(a.Conjugate().Mult(b).pow(inc))
+}
+
+// Raise the unit dual quaternion to the power t, scaling both the angle and
+// the translation of its screw motion.
+func (this DualQuat) pow(t float64) DualQuat {
+	// Reference : Ladislav Kavan et al., "Dual Quaternions for Rigid
+	// Transformation Blending", 2006
+	trans := this.Translation()
+	vlen := math.Sqrt(this.Real.X*this.Real.X + this.Real.Y*this.Real.Y + this.Real.Z*this.Real.Z)
+	if vlen < epsilon {
+		// no rotation, only scale the translation
+		return DualQuatFromRotationTranslation(QuatIdentity, trans.MultScalar(t))
+	}
+
+	// screw axis l, angle about it, distance along it and its moment
+	l := Vec3{this.Real.X, this.Real.Y, this.Real.Z}.DivScalar(vlen)
+	angle := 2 * math.Atan2(vlen, this.Real.W)
+	dist := trans.Dot(l)
+	moment := trans.Cross(l).Add(trans.Sub(l.MultScalar(dist)).MultScalar(this.Real.W / vlen)).MultScalar(0.5)
+
+	angle *= t
+	dist *= t
+	sin, cos := math.Sin(angle/2), math.Cos(angle/2)
+	v := moment.MultScalar(sin).Add(l.MultScalar(dist / 2 * cos))
+	return DualQuat{
+		Quat{cos, l.X * sin, l.Y * sin, l.Z * sin},
+		Quat{-dist / 2 * sin, v.X, v.Y, v.Z},
+	}
+}
+
+// Dual quaternion linear blending of the unit dual quaternions using the
+// given weights. Each dual quaternion is negated when needed so it lies in
+// the same hemisphere as the first. The weighted sum is normalized into a
+// rigid transformation.
+// An empty input blends to the identity. ok is false and the identity is
+// returned when weights isn't the same length as dqs or the weights cancel
+// out so the sum can't be normalized.
+func DLB(dqs []DualQuat, weights []float64) (out DualQuat, ok bool) {
+	if len(weights) != len(dqs) {
+		return DualQuatIdentity, false
+	}
+	if len(dqs) == 0 {
+		return DualQuatIdentity, true
+	}
+	for k, dq := range dqs {
+		w := weights[k]
+		if dq.Real.Dot(dqs[0].Real) < 0 {
+			w = -w
+		}
+		out.Real.AddIn(dq.Real.MultScalar(w))
+		out.Dual.AddIn(dq.Dual.MultScalar(w))
+	}
+	if closeEq(out.Real.NormSq(), 0, epsilon) {
+		return DualQuatIdentity, false
+	}
+	return out.Normalize(), true
+}
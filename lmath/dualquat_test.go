@@ -0,0 +1,171 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func dualQuatTestEq(a, b DualQuat) bool {
+	// q and -q are the same transformation
+	if a.Real.Dot(b.Real) < 0 {
+		b.Real.MultInScalar(-1)
+		b.Dual.MultInScalar(-1)
+	}
+	return a.Real.Sub(b.Real).Norm() < 1e-9 && a.Dual.Sub(b.Dual).Norm() < 1e-9
+}
+
+func TestTransformPointDualQuat(t *testing.T) {
+	cases := []struct {
+		rot   Quat
+		trans Vec3
+	}{
+		{QuatIdentity, Vec3{0, 0, 0}},
+		{QuatIdentity, Vec3{1, 2, 3}},
+		{transformTestRotation(math.Pi/2, 0, 0, 1), Vec3{0, 0, 0}},
+		{transformTestRotation(0.7, 1, 2, 3), Vec3{-4, 5, 1}},
+	}
+	points := []Vec3{{0, 0, 0}, {1, 0, 0}, {1, -2, 3}}
+
+	for testIndex, c := range cases {
+		dq := DualQuatFromRotationTranslation(c.rot, c.trans)
+		if dq.Translation().Sub(c.trans).Length() > 1e-9 {
+			t.Errorf("TestTransformPointDualQuat %d translation %v %v", testIndex, dq.Translation(), c.trans)
+		}
+		m := dq.Mat4()
+		for _, p := range points {
+			get := dq.TransformPoint(p)
+			want := c.rot.RotateVec3(p).Add(c.trans)
+			if get.Sub(want).Length() > 1e-9 || get.Sub(m.TransformPoint(p)).Length() > 1e-9 {
+				t.Errorf("TestTransformPointDualQuat %d %v %v", testIndex, get, want)
+			}
+		}
+	}
+}
+
+func TestMultDualQuat(t *testing.T) {
+	a := DualQuatFromRotationTranslation(transformTestRotation(0.7, 1, 2, 3), Vec3{-4, 5, 1})
+	b := DualQuatFromRotationTranslation(transformTestRotation(2, 0, 1, 0), Vec3{1, 0, 2})
+	p := Vec3{1, -2, 3}
+
+	get := a.Mult(b).TransformPoint(p)
+	want := a.TransformPoint(b.TransformPoint(p))
+	if get.Sub(want).Length() > 1e-9 {
+		t.Errorf("TestMultDualQuat %v %v", get, want)
+	}
+
+	m := a.Mult(b).Mat4()
+	wantMat := a.Mat4().Mult(b.Mat4())
+	for k := 0; k < 16; k++ {
+		if !closeEq(m.At(k), wantMat.At(k), 1e-9) {
+			t.Errorf("TestMultDualQuat mat4\n%v\n%v", &m, &wantMat)
+			break
+		}
+	}
+
+	// the conjugate is the inverse
+	if !dualQuatTestEq(a.Mult(a.Conjugate()), DualQuatIdentity) {
+		t.Errorf("TestMultDualQuat conjugate %v", a.Mult(a.Conjugate()))
+	}
+}
+
+func TestNormalizeDualQuat(t *testing.T) {
+	dq := DualQuatFromRotationTranslation(transformTestRotation(0.7, 1, 2, 3), Vec3{-4, 5, 1})
+	scaled := DualQuat{dq.Real.MultScalar(3), dq.Dual.MultScalar(3)}
+	if get := scaled.Normalize(); !dualQuatTestEq(get, dq) {
+		t.Errorf("TestNormalizeDualQuat %v %v", get, dq)
+	}
+
+	// a dual part not orthogonal to the real part is corrected
+	skewed := DualQuat{dq.Real, dq.Dual.Add(dq.Real.MultScalar(0.5))}
+	get := skewed.Normalize()
+	if !closeEq(get.Real.Dot(get.Dual), 0, 1e-9) || !dualQuatTestEq(get, dq) {
+		t.Errorf("TestNormalizeDualQuat skewed %v %v", get, dq)
+	}
+}
+
+func TestConvertDualQuat(t *testing.T) {
+	tr := Transform{Vec3{1, 2, 3}, transformTestRotation(0.7, 1, 2, 3), Vec3{1, 1, 1}}
+	dq := DualQuatFromTransform(tr)
+	back := dq.Transform()
+	if back.Position.Sub(tr.Position).Length() > 1e-9 || math.Abs(back.Rotation.Dot(tr.Rotation)) < 1-1e-9 {
+		t.Errorf("TestConvertDualQuat transform %v %v", back, tr)
+	}
+
+	fromMat, ok := DualQuatFromMat4(tr.Mat4())
+	if !ok || !dualQuatTestEq(fromMat, dq) {
+		t.Errorf("TestConvertDualQuat mat4 %v %v %v", fromMat, dq, ok)
+	}
+
+	tr.Scale = Vec3{2, 2, 2}
+	if _, ok := DualQuatFromMat4(tr.Mat4()); ok {
+		t.Errorf("TestConvertDualQuat scaled mat4 accepted")
+	}
+}
+
+func TestScLerpDualQuat(t *testing.T) {
+	a := DualQuatFromRotationTranslation(transformTestRotation(0.7, 1, 2, 3), Vec3{-4, 5, 1})
+	b := DualQuatFromRotationTranslation(transformTestRotation(2, 0, 1, 0), Vec3{1, 0, 2})
+	shift := DualQuatFromRotationTranslation(QuatIdentity, Vec3{2, 4, 6})
+
+	cases := []struct {
+		a, b DualQuat
+		inc  float64
+		want DualQuat
+	}{
+		{a, b, 0, a},
+		{a, b, 1, b},
+		{a, DualQuat{b.Real.MultScalar(-1), b.Dual.MultScalar(-1)}, 1, b},
+		{DualQuatIdentity, shift, 0.5, DualQuatFromRotationTranslation(QuatIdentity, Vec3{1, 2, 3})},
+		// rotating about the Z axis through (1,0,0) with no translation along it
+		{
+			DualQuatIdentity,
+			DualQuatFromRotationTranslation(transformTestRotation(math.Pi, 0, 0, 1), Vec3{2, 0, 0}),
+			0.5,
+			DualQuatFromRotationTranslation(transformTestRotation(math.Pi/2, 0, 0, 1), Vec3{1, -1, 0}),
+		},
+	}
+
+	for testIndex, c := range cases {
+		get := ScLerp(c.a, c.b, c.inc)
+		if !dualQuatTestEq(get, c.want) {
+			t.Errorf("TestScLerpDualQuat %d %v %v", testIndex, get, c.want)
+		}
+	}
+
+	// two half steps make the whole screw motion
+	d := a.Conjugate().Mult(b)
+	half := ScLerp(DualQuatIdentity, d, 0.5)
+	if !dualQuatTestEq(half.Mult(half), d) {
+		t.Errorf("TestScLerpDualQuat half %v %v", half.Mult(half), d)
+	}
+}
+
+func TestDLBDualQuat(t *testing.T) {
+	a := DualQuatFromRotationTranslation(transformTestRotation(0.7, 1, 2, 3), Vec3{-4, 5, 1})
+	b := DualQuatFromRotationTranslation(transformTestRotation(1.2, 1, 2, 3), Vec3{-4, 5, 1})
+	neg := DualQuat{a.Real.MultScalar(-1), a.Dual.MultScalar(-1)}
+
+	cases := []struct {
+		dqs     []DualQuat
+		weights []float64
+		want    DualQuat
+		ok      bool
+	}{
+		{[]DualQuat{a}, []float64{1}, a, true},
+		{[]DualQuat{a, b}, []float64{1, 0}, a, true},
+		{[]DualQuat{a, neg}, []float64{0.5, 0.5}, a, true},
+		{[]DualQuat{a, b}, []float64{0.5, 0.5},
+			DualQuatFromRotationTranslation(transformTestRotation(0.95, 1, 2, 3), Vec3{-4, 5, 1}), true},
+		{nil, nil, DualQuatIdentity, true},
+		{[]DualQuat{a, b}, []float64{1}, DualQuatIdentity, false},
+		{[]DualQuat{a}, []float64{1, 1}, DualQuatIdentity, false},
+		{[]DualQuat{a, b}, []float64{0, 0}, DualQuatIdentity, false},
+	}
+
+	for testIndex, c := range cases {
+		get, ok := DLB(c.dqs, c.weights)
+		if ok != c.ok || !dualQuatTestEq(get, c.want) || get.HasNaN() {
+			t.Errorf("TestDLBDualQuat %d %v %v %v", testIndex, get, c.want, ok)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package lmath
+
+import (
+	"math"
+)
+
+// EulerOrder specifies the sequence of axes and the frame used when
+// converting between euler angles and rotations.
+//
+// The angles (a,b,c) passed to FromEulerOrder and returned from EulerOrder
+// always belong to the axes in the order they appear in the name
+// (ie. for ExtrinsicZYX 'a' is about Z, 'b' about Y and 'c' about X).
+//
+//	Extrinsic rotations are about the fixed world axes.
+//	  ExtrinsicXYZ(a,b,c) == Rz(c) * Ry(b) * Rx(a)
+//	Intrinsic rotations are about the axes of the rotating body.
+//	  IntrinsicXYZ(a,b,c) == Rx(a) * Ry(b) * Rz(c)
+//
+// An intrinsic sequence is the same rotation as the reversed extrinsic
+// sequence with the angles reversed, IntrinsicZYX(a,b,c) == ExtrinsicXYZ(c,b,a).
+// The existing FromEuler(pitch,yaw,roll) functions use ExtrinsicXYZ.
+type EulerOrder int
+
+const (
+	// Tait-Bryan angles
+	ExtrinsicXYZ EulerOrder = iota
+	ExtrinsicXZY
+	ExtrinsicYXZ
+	ExtrinsicYZX
+	ExtrinsicZXY
+	ExtrinsicZYX
+
+	// Proper euler angles
+	ExtrinsicXYX
+	ExtrinsicXZX
+	ExtrinsicYXY
+	ExtrinsicYZY
+	ExtrinsicZXZ
+	ExtrinsicZYZ
+
+	// Tait-Bryan angles
+	IntrinsicXYZ
+	IntrinsicXZY
+	IntrinsicYXZ
+	IntrinsicYZX
+	IntrinsicZXY
+	IntrinsicZYX
+
+	// Proper euler angles
+	IntrinsicXYX
+	IntrinsicXZX
+	IntrinsicYXY
+	IntrinsicYZY
+	IntrinsicZXZ
+	IntrinsicZYZ
+)
+
+const (
+	// Below this the middle rotation is treated as being in gimbal lock
+	gimbalEpsilon = 0.0000001
+)
+
+// The axes (0 = X, 1 = Y, 2 = Z) of each sequence as they appear in the name
+var eulerAxes = [...][3]int{
+	{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0},
+	{0, 1, 0}, {0, 2, 0}, {1, 0, 1}, {1, 2, 1}, {2, 0, 2}, {2, 1, 2},
+}
+
+var eulerNames = [...]string{
+	"XYZ", "XZY", "YXZ", "YZX", "ZXY", "ZYX",
+	"XYX", "XZX", "YXY", "YZY", "ZXZ", "ZYZ",
+}
+
+// Return true if the rotations are about the axes of the rotating body.
+func (this EulerOrder) IsIntrinsic() bool {
+	return this >= IntrinsicXYZ
+}
+
+// Return true if the first and last axes are the same (ie. ZXZ).
+func (this EulerOrder) IsProper() bool {
+	axes := this.Axes()
+	return axes[0] == axes[2]
+}
+
+// Return the axes (0 = X, 1 = Y, 2 = Z) in the order they appear in the name.
+func (this EulerOrder) Axes() [3]int {
+	return eulerAxes[int(this)%len(eulerAxes)]
+}
+
+// Implement the Stringer interface
+func (this EulerOrder) String() string {
+	if this < ExtrinsicXYZ || this > IntrinsicZYZ {
+		return "EulerOrder(invalid)"
+	}
+	if this.IsIntrinsic() {
+		return "Intrinsic" + eulerNames[this-IntrinsicXYZ]
+	}
+	return "Extrinsic" + eulerNames[this]
+}
+
+// Return the axes and angles as the equivalent extrinsic sequence.
+// The rotation matrix is R(axes[2],c) * R(axes[1],b) * R(axes[0],a)
+func (this EulerOrder) extrinsic(a, b, c float64) (axes [3]int, ea, eb, ec float64) {
+	axes = this.Axes()
+	if this.IsIntrinsic() {
+		axes[0], axes[2] = axes[2], axes[0]
+		return axes, c, b, a
+	}
+	return axes, a, b, c
+}
+
+// Return the rotation matrix about one of the major axes (0 = X, 1 = Y, 2 = Z)
+func axisRotation(axis int, angle float64) Mat3 {
+	var m Mat3
+	switch axis {
+	case 0:
+		m.FromAxisAngle(angle, 1, 0, 0)
+	case 1:
+		m.FromAxisAngle(angle, 0, 1, 0)
+	default:
+		m.FromAxisAngle(angle, 0, 0, 1)
+	}
+	return m
+}
+
+// Return the angle of the rotation matrix r about the major axis.
+// Assumes r only rotates about that axis.
+func axisAngle(axis int, r Mat3) float64 {
+	// For a rotation about 'axis' by angle t
+	//   r[i2][i1] == sin(t)
+	//   r[i1][i1] == cos(t)
+	i1 := (axis + 1) % 3
+	i2 := (axis + 2) % 3
+	return math.Atan2(r.Get(i2, i1), r.Get(i1, i1))
+}
+
+// Extract the euler angles of the rotation matrix m for the given order.
+//
+// Reference : Ken Shoemake, "Euler Angle Conversion", Graphics Gems IV
+//
+// In gimbal lock the first and last rotations are about the same axis and
+// only their combination is known. The last rotation applied (the angle 'c'
+// for both intrinsic and extrinsic orders) is set to zero.
+func eulerFromMat3(order EulerOrder, m Mat3) (a, b, c float64) {
+	axes, _, _, _ := order.extrinsic(0, 0, 0)
+
+	// i, j are the first two axes of the extrinsic sequence and k is the
+	// remaining axis. Odd parity means i,j,k is not a cyclic permutation of
+	// x,y,z, which flips the sign of every angle.
+	i, j := axes[0], axes[1]
+	k := 3 - i - j
+	odd := j != (i+1)%3
+
+	var ea, eb, ec float64
+	var locked bool
+	if order.IsProper() {
+		sy := math.Sqrt(m.Get(i, j)*m.Get(i, j) + m.Get(i, k)*m.Get(i, k))
+		locked = sy < gimbalEpsilon
+		ea = math.Atan2(m.Get(i, j), m.Get(i, k))
+		eb = math.Atan2(sy, m.Get(i, i))
+		ec = math.Atan2(m.Get(j, i), -m.Get(k, i))
+	} else {
+		cy := math.Sqrt(m.Get(i, i)*m.Get(i, i) + m.Get(j, i)*m.Get(j, i))
+		locked = cy < gimbalEpsilon
+		ea = math.Atan2(m.Get(k, j), m.Get(k, k))
+		eb = math.Atan2(-m.Get(k, i), cy)
+		ec = math.Atan2(m.Get(j, i), m.Get(i, i))
+	}
+	if odd {
+		ea, eb, ec = -ea, -eb, -ec
+	}
+	if order.IsProper() && eb < 0 {
+		// R(i,c) * R(j,b) * R(i,a) == R(i,c+pi) * R(j,-b) * R(i,a+pi)
+		// keep the middle angle in the range [0,pi]
+		eb = -eb
+		ea = math.Remainder(ea+math.Pi, 2*math.Pi)
+		ec = math.Remainder(ec+math.Pi, 2*math.Pi)
+	}
+
+	if !locked {
+		if order.IsIntrinsic() {
+			return ec, eb, ea
+		}
+		return ea, eb, ec
+	}
+
+	// Gimbal lock, keep b and solve for a with c == 0
+	mid := axisRotation(axes[1], -eb)
+	if order.IsIntrinsic() {
+		// m == R(a) * R(b)
+		return axisAngle(axes[2], m.Mult(mid)), eb, 0
+	}
+	// m == R(b) * R(a)
+	return axisAngle(axes[0], mid.Mult(m)), eb, 0
+}
+
+// =============================================================================
+
+// Set this as a rotation matrix from the euler angles (radians) applied in
+// the given order. See EulerOrder for how the angles are interpreted.
+func (this *Mat3) FromEulerOrder(order EulerOrder, a, b, c float64) *Mat3 {
+	axes, ea, eb, ec := order.extrinsic(a, b, c)
+	*this = axisRotation(axes[2], ec).Mult(axisRotation(axes[1], eb)).Mult(axisRotation(axes[0], ea))
+	return this
+}
+
+// Return the euler angles (radians) of this rotation matrix for the given
+// order. Assumes the matrix is a valid rotation matrix.
+// The middle angle is in the range [-pi/2,pi/2] for Tait-Bryan orders and
+// [0,pi] for proper euler orders. The others are in the range [-pi,pi].
+// In gimbal lock the angle 'c' is set to zero.
+func (this Mat3) EulerOrder(order EulerOrder) (a, b, c float64) {
+	return eulerFromMat3(order, this)
+}
+
+// Set this as a rotation matrix from the euler angles (radians) applied in
+// the given order. See EulerOrder for how the angles are interpreted.
+func (this *Mat4) FromEulerOrder(order EulerOrder, a, b, c float64) *Mat4 {
+	var m Mat3
+	m.FromEulerOrder(order, a, b, c)
+	this.ToIdentity()
+	return this.SetUpperMat3(m)
+}
+
+// Return the euler angles (radians) of the rotation in the upper 3x3 of this
+// matrix for the given order. See Mat3.EulerOrder
+func (this Mat4) EulerOrder(order EulerOrder) (a, b, c float64) {
+	return eulerFromMat3(order, this.UpperMat3())
+}
+
+// Set this quaternion from the euler angles (radians) applied in the given
+// order. See EulerOrder for how the angles are interpreted.
+// Return this
+func (this *Quat) FromEulerOrder(order EulerOrder, a, b, c float64) *Quat {
+	axes, ea, eb, ec := order.extrinsic(a, b, c)
+	var qa, qb, qc Quat
+	qa.fromAxisIndex(axes[0], ea)
+	qb.fromAxisIndex(axes[1], eb)
+	qc.fromAxisIndex(axes[2], ec)
+	*this = qc.Mult(qb).Mult(qa)
+	return this
+}
+
+// Return the euler angles (radians) of this rotation quaternion for the
+// given order. See Mat3.EulerOrder
+func (this Quat) EulerOrder(order EulerOrder) (a, b, c float64) {
+	return eulerFromMat3(order, this.Mat3())
+}
+
+// Set the quaternion as a rotation about one of the major axes
+// (0 = X, 1 = Y, 2 = Z).
+func (this *Quat) fromAxisIndex(axis int, angle float64) *Quat {
+	this.Set(math.Cos(angle/2), 0, 0, 0)
+	switch axis {
+	case 0:
+		this.X = math.Sin(angle / 2)
+	case 1:
+		this.Y = math.Sin(angle / 2)
+	default:
+		this.Z = math.Sin(angle / 2)
+	}
+	return this
+}
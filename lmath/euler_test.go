@@ -0,0 +1,261 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+var allEulerOrders = []EulerOrder{
+	ExtrinsicXYZ, ExtrinsicXZY, ExtrinsicYXZ, ExtrinsicYZX, ExtrinsicZXY, ExtrinsicZYX,
+	ExtrinsicXYX, ExtrinsicXZX, ExtrinsicYXY, ExtrinsicYZY, ExtrinsicZXZ, ExtrinsicZYZ,
+	IntrinsicXYZ, IntrinsicXZY, IntrinsicYXZ, IntrinsicYZX, IntrinsicZXY, IntrinsicZYX,
+	IntrinsicXYX, IntrinsicXZX, IntrinsicYXY, IntrinsicYZY, IntrinsicZXZ, IntrinsicZYZ,
+}
+
+func TestStringEulerOrder(t *testing.T) {
+	cases := []struct {
+		order     EulerOrder
+		want      string
+		intrinsic bool
+		proper    bool
+	}{
+		{ExtrinsicXYZ, "ExtrinsicXYZ", false, false},
+		{ExtrinsicZYZ, "ExtrinsicZYZ", false, true},
+		{IntrinsicZYX, "IntrinsicZYX", true, false},
+		{IntrinsicZXZ, "IntrinsicZXZ", true, true},
+		{EulerOrder(-1), "EulerOrder(invalid)", false, false},
+	}
+
+	for testIndex, c := range cases {
+		if get := c.order.String(); get != c.want {
+			t.Errorf("TestStringEulerOrder %d %v", testIndex, get)
+		}
+		if c.order < 0 {
+			continue
+		}
+		if c.order.IsIntrinsic() != c.intrinsic || c.order.IsProper() != c.proper {
+			t.Errorf("TestStringEulerOrder flags %d", testIndex)
+		}
+	}
+}
+
+func TestFromEulerOrderMat3(t *testing.T) {
+	var rx, ry, rz Mat3
+	a, b, c := Radians(30), Radians(-50), Radians(110)
+
+	cases := []struct {
+		order EulerOrder
+		want  func() Mat3
+	}{
+		{ExtrinsicXYZ, func() Mat3 {
+			rx.FromAxisAngle(a, 1, 0, 0)
+			ry.FromAxisAngle(b, 0, 1, 0)
+			rz.FromAxisAngle(c, 0, 0, 1)
+			return rz.Mult(ry).Mult(rx)
+		}},
+		{IntrinsicXYZ, func() Mat3 {
+			rx.FromAxisAngle(a, 1, 0, 0)
+			ry.FromAxisAngle(b, 0, 1, 0)
+			rz.FromAxisAngle(c, 0, 0, 1)
+			return rx.Mult(ry).Mult(rz)
+		}},
+		{ExtrinsicZYX, func() Mat3 {
+			rz.FromAxisAngle(a, 0, 0, 1)
+			ry.FromAxisAngle(b, 0, 1, 0)
+			rx.FromAxisAngle(c, 1, 0, 0)
+			return rx.Mult(ry).Mult(rz)
+		}},
+		{IntrinsicYXZ, func() Mat3 {
+			ry.FromAxisAngle(a, 0, 1, 0)
+			rx.FromAxisAngle(b, 1, 0, 0)
+			rz.FromAxisAngle(c, 0, 0, 1)
+			return ry.Mult(rx).Mult(rz)
+		}},
+		{IntrinsicZXZ, func() Mat3 {
+			rz.FromAxisAngle(a, 0, 0, 1)
+			rx.FromAxisAngle(b, 1, 0, 0)
+			ry.FromAxisAngle(c, 0, 0, 1)
+			return rz.Mult(rx).Mult(ry)
+		}},
+		{ExtrinsicYZY, func() Mat3 {
+			ry.FromAxisAngle(a, 0, 1, 0)
+			rz.FromAxisAngle(b, 0, 0, 1)
+			rx.FromAxisAngle(c, 0, 1, 0)
+			return rx.Mult(rz).Mult(ry)
+		}},
+	}
+
+	var m Mat3
+	for testIndex, tc := range cases {
+		m.FromEulerOrder(tc.order, a, b, c)
+		if want := tc.want(); !m.Eq(want) {
+			t.Errorf("TestFromEulerOrderMat3 %d %v\n%v\n%v", testIndex, tc.order, m, want)
+		}
+	}
+
+	// The existing pitch, yaw, roll functions use ExtrinsicXYZ
+	var pyr Mat3
+	pyr.FromEuler(a, b, c)
+	if m.FromEulerOrder(ExtrinsicXYZ, a, b, c); !m.Eq(pyr) {
+		t.Errorf("TestFromEulerOrderMat3 FromEuler\n%v\n%v", m, pyr)
+	}
+
+	// Intrinsic orders are the reversed extrinsic order with reversed angles
+	var m2 Mat3
+	for _, order := range allEulerOrders[:12] {
+		axes := order.Axes()
+		for _, intrinsic := range allEulerOrders[12:] {
+			ia := intrinsic.Axes()
+			if ia[0] != axes[2] || ia[1] != axes[1] || ia[2] != axes[0] {
+				continue
+			}
+			m.FromEulerOrder(order, a, b, c)
+			m2.FromEulerOrder(intrinsic, c, b, a)
+			if !m.Eq(m2) {
+				t.Errorf("TestFromEulerOrderMat3 reverse %v %v", order, intrinsic)
+			}
+		}
+	}
+}
+
+func TestFromEulerOrderRotate(t *testing.T) {
+	cases := []struct {
+		order   EulerOrder
+		a, b, c float64
+		v, want Vec3
+	}{
+		// yaw about Z, then pitch about the new Y
+		{IntrinsicZYX, 90, 0, 0, Vec3{1, 0, 0}, Vec3{0, 1, 0}},
+		{IntrinsicZYX, 90, 90, 0, Vec3{1, 0, 0}, Vec3{0, 0, -1}},
+		{ExtrinsicZYX, 90, 90, 0, Vec3{1, 0, 0}, Vec3{0, 1, 0}},
+		{IntrinsicZXZ, 90, 90, 0, Vec3{0, 1, 0}, Vec3{0, 0, 1}},
+		{ExtrinsicZXZ, 90, 90, 0, Vec3{1, 0, 0}, Vec3{0, 0, 1}},
+		{ExtrinsicYXY, 0, 0, 90, Vec3{0, 0, 1}, Vec3{1, 0, 0}},
+	}
+
+	var m Mat3
+	var m4 Mat4
+	var q Quat
+	for testIndex, c := range cases {
+		a, b, cc := Radians(c.a), Radians(c.b), Radians(c.c)
+		m.FromEulerOrder(c.order, a, b, cc)
+		if get := m.MultVec3(c.v); !get.Eq(c.want) {
+			t.Errorf("TestFromEulerOrderRotate mat3 %d %v", testIndex, get)
+		}
+		m4.FromEulerOrder(c.order, a, b, cc)
+		if get := m4.MultVec3(c.v); !get.Eq(c.want) {
+			t.Errorf("TestFromEulerOrderRotate mat4 %d %v", testIndex, get)
+		}
+		q.FromEulerOrder(c.order, a, b, cc)
+		if get := q.RotateVec3(c.v); !get.Eq(c.want) {
+			t.Errorf("TestFromEulerOrderRotate quat %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestEulerOrderRoundTrip(t *testing.T) {
+	angles := [][3]float64{
+		{10, 20, 30},
+		{-170, 80, 95},
+		{45, -89, -45},
+		{120, 5, -160},
+		{0, 0.001, 0},
+	}
+
+	var m Mat3
+	var m4 Mat4
+	var q Quat
+	for _, order := range allEulerOrders {
+		for angleIndex, in := range angles {
+			a, b, c := Radians(in[0]), Radians(in[1]), Radians(in[2])
+			if order.IsProper() {
+				// middle angle of a proper euler order is in [0,pi]
+				b = math.Abs(b) + math.Pi/2*math.Abs(math.Sin(b))
+			}
+			m.FromEulerOrder(order, a, b, c)
+			m4.FromEulerOrder(order, a, b, c)
+			q.FromEulerOrder(order, a, b, c)
+
+			if !q.Mat3().Eq(m) || !m4.UpperMat3().Eq(m) {
+				t.Errorf("TestEulerOrderRoundTrip types %v %d", order, angleIndex)
+			}
+
+			ga, gb, gc := m.EulerOrder(order)
+			if !closeEq(ga, a, 1e-7) || !closeEq(gb, b, 1e-7) || !closeEq(gc, c, 1e-7) {
+				t.Errorf("TestEulerOrderRoundTrip %v %d %v %v %v", order, angleIndex,
+					Degrees(ga), Degrees(gb), Degrees(gc))
+			}
+			qa, qb, qc := q.EulerOrder(order)
+			if !closeEq(qa, a, 1e-7) || !closeEq(qb, b, 1e-7) || !closeEq(qc, c, 1e-7) {
+				t.Errorf("TestEulerOrderRoundTrip quat %v %d", order, angleIndex)
+			}
+			ma, mb, mc := m4.EulerOrder(order)
+			if ma != ga || mb != gb || mc != gc {
+				t.Errorf("TestEulerOrderRoundTrip mat4 %v %d", order, angleIndex)
+			}
+		}
+	}
+}
+
+func TestEulerOrderGimbalLock(t *testing.T) {
+	var m, get Mat3
+	var m4 Mat4
+	var q Quat
+	for _, order := range allEulerOrders {
+		locks := []float64{math.Pi / 2, -math.Pi / 2}
+		if order.IsProper() {
+			locks = []float64{0, math.Pi}
+		}
+		for _, b := range locks {
+			a, c := Radians(35), Radians(-70)
+			m.FromEulerOrder(order, a, b, c)
+			m4.FromEulerOrder(order, a, b, c)
+			q.FromEulerOrder(order, a, b, c)
+
+			ga, gb, gc := m.EulerOrder(order)
+			if gc != 0 || !closeEq(gb, b, 1e-7) {
+				t.Errorf("TestEulerOrderGimbalLock %v %v %v %v %v", order, b, ga, gb, gc)
+			}
+			if get.FromEulerOrder(order, ga, gb, gc); !get.Eq(m) {
+				t.Errorf("TestEulerOrderGimbalLock rotation %v %v\n%v\n%v", order, b, get, m)
+			}
+
+			// All the types must resolve the lock the same way
+			qa, qb, qc := q.EulerOrder(order)
+			ma, mb, mc := m4.EulerOrder(order)
+			if !closeEq(qa, ga, 1e-7) || !closeEq(qb, gb, 1e-7) || qc != 0 ||
+				ma != ga || mb != gb || mc != gc {
+				t.Errorf("TestEulerOrderGimbalLock types %v %v", order, b)
+			}
+		}
+	}
+}
+
+func TestMat3QuatConversion(t *testing.T) {
+	cases := []struct {
+		angle float64
+		axis  Vec3
+	}{
+		{30, Vec3{1, 2, 3}},
+		{90, Vec3{-1, 0.5, 0.2}},
+		{179, Vec3{1, 0.1, 0}},
+		{180, Vec3{0.2, 1, 0}},
+		{180, Vec3{0, 0.3, -1}},
+		{200, Vec3{1, 1, 1}},
+	}
+
+	var m Mat3
+	var q Quat
+	for testIndex, c := range cases {
+		axis := c.axis.Normalize()
+		m.FromAxisAngle(Radians(c.angle), axis.X, axis.Y, axis.Z)
+		q.FromAxisAngle(Radians(c.angle), axis.X, axis.Y, axis.Z)
+		if get := q.Mat3(); !get.Eq(m) {
+			t.Errorf("TestMat3QuatConversion mat %d\n%v\n%v", testIndex, get, m)
+		}
+		var get Quat
+		if get.FromMat3(m); !get.Eq(q) && !get.Eq(q.MultScalar(-1)) {
+			t.Errorf("TestMat3QuatConversion quat %d %v %v", testIndex, get, q)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package lmath
+
+import (
+	"math"
+)
+
+// The result of testing a volume against a Frustum.
+type Containment int
+
+const (
+	// The volume is completely outside
+	Outside Containment = iota
+	// The volume is partly inside
+	Intersecting
+	// The volume is completely inside
+	Inside
+)
+
+// Implement the Stringer interface
+func (this Containment) String() string {
+	switch this {
+	case Outside:
+		return "Outside"
+	case Intersecting:
+		return "Intersecting"
+	case Inside:
+		return "Inside"
+	}
+	return "Containment(invalid)"
+}
+
+// Index of each plane in Frustum.Planes
+const (
+	FrustumLeft = iota
+	FrustumRight
+	FrustumBottom
+	FrustumTop
+	FrustumNear
+	FrustumFar
+)
+
+// A view frustum made of six planes whose normals point into the frustum.
+// A point is inside when it is in front of (or on) every plane.
+// The planes are named after the clip space sides they come from, so with
+// ClipSpace.FlipY the bottom plane is at the top of the view.
+type Frustum struct {
+	Planes [6]Plane
+}
+
+// Return the frustum of the combined view-projection matrix (ie. proj * view)
+// using the OpenGL clip space. The planes are in the space the view matrix
+// transforms from, usually world space.
+func FrustumFromMat4(m Mat4) Frustum {
+	return FrustumFromMat4Clip(m, ClipSpaceOpenGL)
+}
+
+// Return the frustum of the combined view-projection matrix (ie. proj * view)
+// where the projection was built for the given clip space.
+func FrustumFromMat4Clip(m Mat4, clip ClipSpace) Frustum {
+	// Reference : Gil Gribb and Klaus Hartmann, "Fast Extraction of Viewing
+	// Frustum Planes from the World-View-Projection Matrix", 2001
+	// A point p is inside when each clip coordinate is within [-w,w] (depth
+	// is within [dn*w,df*w]). With the rows r0..r3 of m this gives planes
+	// such as  r3.p + r0.p >= 0  for the left side.
+	var r [4]Vec4
+	for k := range r {
+		r[k].Set(m.Row(k))
+	}
+
+	var out Frustum
+	out.Planes[FrustumLeft] = planeFromVec4(r[3].Add(r[0]))
+	out.Planes[FrustumRight] = planeFromVec4(r[3].Sub(r[0]))
+	out.Planes[FrustumBottom] = planeFromVec4(r[3].Add(r[1]))
+	out.Planes[FrustumTop] = planeFromVec4(r[3].Sub(r[1]))
+
+	dn, df := clip.depthRange()
+	out.Planes[FrustumNear] = planeFromVec4(depthPlane(r[2], r[3], dn))
+	out.Planes[FrustumFar] = planeFromVec4(depthPlane(r[2], r[3], df))
+	return out
+}
+
+// Return the clip plane for the depth value d, which is either the lower or
+// upper end of the depth range.
+func depthPlane(r2, r3 Vec4, d float64) Vec4 {
+	if d > 0 {
+		// z <= w
+		return r3.Sub(r2)
+	}
+	// z >= d*w
+	return r2.Sub(r3.MultScalar(d))
+}
+
+// Return the plane a*x + b*y + c*z + d >= 0 with a unit normal.
+// A zero normal (ie. the far plane of an infinite projection) gives a plane
+// which every point is in front of.
+func planeFromVec4(v Vec4) Plane {
+	p := Plane{Vec3{v.X, v.Y, v.Z}, -v.W}
+	if p.Normal.LengthSq() < epsilon*epsilon {
+		return Plane{Vec3{0, 0, 0}, math.Inf(-1)}
+	}
+	return p.Normalize()
+}
+
+// Equal if every plane is equal within the tolerance. See Plane.ApproxEq
+func (this Frustum) ApproxEq(other Frustum, tol Tolerance) bool {
+	for i, plane := range this.Planes {
+		if !plane.ApproxEq(other.Planes[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if the point is inside or on the frustum.
+func (this Frustum) ContainsPoint(p Vec3) bool {
+	for _, plane := range this.Planes {
+		if plane.SignedDistance(p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Test the sphere against the frustum.
+func (this Frustum) IntersectsSphere(s Sphere) Containment {
+	out := Inside
+	for _, plane := range this.Planes {
+		d := plane.SignedDistance(s.Center)
+		if d < -s.Radius {
+			return Outside
+		}
+		if d < s.Radius {
+			out = Intersecting
+		}
+	}
+	return out
+}
+
+// Test the box against the frustum.
+// Like most frustum culling this is conservative, large boxes near the
+// corners of the frustum may report Intersecting when they are outside.
+func (this Frustum) IntersectsAABB(box AABB) Containment {
+	c := box.Center()
+	e := box.Extents()
+	out := Inside
+	for _, plane := range this.Planes {
+		// projected radius of the box onto the plane normal
+		n := plane.Normal
+		r := e.X*math.Abs(n.X) + e.Y*math.Abs(n.Y) + e.Z*math.Abs(n.Z)
+		d := plane.SignedDistance(c)
+		if d < -r {
+			return Outside
+		}
+		if d < r {
+			out = Intersecting
+		}
+	}
+	return out
+}
+
+// Return the 8 corners of the frustum.
+// Bit 0 of the index picks the right plane over the left, bit 1 the top
+// over the bottom and bit 2 the far over the near.
+// The far corners are not finite when the frustum has an infinite far plane.
+func (this Frustum) Corners() (out [8]Vec3) {
+	for k := range out {
+		x, y, z := FrustumLeft, FrustumBottom, FrustumNear
+		if k&1 != 0 {
+			x = FrustumRight
+		}
+		if k&2 != 0 {
+			y = FrustumTop
+		}
+		if k&4 != 0 {
+			z = FrustumFar
+		}
+		out[k] = intersectPlanes(this.Planes[x], this.Planes[y], this.Planes[z])
+	}
+	return
+}
+
+// Return the point where the three planes meet.
+func intersectPlanes(a, b, c Plane) Vec3 {
+	bc := b.Normal.Cross(c.Normal)
+	ca := c.Normal.Cross(a.Normal)
+	ab := a.Normal.Cross(b.Normal)
+	denom := a.Normal.Dot(bc)
+	return bc.MultScalar(a.D).Add(ca.MultScalar(b.D)).Add(ab.MultScalar(c.D)).DivScalar(denom)
+}
+
+// Return true if no element of the planes is NaN or infinite.
+func (this Frustum) IsFinite() bool {
+	for _, p := range this.Planes {
+		if !p.IsFinite() {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if any element of the planes is NaN.
+func (this Frustum) HasNaN() bool {
+	for _, p := range this.Planes {
+		if p.HasNaN() {
+			return true
+		}
+	}
+	return false
+}
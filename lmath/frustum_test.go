@@ -0,0 +1,140 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+// A camera at the origin looking down -Z with a 90 degree field of view
+// between near = 1 and far = 10.
+func testFrustumMat4(clip ClipSpace) Mat4 {
+	var m Mat4
+	m.ToPerspectiveClip(math.Pi/2, 1, 1, 10, clip)
+	return m
+}
+
+func TestFrustumCorners(t *testing.T) {
+	clips := []ClipSpace{
+		ClipSpaceOpenGL,
+		ClipSpaceD3D,
+		{DepthZeroToOne: true, ReverseZ: true},
+		{ReverseZ: true},
+	}
+	want := [8]Vec3{
+		{-1, -1, -1}, {1, -1, -1}, {-1, 1, -1}, {1, 1, -1},
+		{-10, -10, -10}, {10, -10, -10}, {-10, 10, -10}, {10, 10, -10},
+	}
+
+	for clipIndex, clip := range clips {
+		f := FrustumFromMat4Clip(testFrustumMat4(clip), clip)
+		corners := f.Corners()
+		for k := range corners {
+			if !corners[k].CloseEq(want[k], 1e-7) {
+				t.Errorf("TestFrustumCorners %d %d %v", clipIndex, k, corners[k])
+			}
+		}
+		if !f.Planes[FrustumNear].Normal.Eq(Vec3{0, 0, -1}) || !f.Planes[FrustumFar].Normal.Eq(Vec3{0, 0, 1}) {
+			t.Errorf("TestFrustumCorners planes %d %v", clipIndex, f.Planes)
+		}
+	}
+
+	// Vulkan flips the Y axis so the top and bottom planes swap
+	f := FrustumFromMat4Clip(testFrustumMat4(ClipSpaceVulkan), ClipSpaceVulkan)
+	corners := f.Corners()
+	if !corners[0].CloseEq(Vec3{-1, 1, -1}, 1e-7) || !corners[7].CloseEq(Vec3{10, -10, -10}, 1e-7) {
+		t.Errorf("TestFrustumCorners vulkan %v", corners)
+	}
+
+	// Build the frustum from a view-projection matrix
+	var view Mat4
+	view.ToLookAt(Vec3{5, 0, 0}, Vec3{5, 0, 1}, Vec3{0, 1, 0})
+	proj := testFrustumMat4(ClipSpaceOpenGL)
+	f = FrustumFromMat4(proj.Mult(view))
+	corners = f.Corners()
+	if !corners[4].CloseEq(Vec3{15, -10, 10}, 1e-7) || !corners[3].CloseEq(Vec3{4, 1, 1}, 1e-7) {
+		t.Errorf("TestFrustumCorners view %v", corners)
+	}
+}
+
+func TestFrustumContainsPoint(t *testing.T) {
+	cases := []struct {
+		p    Vec3
+		want bool
+	}{
+		{Vec3{0, 0, -5}, true},
+		{Vec3{0, 0, -1}, true},
+		{Vec3{0, 0, -9.9999}, true},
+		{Vec3{4.9, -4.9, -5}, true},
+		{Vec3{0, 0, -0.5}, false},
+		{Vec3{0, 0, -10.5}, false},
+		{Vec3{0, 0, 5}, false},
+		{Vec3{5.1, 0, -5}, false},
+		{Vec3{0, -5.1, -5}, false},
+	}
+
+	for _, clip := range []ClipSpace{ClipSpaceOpenGL, ClipSpaceVulkan, {ReverseZ: true, DepthZeroToOne: true}} {
+		f := FrustumFromMat4Clip(testFrustumMat4(clip), clip)
+		for testIndex, c := range cases {
+			if get := f.ContainsPoint(c.p); get != c.want {
+				t.Errorf("TestFrustumContainsPoint %v %d %v", clip, testIndex, get)
+			}
+		}
+	}
+}
+
+func TestFrustumInfiniteFar(t *testing.T) {
+	clip := ClipSpace{InfiniteFar: true}
+	f := FrustumFromMat4Clip(testFrustumMat4(clip), clip)
+	if !f.ContainsPoint(Vec3{0, 0, -1e6}) || f.ContainsPoint(Vec3{0, 0, -0.5}) {
+		t.Errorf("TestFrustumInfiniteFar point")
+	}
+	if get := f.IntersectsSphere(Sphere{Vec3{0, 0, -1e6}, 1}); get != Inside {
+		t.Errorf("TestFrustumInfiniteFar sphere %v", get)
+	}
+	if c := f.Corners(); !c[0].CloseEq(Vec3{-1, -1, -1}, 1e-7) {
+		t.Errorf("TestFrustumInfiniteFar corner %v", c[0])
+	}
+}
+
+func TestFrustumIntersects(t *testing.T) {
+	f := FrustumFromMat4(testFrustumMat4(ClipSpaceOpenGL))
+
+	sphereCases := []struct {
+		s    Sphere
+		want Containment
+	}{
+		{Sphere{Vec3{0, 0, -5}, 1}, Inside},
+		{Sphere{Vec3{0, 0, -1}, 0.5}, Intersecting},
+		{Sphere{Vec3{0, 0, -10}, 0.5}, Intersecting},
+		{Sphere{Vec3{5, 0, -5}, 0.5}, Intersecting},
+		{Sphere{Vec3{0, 0, 2}, 0.5}, Outside},
+		{Sphere{Vec3{8, 0, -5}, 2}, Outside},
+		{Sphere{Vec3{0, 0, -5}, 100}, Intersecting},
+	}
+	for testIndex, c := range sphereCases {
+		if get := f.IntersectsSphere(c.s); get != c.want {
+			t.Errorf("TestFrustumIntersects sphere %d %v", testIndex, get)
+		}
+	}
+
+	boxCases := []struct {
+		b    AABB
+		want Containment
+	}{
+		{AABB{Vec3{-1, -1, -6}, Vec3{1, 1, -4}}, Inside},
+		{AABB{Vec3{-1, -1, -2}, Vec3{1, 1, 0}}, Intersecting},
+		{AABB{Vec3{4, -1, -6}, Vec3{6, 1, -4}}, Intersecting},
+		{AABB{Vec3{-1, -1, -12}, Vec3{1, 1, -11}}, Outside},
+		{AABB{Vec3{-1, -1, 1}, Vec3{1, 1, 2}}, Outside},
+		{AABB{Vec3{-20, -20, -20}, Vec3{20, 20, 20}}, Intersecting},
+	}
+	for testIndex, c := range boxCases {
+		if get := f.IntersectsAABB(c.b); get != c.want {
+			t.Errorf("TestFrustumIntersects box %d %v", testIndex, get)
+		}
+	}
+
+	if Outside.String() != "Outside" || Inside.String() != "Inside" || Intersecting.String() != "Intersecting" {
+		t.Errorf("TestFrustumIntersects String")
+	}
+}
@@ -0,0 +1,286 @@
+package lmath
+
+import (
+	"math"
+)
+
+// This file holds the intersection tests between the geometric primitives.
+
+// The result of a ray intersection test.
+type RayHit struct {
+	// The parameter 't' along the ray, ie. Point == ray.At(Distance).
+	// This is the distance from the origin when the ray direction is unit length.
+	Distance float64
+
+	// The point where the ray hits the surface
+	Point Vec3
+
+	// The unit surface normal at Point
+	Normal Vec3
+}
+
+// Intersect the ray with the triangle using the Moller-Trumbore algorithm.
+// Both faces of the triangle are hit. The normal returned is always the front
+// face normal (see Triangle.Normal), so hit.Normal.Dot(ray.Dir) > 0 means the
+// back face was hit.
+func (this Ray) IntersectTriangle(tri Triangle) (hit RayHit, ok bool) {
+	// Reference : Tomas Moller and Ben Trumbore,
+	// "Fast, Minimum Storage Ray/Triangle Intersection", 1997
+	e1 := tri.B.Sub(tri.A)
+	e2 := tri.C.Sub(tri.A)
+	p := this.Dir.Cross(e2)
+	det := e1.Dot(p)
+	if math.Abs(det) < epsilon {
+		// ray is parallel to the triangle
+		return
+	}
+	inv := 1 / det
+
+	s := this.Origin.Sub(tri.A)
+	u := s.Dot(p) * inv
+	if u < 0 || u > 1 {
+		return
+	}
+	q := s.Cross(e1)
+	v := this.Dir.Dot(q) * inv
+	if v < 0 || u+v > 1 {
+		return
+	}
+	t := e2.Dot(q) * inv
+	if t < 0 {
+		return
+	}
+	return RayHit{t, this.At(t), e1.Cross(e2).Normalize()}, true
+}
+
+// Intersect the ray with the box using the slab method.
+// Returns the first point along the ray on the surface of the box. When the
+// ray starts inside the box this is where the ray leaves the box.
+// The normal points out of the box.
+func (this Ray) IntersectAABB(box AABB) (hit RayHit, ok bool) {
+	origin := [3]float64{this.Origin.X, this.Origin.Y, this.Origin.Z}
+	dir := [3]float64{this.Dir.X, this.Dir.Y, this.Dir.Z}
+	min := [3]float64{box.Min.X, box.Min.Y, box.Min.Z}
+	max := [3]float64{box.Max.X, box.Max.Y, box.Max.Z}
+
+	tmin, tmax := math.Inf(-1), math.Inf(1)
+	minAxis, maxAxis := -1, -1
+	for k := 0; k < 3; k++ {
+		if math.Abs(dir[k]) < epsilon {
+			// parallel to the slab, miss unless the origin is between them
+			if origin[k] < min[k] || origin[k] > max[k] {
+				return
+			}
+			continue
+		}
+
+		t1 := (min[k] - origin[k]) / dir[k]
+		t2 := (max[k] - origin[k]) / dir[k]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > tmin {
+			tmin, minAxis = t1, k
+		}
+		if t2 < tmax {
+			tmax, maxAxis = t2, k
+		}
+		if tmin > tmax || tmax < 0 {
+			return
+		}
+	}
+
+	// The normal is against the direction of travel when entering and
+	// along it when leaving.
+	t, axis, sign := tmin, minAxis, -1.0
+	if tmin < 0 {
+		t, axis, sign = tmax, maxAxis, 1.0
+	}
+	if axis < 0 {
+		// degenerate zero length direction
+		return
+	}
+	var n [3]float64
+	n[axis] = math.Copysign(1, dir[axis]) * sign
+	return RayHit{t, this.At(t), Vec3{n[0], n[1], n[2]}}, true
+}
+
+// Intersect the ray with the oriented box.
+// Returns the first point along the ray on the surface of the box. When the
+// ray starts inside the box this is where the ray leaves the box.
+// The normal points out of the box.
+func (this Ray) IntersectOBB(box OBB) (hit RayHit, ok bool) {
+	inv := box.Rotation.Conjugate()
+	local := Ray{box.toLocal(this.Origin), inv.RotateVec3(this.Dir)}
+	h := box.HalfExtents
+	if hit, ok = local.IntersectAABB(AABB{h.MultScalar(-1), h}); !ok {
+		return
+	}
+	hit.Point = this.At(hit.Distance)
+	hit.Normal = box.Rotation.RotateVec3(hit.Normal)
+	return
+}
+
+// Intersect the ray with the sphere.
+// Returns the first point along the ray on the surface of the sphere. When the
+// ray starts inside the sphere this is where the ray leaves the sphere.
+// The normal points out of the sphere.
+func (this Ray) IntersectSphere(s Sphere) (hit RayHit, ok bool) {
+	// Solve |o + t*d - c|^2 = r^2 for t
+	m := this.Origin.Sub(s.Center)
+	a := this.Dir.LengthSq()
+	b := m.Dot(this.Dir)
+	c := m.LengthSq() - s.Radius*s.Radius
+	if c > 0 && b > 0 {
+		// outside of the sphere and pointing away
+		return
+	}
+	disc := b*b - a*c
+	if disc < 0 || a == 0 {
+		return
+	}
+
+	t := (-b - math.Sqrt(disc)) / a
+	if t < 0 {
+		// inside the sphere, use the exit point
+		t = (-b + math.Sqrt(disc)) / a
+	}
+	p := this.At(t)
+	return RayHit{t, p, p.Sub(s.Center).Normalize()}, true
+}
+
+// Intersect the ray with the plane.
+// Misses if the ray is parallel to the plane or points away from it.
+// The normal returned is the normal of the plane.
+func (this Ray) IntersectPlane(p Plane) (hit RayHit, ok bool) {
+	denom := p.Normal.Dot(this.Dir)
+	if math.Abs(denom) < epsilon {
+		return
+	}
+	t := (p.D - p.Normal.Dot(this.Origin)) / denom
+	if t < 0 {
+		return
+	}
+	return RayHit{t, this.At(t), p.Normal.Normalize()}, true
+}
+
+// =============================================================================
+
+// Return true if the two spheres overlap or touch.
+func (this Sphere) IntersectsSphere(other Sphere) bool {
+	r := this.Radius + other.Radius
+	return this.Center.Sub(other.Center).LengthSq() <= r*r
+}
+
+// Return true if the sphere and box overlap or touch.
+func (this Sphere) IntersectsAABB(box AABB) bool {
+	return box.ClosestPoint(this.Center).Sub(this.Center).LengthSq() <= this.Radius*this.Radius
+}
+
+// Return true if the two boxes overlap or touch.
+func (this AABB) IntersectsAABB(other AABB) bool {
+	return this.Min.X <= other.Max.X && this.Max.X >= other.Min.X &&
+		this.Min.Y <= other.Max.Y && this.Max.Y >= other.Min.Y &&
+		this.Min.Z <= other.Max.Z && this.Max.Z >= other.Min.Z
+}
+
+// Return true if the sphere and box overlap or touch.
+func (this AABB) IntersectsSphere(s Sphere) bool {
+	return s.IntersectsAABB(this)
+}
+
+// Return true if the triangle and box overlap or touch.
+func (this AABB) IntersectsTriangle(tri Triangle) bool {
+	return tri.IntersectsAABB(this)
+}
+
+// Return true if the two oriented boxes overlap or touch.
+// Uses the separating axis test over the 15 candidate axes.
+func (this OBB) IntersectsOBB(other OBB) bool {
+	// Reference : Christer Ericson, "Real-Time Collision Detection" 4.4.1
+	a := this.Axes()
+	b := other.Axes()
+	ea := [3]float64{this.HalfExtents.X, this.HalfExtents.Y, this.HalfExtents.Z}
+	eb := [3]float64{other.HalfExtents.X, other.HalfExtents.Y, other.HalfExtents.Z}
+
+	// Rotation expressing other in the frame of this, and its absolute value.
+	// Epsilon guards against parallel edges giving a near zero cross product.
+	var r, abs [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = a[i].Dot(b[j])
+			abs[i][j] = math.Abs(r[i][j]) + epsilon
+		}
+	}
+
+	// translation in the frame of this
+	d := other.Center.Sub(this.Center)
+	t := [3]float64{d.Dot(a[0]), d.Dot(a[1]), d.Dot(a[2])}
+
+	// axes of this
+	for i := 0; i < 3; i++ {
+		rb := eb[0]*abs[i][0] + eb[1]*abs[i][1] + eb[2]*abs[i][2]
+		if math.Abs(t[i]) > ea[i]+rb {
+			return false
+		}
+	}
+	// axes of other
+	for j := 0; j < 3; j++ {
+		ra := ea[0]*abs[0][j] + ea[1]*abs[1][j] + ea[2]*abs[2][j]
+		tb := t[0]*r[0][j] + t[1]*r[1][j] + t[2]*r[2][j]
+		if math.Abs(tb) > ra+eb[j] {
+			return false
+		}
+	}
+	// cross products of the axes a[i] x b[j]
+	for i := 0; i < 3; i++ {
+		i1, i2 := (i+1)%3, (i+2)%3
+		for j := 0; j < 3; j++ {
+			j1, j2 := (j+1)%3, (j+2)%3
+			ra := ea[i1]*abs[i2][j] + ea[i2]*abs[i1][j]
+			rb := eb[j1]*abs[i][j2] + eb[j2]*abs[i][j1]
+			if math.Abs(t[i2]*r[i1][j]-t[i1]*r[i2][j]) > ra+rb {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Return true if the triangle and box overlap or touch.
+// Uses the separating axis test over the 13 candidate axes.
+func (this Triangle) IntersectsAABB(box AABB) bool {
+	// Reference : Tomas Akenine-Moller, "Fast 3D Triangle-Box Overlap Testing", 2001
+	// Move the box to the origin
+	c := box.Center()
+	e := box.Extents()
+	v := [3]Vec3{this.A.Sub(c), this.B.Sub(c), this.C.Sub(c)}
+	f := [3]Vec3{v[1].Sub(v[0]), v[2].Sub(v[1]), v[0].Sub(v[2])}
+
+	// Return true if the axis separates the triangle and the box
+	separated := func(axis Vec3) bool {
+		p0, p1, p2 := v[0].Dot(axis), v[1].Dot(axis), v[2].Dot(axis)
+		r := e.X*math.Abs(axis.X) + e.Y*math.Abs(axis.Y) + e.Z*math.Abs(axis.Z)
+		return math.Min(p0, math.Min(p1, p2)) > r || math.Max(p0, math.Max(p1, p2)) < -r
+	}
+
+	// 9 axes from the cross products of the box axes and the triangle edges
+	units := [3]Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for _, u := range units {
+		for _, edge := range f {
+			if separated(u.Cross(edge)) {
+				return false
+			}
+		}
+	}
+
+	// 3 face normals of the box
+	for _, u := range units {
+		if separated(u) {
+			return false
+		}
+	}
+
+	// normal of the triangle
+	return !separated(f[0].Cross(f[1]))
+}
@@ -0,0 +1,293 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntersectTriangleRay(t *testing.T) {
+	tri := Triangle{Vec3{0, 0, 0}, Vec3{2, 0, 0}, Vec3{0, 2, 0}}
+	cases := []struct {
+		ray  Ray
+		ok   bool
+		dist float64
+	}{
+		{Ray{Vec3{0.5, 0.5, 5}, Vec3{0, 0, -1}}, true, 5},
+		// back face
+		{Ray{Vec3{0.5, 0.5, -3}, Vec3{0, 0, 1}}, true, 3},
+		// on an edge and a corner
+		{Ray{Vec3{1, 1, 2}, Vec3{0, 0, -1}}, true, 2},
+		{Ray{Vec3{0, 0, 2}, Vec3{0, 0, -1}}, true, 2},
+		// misses
+		{Ray{Vec3{1.5, 1.5, 2}, Vec3{0, 0, -1}}, false, 0},
+		{Ray{Vec3{-0.1, 0.5, 2}, Vec3{0, 0, -1}}, false, 0},
+		{Ray{Vec3{0.5, 0.5, 2}, Vec3{0, 0, 1}}, false, 0},
+		{Ray{Vec3{0.5, 0.5, 0}, Vec3{1, 0, 0}}, false, 0},
+		// diagonal
+		{Ray{Vec3{-1, 0.5, 1}, Vec3{1, 0, -1}.Normalize()}, true, math.Sqrt(2)},
+	}
+
+	for testIndex, c := range cases {
+		hit, ok := c.ray.IntersectTriangle(tri)
+		if ok != c.ok {
+			t.Errorf("TestIntersectTriangleRay %d %v", testIndex, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if !closeEq(hit.Distance, c.dist, epsilon) || !hit.Point.Eq(c.ray.At(c.dist)) ||
+			!hit.Normal.Eq(Vec3{0, 0, 1}) || !tri.Contains(hit.Point) {
+			t.Errorf("TestIntersectTriangleRay hit %d %v", testIndex, hit)
+		}
+	}
+}
+
+func TestIntersectAABBRay(t *testing.T) {
+	box := AABB{Vec3{-1, -1, -1}, Vec3{1, 1, 1}}
+	cases := []struct {
+		ray    Ray
+		ok     bool
+		dist   float64
+		normal Vec3
+	}{
+		{Ray{Vec3{-5, 0, 0}, Vec3{1, 0, 0}}, true, 4, Vec3{-1, 0, 0}},
+		{Ray{Vec3{0, 5, 0}, Vec3{0, -1, 0}}, true, 4, Vec3{0, 1, 0}},
+		{Ray{Vec3{0.5, 0.5, -3}, Vec3{0, 0, 1}}, true, 2, Vec3{0, 0, -1}},
+		{Ray{Vec3{-3, -2, 0}, Vec3{1, 1, 0}.Normalize()}, true, 2 * math.Sqrt(2), Vec3{-1, 0, 0}},
+		// inside, hit on the way out
+		{Ray{Vec3{0, 0, 0}, Vec3{0, 0, 1}}, true, 1, Vec3{0, 0, 1}},
+		{Ray{Vec3{0.5, 0, 0}, Vec3{-1, 0, 0}}, true, 1.5, Vec3{-1, 0, 0}},
+		// along a face
+		{Ray{Vec3{-5, 1, 0}, Vec3{1, 0, 0}}, true, 4, Vec3{-1, 0, 0}},
+		// misses
+		{Ray{Vec3{-5, 0, 0}, Vec3{-1, 0, 0}}, false, 0, Vec3{}},
+		{Ray{Vec3{-5, 2, 0}, Vec3{1, 0, 0}}, false, 0, Vec3{}},
+		{Ray{Vec3{-3, 0, 0}, Vec3{1, 1, 0}.Normalize()}, false, 0, Vec3{}},
+		{Ray{Vec3{0, 0, 0}, Vec3{0, 0, 0}}, false, 0, Vec3{}},
+	}
+
+	for testIndex, c := range cases {
+		hit, ok := c.ray.IntersectAABB(box)
+		if ok != c.ok {
+			t.Errorf("TestIntersectAABBRay %d %v", testIndex, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if !closeEq(hit.Distance, c.dist, epsilon) || !hit.Point.Eq(c.ray.At(c.dist)) ||
+			!hit.Normal.Eq(c.normal) {
+			t.Errorf("TestIntersectAABBRay hit %d %v", testIndex, hit)
+		}
+	}
+}
+
+func TestIntersectOBBRay(t *testing.T) {
+	var q Quat
+	q.FromAxisAngle(math.Pi/4, 0, 0, 1)
+	box := OBB{Vec3{5, 0, 0}, Vec3{1, 1, 1}, q}
+	s := math.Sqrt(2)
+
+	hit, ok := Ray{Vec3{0, 0, 0}, Vec3{1, 0, 0}}.IntersectOBB(box)
+	if !ok || !closeEq(hit.Distance, 5-s, epsilon) || !hit.Point.Eq(Vec3{5 - s, 0, 0}) {
+		t.Errorf("TestIntersectOBBRay %v %v", ok, hit)
+	}
+	// hits the corner so the normal is either of the two faces
+	n1 := Vec3{-s / 2, -s / 2, 0}
+	n2 := Vec3{-s / 2, s / 2, 0}
+	if !hit.Normal.Eq(n1) && !hit.Normal.Eq(n2) {
+		t.Errorf("TestIntersectOBBRay normal %v", hit.Normal)
+	}
+
+	hit, ok = Ray{Vec3{5, 5, 0}, Vec3{0, -1, 0}}.IntersectOBB(box)
+	if !ok || !closeEq(hit.Distance, 5-s, epsilon) {
+		t.Errorf("TestIntersectOBBRay y %v %v", ok, hit)
+	}
+	hit, ok = Ray{Vec3{5, 0, 5}, Vec3{0, 0, -1}}.IntersectOBB(box)
+	if !ok || !closeEq(hit.Distance, 4, epsilon) || !hit.Normal.Eq(Vec3{0, 0, 1}) {
+		t.Errorf("TestIntersectOBBRay z %v %v", ok, hit)
+	}
+	if _, ok = (Ray{Vec3{0, 1.5, 0}, Vec3{1, 0, 0}}).IntersectOBB(box); ok {
+		t.Errorf("TestIntersectOBBRay miss")
+	}
+}
+
+func TestIntersectSphereRay(t *testing.T) {
+	s := Sphere{Vec3{0, 0, 0}, 2}
+	cases := []struct {
+		ray    Ray
+		ok     bool
+		dist   float64
+		normal Vec3
+	}{
+		{Ray{Vec3{-5, 0, 0}, Vec3{1, 0, 0}}, true, 3, Vec3{-1, 0, 0}},
+		{Ray{Vec3{0, 0, 10}, Vec3{0, 0, -1}}, true, 8, Vec3{0, 0, 1}},
+		// tangent
+		{Ray{Vec3{-5, 2, 0}, Vec3{1, 0, 0}}, true, 5, Vec3{0, 1, 0}},
+		// inside
+		{Ray{Vec3{0, 0, 0}, Vec3{0, 1, 0}}, true, 2, Vec3{0, 1, 0}},
+		{Ray{Vec3{1, 0, 0}, Vec3{-1, 0, 0}}, true, 3, Vec3{-1, 0, 0}},
+		// misses
+		{Ray{Vec3{-5, 0, 0}, Vec3{-1, 0, 0}}, false, 0, Vec3{}},
+		{Ray{Vec3{-5, 2.1, 0}, Vec3{1, 0, 0}}, false, 0, Vec3{}},
+	}
+
+	for testIndex, c := range cases {
+		hit, ok := c.ray.IntersectSphere(s)
+		if ok != c.ok {
+			t.Errorf("TestIntersectSphereRay %d %v", testIndex, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if !closeEq(hit.Distance, c.dist, epsilon) || !hit.Point.Eq(c.ray.At(c.dist)) ||
+			!hit.Normal.Eq(c.normal) {
+			t.Errorf("TestIntersectSphereRay hit %d %v", testIndex, hit)
+		}
+	}
+}
+
+func TestIntersectPlaneRay(t *testing.T) {
+	p := Plane{Vec3{0, 1, 0}, 2}
+	cases := []struct {
+		ray  Ray
+		ok   bool
+		dist float64
+	}{
+		{Ray{Vec3{0, 5, 0}, Vec3{0, -1, 0}}, true, 3},
+		{Ray{Vec3{1, -1, 1}, Vec3{0, 1, 0}}, true, 3},
+		{Ray{Vec3{0, 0, 0}, Vec3{1, 1, 0}.Normalize()}, true, 2 * math.Sqrt(2)},
+		{Ray{Vec3{0, 2, 0}, Vec3{1, 0, 0}}, false, 0},
+		{Ray{Vec3{0, 5, 0}, Vec3{1, 0, 0}}, false, 0},
+		{Ray{Vec3{0, 5, 0}, Vec3{0, 1, 0}}, false, 0},
+	}
+
+	for testIndex, c := range cases {
+		hit, ok := c.ray.IntersectPlane(p)
+		if ok != c.ok {
+			t.Errorf("TestIntersectPlaneRay %d %v", testIndex, ok)
+			continue
+		}
+		if ok && (!closeEq(hit.Distance, c.dist, epsilon) || !p.Contains(hit.Point) ||
+			!hit.Normal.Eq(p.Normal)) {
+			t.Errorf("TestIntersectPlaneRay hit %d %v", testIndex, hit)
+		}
+	}
+}
+
+func TestIntersectsVolumes(t *testing.T) {
+	box := AABB{Vec3{0, 0, 0}, Vec3{2, 2, 2}}
+	sphereCases := []struct {
+		s    Sphere
+		want bool
+	}{
+		{Sphere{Vec3{1, 1, 1}, 0.5}, true},
+		{Sphere{Vec3{3, 1, 1}, 1}, true},
+		{Sphere{Vec3{3, 1, 1}, 0.9}, false},
+		{Sphere{Vec3{3, 3, 3}, 1.7}, false},
+		{Sphere{Vec3{3, 3, 3}, 1.8}, true},
+	}
+	for testIndex, c := range sphereCases {
+		if get := c.s.IntersectsAABB(box); get != c.want {
+			t.Errorf("TestIntersectsVolumes sphere %d %v", testIndex, get)
+		}
+		if get := box.IntersectsSphere(c.s); get != c.want {
+			t.Errorf("TestIntersectsVolumes sphere box %d %v", testIndex, get)
+		}
+	}
+
+	boxCases := []struct {
+		b    AABB
+		want bool
+	}{
+		{AABB{Vec3{1, 1, 1}, Vec3{3, 3, 3}}, true},
+		{AABB{Vec3{2, 0, 0}, Vec3{3, 1, 1}}, true},
+		{AABB{Vec3{0.5, 0.5, 0.5}, Vec3{1, 1, 1}}, true},
+		{AABB{Vec3{2.1, 0, 0}, Vec3{3, 1, 1}}, false},
+		{AABB{Vec3{0, 0, -2}, Vec3{1, 1, -0.1}}, false},
+	}
+	for testIndex, c := range boxCases {
+		if get := box.IntersectsAABB(c.b); get != c.want {
+			t.Errorf("TestIntersectsVolumes box %d %v", testIndex, get)
+		}
+		if get := c.b.IntersectsAABB(box); get != c.want {
+			t.Errorf("TestIntersectsVolumes box swap %d %v", testIndex, get)
+		}
+	}
+
+	if !(Sphere{Vec3{0, 0, 0}, 1}).IntersectsSphere(Sphere{Vec3{0, 3, 0}, 2}) ||
+		(Sphere{Vec3{0, 0, 0}, 1}).IntersectsSphere(Sphere{Vec3{0, 3.1, 0}, 2}) {
+		t.Errorf("TestIntersectsVolumes spheres")
+	}
+}
+
+func TestIntersectsOBB(t *testing.T) {
+	var q45, qx, qy Quat
+	q45.FromAxisAngle(math.Pi/4, 0, 0, 1)
+	qx.FromAxisAngle(math.Pi/4, 1, 0, 0)
+	qy.FromAxisAngle(math.Pi/4, 0, 1, 0)
+	unit := Vec3{1, 1, 1}
+	id := Quat{1, 0, 0, 0}
+	s := math.Sqrt(2)
+
+	cases := []struct {
+		a, b OBB
+		want bool
+	}{
+		{OBB{Vec3{0, 0, 0}, unit, id}, OBB{Vec3{1.5, 0, 0}, unit, id}, true},
+		{OBB{Vec3{0, 0, 0}, unit, id}, OBB{Vec3{2.1, 0, 0}, unit, id}, false},
+		// rotated corner pointing at a face
+		{OBB{Vec3{0, 0, 0}, unit, id}, OBB{Vec3{1 + s - 0.1, 0, 0}, unit, q45}, true},
+		{OBB{Vec3{0, 0, 0}, unit, id}, OBB{Vec3{1 + s + 0.1, 0, 0}, unit, q45}, false},
+		// corner to corner along the diagonal
+		{OBB{Vec3{0, 0, 0}, unit, q45}, OBB{Vec3{2 * s, 0, 0}, unit, q45}, true},
+		{OBB{Vec3{0, 0, 0}, unit, q45}, OBB{Vec3{2*s + 0.1, 0, 0}, unit, q45}, false},
+		{OBB{Vec3{0, 0, 0}, unit, q45}, OBB{Vec3{2 * s, 2*s + 0.1, 0}, unit, q45}, false},
+		// edge to edge, only separated by the cross product of the edges
+		{OBB{Vec3{0, 0, 0}, unit, q45}, OBB{Vec3{2*s + 0.1, 0, 0}, unit, qy}, false},
+		{OBB{Vec3{0, 0, 0}, unit, q45}, OBB{Vec3{2*s - 0.1, 0, 0}, unit, qy}, true},
+		{OBB{Vec3{0, 0, 0}, unit, qx}, OBB{Vec3{0, 1.5, 1.5}, unit, qy}, true},
+	}
+
+	for testIndex, c := range cases {
+		if get := c.a.IntersectsOBB(c.b); get != c.want {
+			t.Errorf("TestIntersectsOBB %d %v", testIndex, get)
+		}
+		if get := c.b.IntersectsOBB(c.a); get != c.want {
+			t.Errorf("TestIntersectsOBB swap %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestIntersectsTriangleAABB(t *testing.T) {
+	box := AABB{Vec3{-1, -1, -1}, Vec3{1, 1, 1}}
+	cases := []struct {
+		tri  Triangle
+		want bool
+	}{
+		// triangle inside the box
+		{Triangle{Vec3{0, 0, 0}, Vec3{0.5, 0, 0}, Vec3{0, 0.5, 0}}, true},
+		// box inside a large triangle
+		{Triangle{Vec3{-10, -10, 0}, Vec3{10, -10, 0}, Vec3{0, 10, 0}}, true},
+		// vertex poking into the box
+		{Triangle{Vec3{0.9, 0.9, 0.9}, Vec3{5, 0, 0}, Vec3{0, 5, 0}}, true},
+		// plane of the triangle misses the box
+		{Triangle{Vec3{-10, -10, 2}, Vec3{10, -10, 2}, Vec3{0, 10, 2}}, false},
+		// separated along a box face
+		{Triangle{Vec3{2, 0, 0}, Vec3{3, 0, 0}, Vec3{2, 1, 0}}, false},
+		// crosses the corner region but misses the box, needs an edge axis
+		{Triangle{Vec3{2.5, 0, 0}, Vec3{0, 2.5, 0}, Vec3{2.5, 2.5, 1}}, false},
+		{Triangle{Vec3{1.5, 0, 0}, Vec3{0, 1.5, 0}, Vec3{0, 0, 5}}, true},
+	}
+
+	for testIndex, c := range cases {
+		if get := c.tri.IntersectsAABB(box); get != c.want {
+			t.Errorf("TestIntersectsTriangleAABB %d %v", testIndex, get)
+		}
+		if get := box.IntersectsTriangle(c.tri); get != c.want {
+			t.Errorf("TestIntersectsTriangleAABB box %d %v", testIndex, get)
+		}
+	}
+}
@@ -0,0 +1,735 @@
+package lmath
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Returned by the solvers when the system has no unique solution.
+var ErrSingularMatrix = errors.New("lmath: singular matrix")
+
+// Returned by the least squares solvers when there isn't one right hand side
+// value for each row.
+var ErrLengthMismatch = errors.New("lmath: mismatched lengths")
+
+// This file holds the matrix decompositions of Mat3 and Mat4.
+// The algorithms work on an n x n row-major matrix stored in the start of a
+// [16]float64 so they can be shared between both sizes without allocating.
+
+const (
+	// Most sweeps the Jacobi methods take before giving up on convergence
+	jacobiSweeps = 50
+	// Relative size of the off diagonal terms at which Jacobi stops rotating,
+	// close to the float64 precision since it converges quadratically
+	jacobiEpsilon = 1e-15
+)
+
+// Decompose the matrix in place into P*A = L*U using partial pivoting.
+// L is unit lower triangular and stored below the diagonal, U is stored on
+// and above the diagonal. Row i of P*A is row perm[i] of A.
+// ok is false when the matrix is singular, the result is still valid.
+// A pivot is treated as zero when it is below eps relative to the largest
+// entry, so uniformly scaling the matrix doesn't change ok.
+func luDecompose(a *[16]float64, n int, eps float64) (perm [4]int, ok bool) {
+	ok = true
+	scale := 0.0
+	for i := 0; i < n; i++ {
+		perm[i] = i
+		for j := 0; j < n; j++ {
+			scale = math.Max(scale, math.Abs(a[i*n+j]))
+		}
+	}
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i*n+k]) > math.Abs(a[p*n+k]) {
+				p = i
+			}
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				a[k*n+j], a[p*n+j] = a[p*n+j], a[k*n+j]
+			}
+			perm[k], perm[p] = perm[p], perm[k]
+		}
+
+		pivot := a[k*n+k]
+		if math.Abs(pivot) <= eps*scale {
+			ok = false
+		}
+		if pivot == 0 {
+			// nothing left to eliminate in this column
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			a[i*n+k] /= pivot
+			for j := k + 1; j < n; j++ {
+				a[i*n+j] -= a[i*n+k] * a[k*n+j]
+			}
+		}
+	}
+	return
+}
+
+// Solve A*x = b using the result of luDecompose.
+func luSolve(lu *[16]float64, perm [4]int, n int, b [4]float64) (x [4]float64) {
+	// forward substitution L*y = P*b
+	for i := 0; i < n; i++ {
+		x[i] = b[perm[i]]
+		for j := 0; j < i; j++ {
+			x[i] -= lu[i*n+j] * x[j]
+		}
+	}
+	// back substitution U*x = y
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= lu[i*n+j] * x[j]
+		}
+		x[i] /= lu[i*n+i]
+	}
+	return
+}
+
+// Decompose the matrix into A = Q*R using Householder reflections.
+// Q is orthogonal and R upper triangular with a non-negative diagonal.
+// A column is treated as zero below the diagonal when its norm there is below
+// epsilon relative to the largest entry of the matrix.
+func householderQR(a [16]float64, n int) (q, r [16]float64) {
+	r = a
+	scale := 0.0
+	for i := 0; i < n; i++ {
+		q[i*n+i] = 1
+		for j := 0; j < n; j++ {
+			scale = math.Max(scale, math.Abs(a[i*n+j]))
+		}
+	}
+	limit := epsilon * scale
+
+	var v [4]float64
+	for k := 0; k < n-1; k++ {
+		// reflect the column below the diagonal onto the axis
+		var norm float64
+		for i := k; i < n; i++ {
+			norm += r[i*n+k] * r[i*n+k]
+		}
+		norm = math.Sqrt(norm)
+		if norm <= limit {
+			// the column is already zero below the diagonal
+			for i := k + 1; i < n; i++ {
+				r[i*n+k] = 0
+			}
+			continue
+		}
+		alpha := -math.Copysign(norm, r[k*n+k])
+
+		var vlen float64
+		for i := k; i < n; i++ {
+			v[i] = r[i*n+k]
+			if i == k {
+				v[i] -= alpha
+			}
+			vlen += v[i] * v[i]
+		}
+		vlen = math.Sqrt(vlen)
+		if vlen <= limit {
+			continue
+		}
+		for i := k; i < n; i++ {
+			v[i] /= vlen
+		}
+
+		// R = H*R and Q = Q*H where H = I - 2*v*v^T
+		for j := 0; j < n; j++ {
+			var d float64
+			for i := k; i < n; i++ {
+				d += v[i] * r[i*n+j]
+			}
+			for i := k; i < n; i++ {
+				r[i*n+j] -= 2 * v[i] * d
+			}
+		}
+		for i := 0; i < n; i++ {
+			var d float64
+			for l := k; l < n; l++ {
+				d += q[i*n+l] * v[l]
+			}
+			for l := k; l < n; l++ {
+				q[i*n+l] -= 2 * d * v[l]
+			}
+		}
+		for i := k + 1; i < n; i++ {
+			r[i*n+k] = 0
+		}
+	}
+
+	// make the diagonal of R positive so the result is unique
+	for i := 0; i < n; i++ {
+		if r[i*n+i] < 0 {
+			for j := 0; j < n; j++ {
+				r[i*n+j] = -r[i*n+j]
+				q[j*n+i] = -q[j*n+i]
+			}
+		}
+	}
+	return
+}
+
+// Decompose the symmetric positive definite matrix into A = L*L^T.
+// Only the lower triangle of a is read.
+// ok is false when the matrix is not positive definite.
+func cholesky(a [16]float64, n int) (l [16]float64, ok bool) {
+	for j := 0; j < n; j++ {
+		d := a[j*n+j]
+		for k := 0; k < j; k++ {
+			d -= l[j*n+k] * l[j*n+k]
+		}
+		if d <= 0 {
+			return l, false
+		}
+		l[j*n+j] = math.Sqrt(d)
+		for i := j + 1; i < n; i++ {
+			s := a[i*n+j]
+			for k := 0; k < j; k++ {
+				s -= l[i*n+k] * l[j*n+k]
+			}
+			l[i*n+j] = s / l[j*n+j]
+		}
+	}
+	return l, true
+}
+
+// Solve A*x = b using the result of cholesky.
+func choleskySolve(l *[16]float64, n int, b [4]float64) (x [4]float64) {
+	// L*y = b then L^T*x = y
+	for i := 0; i < n; i++ {
+		x[i] = b[i]
+		for j := 0; j < i; j++ {
+			x[i] -= l[i*n+j] * x[j]
+		}
+		x[i] /= l[i*n+i]
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= l[j*n+i] * x[j]
+		}
+		x[i] /= l[i*n+i]
+	}
+	return
+}
+
+// Add the equation row.Dot(x) = y to the least squares QR factorization.
+// r holds the upper triangular R and qb holds Q^T*b of the rows added so far.
+// Givens rotations fold the row into R, so A^T*A is never formed and its
+// condition number isn't squared.
+func givensAddRow(r *[16]float64, qb *[4]float64, n int, row [4]float64, y float64) {
+	for i := 0; i < n; i++ {
+		if row[i] == 0 {
+			continue
+		}
+		// rotate (r[i][i], row[i]) onto (h, 0)
+		h := math.Hypot(r[i*n+i], row[i])
+		c, s := r[i*n+i]/h, row[i]/h
+		for j := i; j < n; j++ {
+			r[i*n+j], row[j] = c*r[i*n+j]+s*row[j], c*row[j]-s*r[i*n+j]
+		}
+		qb[i], y = c*qb[i]+s*y, c*y-s*qb[i]
+	}
+}
+
+// Solve R*x = qb by back substitution using the result of givensAddRow.
+// ok is false when a diagonal term of R is below epsilon relative to the
+// largest one, as then the rows don't span all n dimensions.
+func givensSolve(r *[16]float64, qb [4]float64, n int) (x [4]float64, ok bool) {
+	largest := 0.0
+	for i := 0; i < n; i++ {
+		largest = math.Max(largest, math.Abs(r[i*n+i]))
+	}
+	for i := 0; i < n; i++ {
+		if math.Abs(r[i*n+i]) <= epsilon*largest {
+			return x, false
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		x[i] = qb[i]
+		for j := i + 1; j < n; j++ {
+			x[i] -= r[i*n+j] * x[j]
+		}
+		x[i] /= r[i*n+i]
+	}
+	return x, true
+}
+
+// Return the Jacobi rotation (c, s) which zeroes the off diagonal term of
+// the 2x2 symmetric matrix [app apq; apq aqq].
+func jacobiRotation(app, aqq, apq float64) (c, s float64) {
+	theta := (aqq - app) / (2 * apq)
+	t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+	if theta < 0 {
+		t = -t
+	}
+	c = 1 / math.Sqrt(t*t+1)
+	return c, t * c
+}
+
+// Find the eigenvalues and eigenvectors of the symmetric matrix using the
+// cyclic Jacobi method. The eigenvectors are the columns of vectors and
+// are sorted by decreasing eigenvalue.
+func jacobiEigen(a [16]float64, n int) (values [4]float64, vectors [16]float64) {
+	for i := 0; i < n; i++ {
+		vectors[i*n+i] = 1
+	}
+
+	for sweep := 0; sweep < jacobiSweeps; sweep++ {
+		var off, total float64
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				total += a[i*n+j] * a[i*n+j]
+				if i != j {
+					off += a[i*n+j] * a[i*n+j]
+				}
+			}
+		}
+		if off <= jacobiEpsilon*jacobiEpsilon*total {
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p*n+q] == 0 {
+					continue
+				}
+				c, s := jacobiRotation(a[p*n+p], a[q*n+q], a[p*n+q])
+				// A = J^T * A * J and V = V * J
+				for k := 0; k < n; k++ {
+					akp, akq := a[k*n+p], a[k*n+q]
+					a[k*n+p], a[k*n+q] = c*akp-s*akq, s*akp+c*akq
+				}
+				for k := 0; k < n; k++ {
+					apk, aqk := a[p*n+k], a[q*n+k]
+					a[p*n+k], a[q*n+k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := vectors[k*n+p], vectors[k*n+q]
+					vectors[k*n+p], vectors[k*n+q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		values[i] = a[i*n+i]
+	}
+	sortColumns(&values, n, &vectors)
+	return
+}
+
+// Sort the values in decreasing order along with the matching columns of
+// each of the matrices.
+func sortColumns(values *[4]float64, n int, ms ...*[16]float64) {
+	order := []int{0, 1, 2, 3}[:n]
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+	v := *values
+	for k, from := range order {
+		values[k] = v[from]
+	}
+	for _, m := range ms {
+		old := *m
+		for k, from := range order {
+			for i := 0; i < n; i++ {
+				m[i*n+k] = old[i*n+from]
+			}
+		}
+	}
+}
+
+// Orthogonalize the columns of A with the one-sided Jacobi method, returning
+// A*V = U*diag(s) in w along with the orthogonal V and the singular values
+// sorted in decreasing order. The columns of U are w divided by s.
+func jacobiSVD(a [16]float64, n int) (w [16]float64, s [4]float64, v [16]float64) {
+	u := a
+	for i := 0; i < n; i++ {
+		v[i*n+i] = 1
+	}
+
+	for sweep := 0; sweep < jacobiSweeps; sweep++ {
+		converged := true
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				var alpha, beta, gamma float64
+				for i := 0; i < n; i++ {
+					alpha += u[i*n+p] * u[i*n+p]
+					beta += u[i*n+q] * u[i*n+q]
+					gamma += u[i*n+p] * u[i*n+q]
+				}
+				if math.Abs(gamma) <= jacobiEpsilon*math.Sqrt(alpha*beta) || gamma == 0 {
+					continue
+				}
+				converged = false
+
+				// rotate the columns p and q to be orthogonal
+				c, sn := jacobiRotation(alpha, beta, gamma)
+				for i := 0; i < n; i++ {
+					uip, uiq := u[i*n+p], u[i*n+q]
+					u[i*n+p], u[i*n+q] = c*uip-sn*uiq, sn*uip+c*uiq
+					vip, viq := v[i*n+p], v[i*n+q]
+					v[i*n+p], v[i*n+q] = c*vip-sn*viq, sn*vip+c*viq
+				}
+			}
+		}
+		if converged {
+			break
+		}
+	}
+
+	// the singular values are the lengths of the columns of A*V = U*S
+	for j := 0; j < n; j++ {
+		var d float64
+		for i := 0; i < n; i++ {
+			d += u[i*n+j] * u[i*n+j]
+		}
+		s[j] = math.Sqrt(d)
+	}
+	sortColumns(&s, n, &u, &v)
+	return u, s, v
+}
+
+// Return the Moore-Penrose pseudo inverse of the matrix, V*diag(1/s)*U^T with
+// the singular values close to zero left as zero.
+func pseudoInverse(a [16]float64, n int) (out [16]float64) {
+	w, s, v := jacobiSVD(a, n)
+	// singular values negligible next to the largest one are treated as zero
+	limit := epsilon * s[0]
+	for k := 0; k < n; k++ {
+		if s[k] <= limit {
+			break
+		}
+		// the column of U is w/s so each term is v*w^T/s^2
+		inv := 1 / (s[k] * s[k])
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				out[i*n+j] += v[i*n+k] * w[j*n+k] * inv
+			}
+		}
+	}
+	return
+}
+
+// Return the singular value decomposition A = U*diag(s)*V^T of the 3x3 matrix.
+// U and V are orthogonal and the singular values are sorted in decreasing order.
+func svd3(a [16]float64) (u [16]float64, s [4]float64, v [16]float64) {
+	const n = 3
+	u, s, v = jacobiSVD(a, n)
+
+	// normalize the columns of U, completing the basis where the singular
+	// values are zero
+	var cols [3]Vec3
+	for j := 0; j < n; j++ {
+		cols[j] = Vec3{u[j], u[n+j], u[2*n+j]}
+	}
+	// the singular values are compared relative to the largest one
+	limit := epsilon * s[0]
+	switch {
+	case s[0] == 0:
+		cols = [3]Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	case s[1] <= limit:
+		cols[0] = cols[0].DivScalar(s[0])
+		cols[1] = perpendicular(cols[0])
+		cols[2] = cols[0].Cross(cols[1])
+	case s[2] <= limit:
+		cols[0] = cols[0].DivScalar(s[0])
+		cols[1] = cols[1].DivScalar(s[1])
+		cols[2] = cols[0].Cross(cols[1])
+	default:
+		for j := range cols {
+			cols[j] = cols[j].DivScalar(s[j])
+		}
+	}
+	for j := 0; j < n; j++ {
+		u[j], u[n+j], u[2*n+j] = cols[j].X, cols[j].Y, cols[j].Z
+	}
+	return
+}
+
+// Return a unit vector perpendicular to the unit vector v.
+func perpendicular(v Vec3) Vec3 {
+	// cross with the axis v is least aligned with
+	x, y, z := math.Abs(v.X), math.Abs(v.Y), math.Abs(v.Z)
+	axis := Vec3{0, 0, 1}
+	if x <= y && x <= z {
+		axis = Vec3{1, 0, 0}
+	} else if y <= z {
+		axis = Vec3{0, 1, 0}
+	}
+	return v.Cross(axis).Normalize()
+}
+
+// Copy the matrix into the start of a [16]float64 for the shared algorithms.
+func (this Mat3) linalg() (a [16]float64) {
+	copy(a[:], this.mat[:])
+	return
+}
+
+// Return the 3x3 matrix stored in the start of a.
+func mat3FromLinalg(a [16]float64) (out Mat3) {
+	copy(out.mat[:], a[:9])
+	return
+}
+
+// Split the packed LU result into the unit lower and upper triangular parts.
+func splitLU(a [16]float64, n int) (l, u [16]float64) {
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			switch {
+			case j < i:
+				l[i*n+j] = a[i*n+j]
+			case j == i:
+				l[i*n+j] = 1
+				u[i*n+j] = a[i*n+j]
+			default:
+				u[i*n+j] = a[i*n+j]
+			}
+		}
+	}
+	return
+}
+
+// Decompose the matrix into P*A = L*U using partial pivoting, where L is unit
+// lower triangular and U is upper triangular. Row i of P*A is row perm[i] of A.
+// ok is false when the matrix is singular.
+func (this Mat3) LU() (l, u Mat3, perm [3]int, ok bool) {
+	a := this.linalg()
+	p, ok := luDecompose(&a, mat3Dim, epsilon)
+	la, ua := splitLU(a, mat3Dim)
+	copy(perm[:], p[:])
+	return mat3FromLinalg(la), mat3FromLinalg(ua), perm, ok
+}
+
+// Decompose the matrix into A = Q*R, where Q is orthogonal and R is upper
+// triangular with a non-negative diagonal.
+func (this Mat3) QR() (q, r Mat3) {
+	qa, ra := householderQR(this.linalg(), mat3Dim)
+	return mat3FromLinalg(qa), mat3FromLinalg(ra)
+}
+
+// Decompose the symmetric positive definite matrix into A = L*L^T, where L
+// is lower triangular. Only the lower triangle of the matrix is read.
+// ok is false when the matrix is not positive definite.
+func (this Mat3) Cholesky() (l Mat3, ok bool) {
+	la, ok := cholesky(this.linalg(), mat3Dim)
+	return mat3FromLinalg(la), ok
+}
+
+// Return the eigenvalues and eigenvectors of the symmetric matrix.
+// The eigenvectors are the columns of vectors, sorted by decreasing eigenvalue.
+func (this Mat3) SymmetricEigen() (values Vec3, vectors Mat3) {
+	v, m := jacobiEigen(this.linalg(), mat3Dim)
+	return Vec3{v[0], v[1], v[2]}, mat3FromLinalg(m)
+}
+
+// Return the singular value decomposition A = U*diag(s)*V^T.
+// U and V are orthogonal and the singular values are sorted in decreasing
+// order. The signs are not fixed, so det(U) or det(V) may be -1.
+func (this Mat3) SVD() (u Mat3, s Vec3, v Mat3) {
+	ua, sa, va := svd3(this.linalg())
+	return mat3FromLinalg(ua), Vec3{sa[0], sa[1], sa[2]}, mat3FromLinalg(va)
+}
+
+// Solve the system A*x = b using LU decomposition with partial pivoting.
+// Returns ErrSingularMatrix when there is no unique solution.
+func (this Mat3) Solve(b Vec3) (Vec3, error) {
+	a := this.linalg()
+	perm, ok := luDecompose(&a, mat3Dim, epsilon)
+	if !ok {
+		return Vec3{}, ErrSingularMatrix
+	}
+	x := luSolve(&a, perm, mat3Dim, [4]float64{b.X, b.Y, b.Z})
+	return Vec3{x[0], x[1], x[2]}, nil
+}
+
+// Return the Moore-Penrose pseudo inverse of the matrix.
+// This is the inverse for invertible matrices and otherwise gives the least
+// squares solution with the smallest length when multiplied by a vector.
+func (this Mat3) PseudoInverse() Mat3 {
+	return mat3FromLinalg(pseudoInverse(this.linalg(), mat3Dim))
+}
+
+// Return the x minimizing the sum of (rows[i].Dot(x) - b[i])^2, the least
+// squares solution of the overdetermined system with one equation per row.
+// Returns ErrLengthMismatch when b isn't the same length as rows and
+// ErrSingularMatrix when the rows don't span all three dimensions.
+func LeastSquaresVec3(rows []Vec3, b []float64) (Vec3, error) {
+	if len(b) != len(rows) {
+		return Vec3{}, ErrLengthMismatch
+	}
+	// solve R*x = Q^T*b using the QR factorization of the rows
+	var r [16]float64
+	var qb [4]float64
+	for k, row := range rows {
+		givensAddRow(&r, &qb, mat3Dim, [4]float64{row.X, row.Y, row.Z}, b[k])
+	}
+	x, ok := givensSolve(&r, qb, mat3Dim)
+	if !ok {
+		return Vec3{}, ErrSingularMatrix
+	}
+	return Vec3{x[0], x[1], x[2]}, nil
+}
+
+// Decompose the matrix into P*A = L*U using partial pivoting, where L is unit
+// lower triangular and U is upper triangular. Row i of P*A is row perm[i] of A.
+// ok is false when the matrix is singular.
+func (this Mat4) LU() (l, u Mat4, perm [4]int, ok bool) {
+	a := this.mat
+	perm, ok = luDecompose(&a, mat4Dim, epsilon)
+	l.mat, u.mat = splitLU(a, mat4Dim)
+	return
+}
+
+// Decompose the matrix into A = Q*R, where Q is orthogonal and R is upper
+// triangular with a non-negative diagonal.
+func (this Mat4) QR() (q, r Mat4) {
+	q.mat, r.mat = householderQR(this.mat, mat4Dim)
+	return
+}
+
+// Decompose the symmetric positive definite matrix into A = L*L^T, where L
+// is lower triangular. Only the lower triangle of the matrix is read.
+// ok is false when the matrix is not positive definite.
+func (this Mat4) Cholesky() (l Mat4, ok bool) {
+	l.mat, ok = cholesky(this.mat, mat4Dim)
+	return
+}
+
+// Return the eigenvalues and eigenvectors of the symmetric matrix.
+// The eigenvectors are the columns of vectors, sorted by decreasing eigenvalue.
+func (this Mat4) SymmetricEigen() (values Vec4, vectors Mat4) {
+	v, m := jacobiEigen(this.mat, mat4Dim)
+	return Vec4{v[0], v[1], v[2], v[3]}, Mat4{m}
+}
+
+// OrthonormalizeMode picks the method used by Orthonormalize to pull a
+// matrix back onto the rotations.
+type OrthonormalizeMode int
+
+const (
+	// Keep the direction of the X column, make Y perpendicular to it and
+	// rebuild Z from their cross product. Cheap, but biased toward X.
+	OrthonormalizeGramSchmidt OrthonormalizeMode = iota
+	// Use the rotation from the polar decomposition, the closest rotation
+	// to the matrix. Treats all axes equally.
+	OrthonormalizePolar
+)
+
+// Decompose the matrix into A = R*S where R is a rotation and S is a
+// symmetric stretch. R is the closest rotation to A.
+// When A contains a reflection S has a negative eigenvalue so R stays a rotation.
+func (this Mat3) PolarDecompose() (rotation, stretch Mat3) {
+	u, _, v := this.SVD()
+	// flip the axis of the smallest singular value to remove any reflection
+	if u.Determinant()*v.Determinant() < 0 {
+		x, y, z := u.Col(2)
+		u.SetCol(2, -x, -y, -z)
+	}
+	rotation = u.Mult(v.Transpose())
+
+	// S = R^T*A, averaged with its transpose to remove the rounding error
+	stretch = rotation.Transpose().Mult(this)
+	stretch = stretch.Add(stretch.Transpose()).MultScalar(0.5)
+	return
+}
+
+// Return the rotation closest to the matrix using the given mode.
+// Use this to remove the drift from repeated multiplications of rotations.
+func (this Mat3) Orthonormalize(mode OrthonormalizeMode) Mat3 {
+	if mode == OrthonormalizePolar {
+		r, _ := this.PolarDecompose()
+		return r
+	}
+
+	x0, y0, z0 := this.Col(0)
+	x1, y1, z1 := this.Col(1)
+	c0 := Vec3{x0, y0, z0}
+	c1 := Vec3{x1, y1, z1}
+	if c0.Length() < epsilon {
+		c0 = Vec3{1, 0, 0}
+	}
+	c0 = c0.Normalize()
+	c1 = c1.Sub(c0.MultScalar(c0.Dot(c1)))
+	if c1.Length() < epsilon {
+		c1 = perpendicular(c0)
+	}
+	c1 = c1.Normalize()
+	c2 := c0.Cross(c1)
+
+	out := Mat3{}
+	out.SetCol(0, c0.X, c0.Y, c0.Z)
+	out.SetCol(1, c1.X, c1.Y, c1.Z)
+	out.SetCol(2, c2.X, c2.Y, c2.Z)
+	return out
+}
+
+// Replace the matrix with the rotation closest to it using the given mode.
+func (this *Mat3) OrthonormalizeIn(mode OrthonormalizeMode) *Mat3 {
+	*this = this.Orthonormalize(mode)
+	return this
+}
+
+// Polar decompose the upper 3x3 of the matrix, see Mat3.PolarDecompose.
+func (this Mat4) PolarDecompose() (rotation, stretch Mat3) {
+	return this.UpperMat3().PolarDecompose()
+}
+
+// Return a copy of the matrix with the upper 3x3 replaced by the closest
+// rotation, the translation and bottom row are kept.
+func (this Mat4) Orthonormalize(mode OrthonormalizeMode) Mat4 {
+	this.SetUpperMat3(this.UpperMat3().Orthonormalize(mode))
+	return this
+}
+
+// Replace the upper 3x3 of the matrix with the closest rotation.
+func (this *Mat4) OrthonormalizeIn(mode OrthonormalizeMode) *Mat4 {
+	this.SetUpperMat3(this.UpperMat3().Orthonormalize(mode))
+	return this
+}
+
+// Solve the system A*x = b using LU decomposition with partial pivoting.
+// Returns ErrSingularMatrix when there is no unique solution.
+func (this Mat4) Solve(b Vec4) (Vec4, error) {
+	a := this.mat
+	perm, ok := luDecompose(&a, mat4Dim, epsilon)
+	if !ok {
+		return Vec4{}, ErrSingularMatrix
+	}
+	x := luSolve(&a, perm, mat4Dim, [4]float64{b.X, b.Y, b.Z, b.W})
+	return Vec4{x[0], x[1], x[2], x[3]}, nil
+}
+
+// Return the Moore-Penrose pseudo inverse of the matrix.
+// This is the inverse for invertible matrices and otherwise gives the least
+// squares solution with the smallest length when multiplied by a vector.
+func (this Mat4) PseudoInverse() Mat4 {
+	return Mat4{pseudoInverse(this.mat, mat4Dim)}
+}
+
+// Return the x minimizing the sum of (rows[i].Dot(x) - b[i])^2, the least
+// squares solution of the overdetermined system with one equation per row.
+// Returns ErrLengthMismatch when b isn't the same length as rows and
+// ErrSingularMatrix when the rows don't span all four dimensions.
+func LeastSquaresVec4(rows []Vec4, b []float64) (Vec4, error) {
+	if len(b) != len(rows) {
+		return Vec4{}, ErrLengthMismatch
+	}
+	// solve R*x = Q^T*b using the QR factorization of the rows
+	var r [16]float64
+	var qb [4]float64
+	for k, row := range rows {
+		givensAddRow(&r, &qb, mat4Dim, [4]float64{row.X, row.Y, row.Z, row.W}, b[k])
+	}
+	x, ok := givensSolve(&r, qb, mat4Dim)
+	if !ok {
+		return Vec4{}, ErrSingularMatrix
+	}
+	return Vec4{x[0], x[1], x[2], x[3]}, nil
+}
@@ -0,0 +1,592 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+var linalgCases3 = []Mat3{
+	Mat3Identity,
+	{[9]float64{2, 1, 1, 4, -6, 0, -2, 7, 2}},
+	{[9]float64{0, 1, 2, 3, 0, 5, 6, 7, 0}},
+	{[9]float64{1, 2, 3, 4, 5, 6, 7, 8, 10}},
+	{[9]float64{1, 2, 3, 2, 4, 6, 3, 6, 9}},
+	{[9]float64{0, 0, 0, 0, 0, 0, 0, 0, 0}},
+}
+
+var linalgCases4 = []Mat4{
+	Mat4Identity,
+	{[16]float64{2, 1, 1, 0, 4, -6, 0, 1, -2, 7, 2, 3, 1, 1, 1, 1}},
+	{[16]float64{0, 1, 2, 3, 4, 0, 6, 7, 8, 9, 0, 11, 12, 13, 14, 0}},
+	{[16]float64{1, 2, 3, 4, 2, 4, 6, 8, 0, 1, 0, 1, 5, 0, 5, 0}},
+}
+
+func TestLUMat3(t *testing.T) {
+	for testIndex, a := range linalgCases3 {
+		l, u, perm, ok := a.LU()
+		pa := Mat3{}
+		for i, p := range perm {
+			x, y, z := a.Row(p)
+			pa.SetRow(i, x, y, z)
+		}
+		if !l.Mult(u).ApproxEq(pa, AbsTolerance(1e-9)) {
+			t.Errorf("TestLUMat3 %d\n%v\n%v", testIndex, l.Mult(u), pa)
+		}
+		for i := 0; i < 3; i++ {
+			if l.Get(i, i) != 1 {
+				t.Errorf("TestLUMat3 %d diagonal %v", testIndex, l)
+			}
+			for j := i + 1; j < 3; j++ {
+				if l.Get(i, j) != 0 || u.Get(j, i) != 0 {
+					t.Errorf("TestLUMat3 %d triangular\n%v\n%v", testIndex, l, u)
+				}
+			}
+		}
+		if singular := closeEq(a.Determinant(), 0, 1e-9); ok == singular {
+			t.Errorf("TestLUMat3 %d ok %v", testIndex, ok)
+		}
+	}
+}
+
+func TestLUMat4(t *testing.T) {
+	for testIndex, a := range linalgCases4 {
+		l, u, perm, ok := a.LU()
+		pa := Mat4{}
+		for i, p := range perm {
+			x, y, z, w := a.Row(p)
+			pa.SetRow(i, x, y, z, w)
+		}
+		if !l.Mult(u).ApproxEq(pa, AbsTolerance(1e-9)) {
+			t.Errorf("TestLUMat4 %d\n%v\n%v", testIndex, l.Mult(u), pa)
+		}
+		if singular := closeEq(a.Determinant(), 0, 1e-9); ok == singular {
+			t.Errorf("TestLUMat4 %d ok %v", testIndex, ok)
+		}
+	}
+}
+
+func TestLUScaledMat3(t *testing.T) {
+	// uniformly scaling the matrix must not change whether it is singular
+	for testIndex, a := range linalgCases3 {
+		_, _, _, want := a.LU()
+		for _, scale := range []float64{1e-10, 1e10} {
+			b := a.MultScalar(scale)
+			if _, _, _, ok := b.LU(); ok != want {
+				t.Errorf("TestLUScaledMat3 %d %v ok %v", testIndex, scale, ok)
+			}
+			x, err := b.Solve(b.MultVec3(Vec3{1, -2, 3}))
+			if want && (err != nil || x.Sub(Vec3{1, -2, 3}).Length() > 1e-9) {
+				t.Errorf("TestLUScaledMat3 %d %v %v %v", testIndex, scale, x, err)
+			}
+		}
+	}
+}
+
+func TestLUScaledMat4(t *testing.T) {
+	for testIndex, a := range linalgCases4 {
+		_, _, _, want := a.LU()
+		for _, scale := range []float64{1e-10, 1e10} {
+			b := a.MultScalar(scale)
+			if _, _, _, ok := b.LU(); ok != want {
+				t.Errorf("TestLUScaledMat4 %d %v ok %v", testIndex, scale, ok)
+			}
+			x, err := b.Solve(b.MultVec4(Vec4{1, -2, 3, 0.5}))
+			if want && (err != nil || x.Sub(Vec4{1, -2, 3, 0.5}).Length() > 1e-9) {
+				t.Errorf("TestLUScaledMat4 %d %v %v %v", testIndex, scale, x, err)
+			}
+		}
+	}
+}
+
+func TestLUNearSingularMat3(t *testing.T) {
+	// the last row is perturbed off the span of the first two
+	cases := []struct {
+		delta float64
+		ok    bool
+	}{
+		{0, false},
+		{1e-14, false},
+		{1e-12, false},
+		{1e-6, true},
+		{1e-3, true},
+	}
+
+	for testIndex, c := range cases {
+		for _, scale := range []float64{1, 1e-10, 1e10} {
+			a := Mat3{[9]float64{1, 2, 3, 4, 5, 6, 7, 8, 9 + c.delta}}.MultScalar(scale)
+			if _, _, _, ok := a.LU(); ok != c.ok {
+				t.Errorf("TestLUNearSingularMat3 %d %v ok %v", testIndex, scale, ok)
+			}
+		}
+	}
+}
+
+func TestQRMat3(t *testing.T) {
+	for testIndex, a := range linalgCases3 {
+		q, r := a.QR()
+		if !q.Mult(r).ApproxEq(a, AbsTolerance(1e-9)) {
+			t.Errorf("TestQRMat3 %d\n%v\n%v", testIndex, q.Mult(r), a)
+		}
+		if !q.Transpose().Mult(q).ApproxEq(Mat3Identity, AbsTolerance(1e-9)) {
+			t.Errorf("TestQRMat3 %d not orthogonal %v", testIndex, q)
+		}
+		for i := 0; i < 3; i++ {
+			if r.Get(i, i) < 0 {
+				t.Errorf("TestQRMat3 %d negative diagonal %v", testIndex, r)
+			}
+			for j := 0; j < i; j++ {
+				if r.Get(i, j) != 0 {
+					t.Errorf("TestQRMat3 %d not triangular %v", testIndex, r)
+				}
+			}
+		}
+	}
+}
+
+func TestQRScaledMat3(t *testing.T) {
+	for testIndex, a := range linalgCases3 {
+		q, r := a.QR()
+		_, _, _, fullRank := a.LU()
+		for _, scale := range []float64{1e-12, 1e12} {
+			qs, rs := a.MultScalar(scale).QR()
+			if !qs.Mult(rs).MultScalar(1/scale).ApproxEq(a, AbsTolerance(1e-9)) {
+				t.Errorf("TestQRScaledMat3 %d %v\n%v\n%v", testIndex, scale, qs, rs)
+			}
+			if rs.Get(1, 0) != 0 || rs.Get(2, 0) != 0 || rs.Get(2, 1) != 0 {
+				t.Errorf("TestQRScaledMat3 %d %v not triangular %v", testIndex, scale, rs)
+			}
+			// the decomposition is unique for a full rank matrix, so uniform
+			// scaling keeps Q and scales R
+			if fullRank && (!qs.ApproxEq(q, AbsTolerance(1e-9)) || !rs.MultScalar(1/scale).ApproxEq(r, AbsTolerance(1e-9))) {
+				t.Errorf("TestQRScaledMat3 %d %v unique\n%v\n%v", testIndex, scale, qs, rs)
+			}
+		}
+	}
+}
+
+func TestQRMat4(t *testing.T) {
+	for testIndex, a := range linalgCases4 {
+		q, r := a.QR()
+		if !q.Mult(r).ApproxEq(a, AbsTolerance(1e-9)) {
+			t.Errorf("TestQRMat4 %d\n%v\n%v", testIndex, q.Mult(r), a)
+		}
+		if !q.Transpose().Mult(q).ApproxEq(Mat4Identity, AbsTolerance(1e-9)) {
+			t.Errorf("TestQRMat4 %d not orthogonal %v", testIndex, q)
+		}
+	}
+}
+
+func TestCholeskyMat3(t *testing.T) {
+	cases := []struct {
+		a    Mat3
+		want bool
+	}{
+		{Mat3Identity, true},
+		{Mat3{[9]float64{4, 12, -16, 12, 37, -43, -16, -43, 98}}, true},
+		{Mat3{[9]float64{2, -1, 0, -1, 2, -1, 0, -1, 2}}, true},
+		{Mat3{[9]float64{1, 2, 0, 2, 1, 0, 0, 0, 1}}, false},
+		{Mat3{[9]float64{1, 0, 0, 0, 0, 0, 0, 0, 1}}, false},
+	}
+
+	for testIndex, c := range cases {
+		l, ok := c.a.Cholesky()
+		if ok != c.want {
+			t.Errorf("TestCholeskyMat3 %d ok %v", testIndex, ok)
+			continue
+		}
+		if ok && !l.Mult(l.Transpose()).ApproxEq(c.a, AbsTolerance(1e-9)) {
+			t.Errorf("TestCholeskyMat3 %d\n%v\n%v", testIndex, l.Mult(l.Transpose()), c.a)
+		}
+	}
+}
+
+func TestCholeskyMat4(t *testing.T) {
+	b := linalgCases4[1]
+	cases := []struct {
+		a    Mat4
+		want bool
+	}{
+		{Mat4Identity, true},
+		{b.Transpose().Mult(b), true},
+		{Mat4Identity.MultScalar(-1), false},
+	}
+
+	for testIndex, c := range cases {
+		l, ok := c.a.Cholesky()
+		if ok != c.want {
+			t.Errorf("TestCholeskyMat4 %d ok %v", testIndex, ok)
+			continue
+		}
+		if ok && !l.Mult(l.Transpose()).ApproxEq(c.a, AbsTolerance(1e-9)) {
+			t.Errorf("TestCholeskyMat4 %d\n%v\n%v", testIndex, l.Mult(l.Transpose()), c.a)
+		}
+	}
+}
+
+func TestSymmetricEigenMat3(t *testing.T) {
+	cases := []struct {
+		a    Mat3
+		want Vec3
+	}{
+		{Mat3Identity, Vec3{1, 1, 1}},
+		{Mat3{[9]float64{1, 0, 0, 0, 3, 0, 0, 0, 2}}, Vec3{3, 2, 1}},
+		{Mat3{[9]float64{2, -1, 0, -1, 2, -1, 0, -1, 2}}, Vec3{2 + math.Sqrt2, 2, 2 - math.Sqrt2}},
+		{Mat3{[9]float64{1, 2, 3, 2, 4, 6, 3, 6, 9}}, Vec3{14, 0, 0}},
+	}
+
+	for testIndex, c := range cases {
+		values, vectors := c.a.SymmetricEigen()
+		if values.Sub(c.want).Length() > 1e-9 {
+			t.Errorf("TestSymmetricEigenMat3 %d values %v %v", testIndex, values, c.want)
+		}
+		if !vectors.Transpose().Mult(vectors).ApproxEq(Mat3Identity, AbsTolerance(1e-9)) {
+			t.Errorf("TestSymmetricEigenMat3 %d not orthogonal %v", testIndex, vectors)
+		}
+		for i, value := range []float64{values.X, values.Y, values.Z} {
+			x, y, z := vectors.Col(i)
+			v := Vec3{x, y, z}
+			if c.a.MultVec3(v).Sub(v.MultScalar(value)).Length() > 1e-9 {
+				t.Errorf("TestSymmetricEigenMat3 %d vector %d %v", testIndex, i, v)
+			}
+		}
+	}
+}
+
+func TestSymmetricEigenMat4(t *testing.T) {
+	b := linalgCases4[2]
+	cases := []Mat4{
+		Mat4Identity,
+		b.Add(b.Transpose()),
+		b.Transpose().Mult(b),
+	}
+
+	for testIndex, a := range cases {
+		values, vectors := a.SymmetricEigen()
+		if values.X < values.Y || values.Y < values.Z || values.Z < values.W {
+			t.Errorf("TestSymmetricEigenMat4 %d not sorted %v", testIndex, values)
+		}
+		for i, value := range []float64{values.X, values.Y, values.Z, values.W} {
+			x, y, z, w := vectors.Col(i)
+			v := Vec4{x, y, z, w}
+			if a.MultVec4(v).Sub(v.MultScalar(value)).Length() > 1e-9 {
+				t.Errorf("TestSymmetricEigenMat4 %d vector %d %v", testIndex, i, v)
+			}
+		}
+	}
+}
+
+func TestSVDMat3(t *testing.T) {
+	for testIndex, a := range linalgCases3 {
+		u, s, v := a.SVD()
+		us := u.Mult(Mat3{[9]float64{s.X, 0, 0, 0, s.Y, 0, 0, 0, s.Z}})
+		if !us.Mult(v.Transpose()).ApproxEq(a, AbsTolerance(1e-9)) {
+			t.Errorf("TestSVDMat3 %d\n%v\n%v", testIndex, us.Mult(v.Transpose()), a)
+		}
+		if !u.Transpose().Mult(u).ApproxEq(Mat3Identity, AbsTolerance(1e-9)) {
+			t.Errorf("TestSVDMat3 %d U not orthogonal %v", testIndex, u)
+		}
+		if !v.Transpose().Mult(v).ApproxEq(Mat3Identity, AbsTolerance(1e-9)) {
+			t.Errorf("TestSVDMat3 %d V not orthogonal %v", testIndex, v)
+		}
+		if s.X < s.Y || s.Y < s.Z || s.Z < 0 {
+			t.Errorf("TestSVDMat3 %d singular values %v", testIndex, s)
+		}
+	}
+}
+
+func TestSVDScaledMat3(t *testing.T) {
+	for testIndex, a := range linalgCases3 {
+		_, want, _ := a.SVD()
+		for _, scale := range []float64{1e-12, 1e12} {
+			b := a.MultScalar(scale)
+			u, s, v := b.SVD()
+			us := u.Mult(Mat3{[9]float64{s.X, 0, 0, 0, s.Y, 0, 0, 0, s.Z}})
+			if !us.Mult(v.Transpose()).MultScalar(1/scale).ApproxEq(a, AbsTolerance(1e-9)) {
+				t.Errorf("TestSVDScaledMat3 %d %v\n%v", testIndex, scale, us.Mult(v.Transpose()))
+			}
+			if !u.Transpose().Mult(u).ApproxEq(Mat3Identity, AbsTolerance(1e-9)) {
+				t.Errorf("TestSVDScaledMat3 %d %v U not orthogonal %v", testIndex, scale, u)
+			}
+			if !s.MultScalar(1/scale).ApproxEq(want, AbsTolerance(1e-9)) {
+				t.Errorf("TestSVDScaledMat3 %d %v singular values %v %v", testIndex, scale, s, want)
+			}
+		}
+	}
+}
+
+func TestPolarDecomposeMat3(t *testing.T) {
+	r := Mat3{}
+	r.FromAxisAngle(0.7, 0, 0.6, 0.8)
+	cases := []Mat3{
+		Mat3Identity,
+		r,
+		r.Mult(Mat3{[9]float64{2, 0, 0, 0, 3, 0, 0, 0, 4}}),
+		r.Mult(Mat3{[9]float64{2, 1, 0, 1, 3, 0, 0, 0, 1}}),
+		r.Mult(Mat3{[9]float64{-1, 0, 0, 0, 1, 0, 0, 0, 1}}),
+		{[9]float64{1, 2, 3, 2, 4, 6, 3, 6, 9}},
+	}
+
+	for testIndex, a := range cases {
+		rotation, stretch := a.PolarDecompose()
+		if !closeEq(rotation.Determinant(), 1, 1e-9) || !rotation.Mult(rotation.Transpose()).ApproxEq(Mat3Identity, AbsTolerance(1e-9)) {
+			t.Errorf("TestPolarDecomposeMat3 %d not a rotation\n%v", testIndex, rotation)
+		}
+		if !stretch.ApproxEq(stretch.Transpose(), AbsTolerance(1e-9)) {
+			t.Errorf("TestPolarDecomposeMat3 %d not symmetric\n%v", testIndex, stretch)
+		}
+		if !rotation.Mult(stretch).ApproxEq(a, AbsTolerance(1e-9)) {
+			t.Errorf("TestPolarDecomposeMat3 %d\n%v\n%v", testIndex, rotation.Mult(stretch), a)
+		}
+	}
+	if rotation, _ := cases[3].PolarDecompose(); !rotation.ApproxEq(r, AbsTolerance(1e-9)) {
+		t.Errorf("TestPolarDecomposeMat3 rotation\n%v\n%v", rotation, r)
+	}
+}
+
+func TestOrthonormalizeMat3(t *testing.T) {
+	step := Mat3{}
+	step.FromAxisAngle(0.01, 0.48, 0.6, 0.64)
+	// drift off the rotations by accumulating rounded steps
+	drifted := Mat3Identity
+	for i := 0; i < 1000; i++ {
+		drifted.MultIn(step)
+		drifted.MultInScalar(1 + 1e-6)
+	}
+	skewed := Mat3{[9]float64{1, 0.1, 0, 0, 1, 0.2, 0.05, 0, 0.9}}
+
+	for testIndex, a := range []Mat3{Mat3Identity, step, drifted, skewed} {
+		for _, mode := range []OrthonormalizeMode{OrthonormalizeGramSchmidt, OrthonormalizePolar} {
+			r := a.Orthonormalize(mode)
+			if !closeEq(r.Determinant(), 1, 1e-9) || !r.Mult(r.Transpose()).ApproxEq(Mat3Identity, AbsTolerance(1e-9)) {
+				t.Errorf("TestOrthonormalizeMat3 %d mode %d not a rotation\n%v", testIndex, mode, r)
+			}
+			if !r.ApproxEq(a, AbsTolerance(0.3)) {
+				t.Errorf("TestOrthonormalizeMat3 %d mode %d too far\n%v\n%v", testIndex, mode, r, a)
+			}
+		}
+
+		// Gram-Schmidt keeps the direction of the X column
+		r := a.Orthonormalize(OrthonormalizeGramSchmidt)
+		x0, y0, z0 := a.Col(0)
+		x1, y1, z1 := r.Col(0)
+		if (Vec3{x0, y0, z0}).Normalize().Sub(Vec3{x1, y1, z1}).Length() > 1e-9 {
+			t.Errorf("TestOrthonormalizeMat3 %d X column moved", testIndex)
+		}
+	}
+
+	m := step
+	m.OrthonormalizeIn(OrthonormalizePolar)
+	if !m.ApproxEq(step, AbsTolerance(1e-9)) {
+		t.Errorf("TestOrthonormalizeMat3 rotation changed\n%v\n%v", m, step)
+	}
+}
+
+func TestOrthonormalizeMat4(t *testing.T) {
+	m := Mat4{}
+	m.FromTRS(Vec3{1, 2, 3}, QuatIdentity, Vec3{1, 1, 1})
+	m.SetUpperMat3(Mat3{[9]float64{1, 0.1, 0, 0, 1, 0.2, 0.05, 0, 0.9}})
+
+	for _, mode := range []OrthonormalizeMode{OrthonormalizeGramSchmidt, OrthonormalizePolar} {
+		r := m.Orthonormalize(mode)
+		u := r.UpperMat3()
+		if !closeEq(u.Determinant(), 1, 1e-9) || !u.Mult(u.Transpose()).ApproxEq(Mat3Identity, AbsTolerance(1e-9)) {
+			t.Errorf("TestOrthonormalizeMat4 mode %d not a rotation\n%v", mode, &r)
+		}
+		for _, k := range []int{3, 7, 11, 12, 13, 14, 15} {
+			if r.At(k) != m.At(k) {
+				t.Errorf("TestOrthonormalizeMat4 mode %d changed %d", mode, k)
+			}
+		}
+		in := m
+		in.OrthonormalizeIn(mode)
+		if !in.ApproxEq(r, AbsTolerance(1e-12)) {
+			t.Errorf("TestOrthonormalizeMat4 mode %d In differs", mode)
+		}
+	}
+
+	rotation, stretch := m.PolarDecompose()
+	if !rotation.Mult(stretch).ApproxEq(m.UpperMat3(), AbsTolerance(1e-9)) {
+		t.Errorf("TestOrthonormalizeMat4 polar\n%v\n%v", rotation, stretch)
+	}
+}
+
+func TestSolveMat3(t *testing.T) {
+	for testIndex, a := range linalgCases3 {
+		want := Vec3{1, -2, 3}
+		x, err := a.Solve(a.MultVec3(want))
+		if singular := closeEq(a.Determinant(), 0, 1e-9); singular {
+			if err != ErrSingularMatrix {
+				t.Errorf("TestSolveMat3 %d err %v", testIndex, err)
+			}
+			continue
+		}
+		if err != nil || x.Sub(want).Length() > 1e-9 {
+			t.Errorf("TestSolveMat3 %d %v %v %v", testIndex, x, want, err)
+		}
+	}
+}
+
+func TestSolveMat4(t *testing.T) {
+	for testIndex, a := range linalgCases4 {
+		want := Vec4{1, -2, 3, 0.5}
+		x, err := a.Solve(a.MultVec4(want))
+		if singular := closeEq(a.Determinant(), 0, 1e-9); singular {
+			if err != ErrSingularMatrix {
+				t.Errorf("TestSolveMat4 %d err %v", testIndex, err)
+			}
+			continue
+		}
+		if err != nil || x.Sub(want).Length() > 1e-9 {
+			t.Errorf("TestSolveMat4 %d %v %v %v", testIndex, x, want, err)
+		}
+	}
+}
+
+func TestPseudoInverseMat3(t *testing.T) {
+	for testIndex, a := range linalgCases3 {
+		p := a.PseudoInverse()
+		// the four Moore-Penrose conditions
+		if !a.Mult(p).Mult(a).ApproxEq(a, AbsTolerance(1e-9)) || !p.Mult(a).Mult(p).ApproxEq(p, AbsTolerance(1e-9)) {
+			t.Errorf("TestPseudoInverseMat3 %d\n%v", testIndex, p)
+		}
+		if ap, pa := a.Mult(p), p.Mult(a); !ap.ApproxEq(ap.Transpose(), AbsTolerance(1e-9)) || !pa.ApproxEq(pa.Transpose(), AbsTolerance(1e-9)) {
+			t.Errorf("TestPseudoInverseMat3 %d not symmetric\n%v", testIndex, p)
+		}
+		if a.HasInverse() && !p.ApproxEq(a.Inverse(), AbsTolerance(1e-9)) {
+			t.Errorf("TestPseudoInverseMat3 %d inverse\n%v\n%v", testIndex, p, a.Inverse())
+		}
+	}
+}
+
+func TestPseudoInverseScaled(t *testing.T) {
+	// a uniformly tiny matrix is still invertible
+	a := Mat3Identity.MultScalar(1e-10)
+	if p := a.PseudoInverse(); !p.MultScalar(1e-10).ApproxEq(Mat3Identity, AbsTolerance(1e-9)) {
+		t.Errorf("TestPseudoInverseScaled identity\n%v", p)
+	}
+	b := Mat3{[9]float64{2, 1, 1, 4, -6, 0, -2, 7, 2}}.MultScalar(1e-12)
+	if p := b.PseudoInverse(); !p.Mult(b).ApproxEq(Mat3Identity, AbsTolerance(1e-9)) {
+		t.Errorf("TestPseudoInverseScaled\n%v", p)
+	}
+	c := Mat4Identity.MultScalar(1e-10)
+	if p := c.PseudoInverse(); !p.MultScalar(1e-10).ApproxEq(Mat4Identity, AbsTolerance(1e-9)) {
+		t.Errorf("TestPseudoInverseScaled Mat4\n%v", p)
+	}
+}
+
+func TestPseudoInverseMat4(t *testing.T) {
+	for testIndex, a := range linalgCases4 {
+		p := a.PseudoInverse()
+		if !a.Mult(p).Mult(a).ApproxEq(a, AbsTolerance(1e-9)) || !p.Mult(a).Mult(p).ApproxEq(p, AbsTolerance(1e-9)) {
+			t.Errorf("TestPseudoInverseMat4 %d\n%v", testIndex, &p)
+		}
+		if a.HasInverse() && !p.ApproxEq(a.Inverse(), AbsTolerance(1e-9)) {
+			t.Errorf("TestPseudoInverseMat4 %d inverse\n%v", testIndex, &p)
+		}
+	}
+}
+
+func TestLeastSquaresVec3(t *testing.T) {
+	// fit the plane z = 2x - y + 3 through noisy points
+	rows := []Vec3{}
+	b := []float64{}
+	noise := []float64{0.01, -0.01, 0.02, -0.02, 0, 0.01}
+	for k, p := range []Vec2{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 1}, {-1, 3}} {
+		rows = append(rows, Vec3{p.X, p.Y, 1})
+		b = append(b, 2*p.X-p.Y+3+noise[k])
+	}
+	x, err := LeastSquaresVec3(rows, b)
+	if err != nil || x.Sub(Vec3{2, -1, 3}).Length() > 0.05 {
+		t.Errorf("TestLeastSquaresVec3 %v %v", x, err)
+	}
+
+	// the solution is exact without noise
+	x, err = LeastSquaresVec3(rows[:3], []float64{3, 5, 2})
+	if err != nil || x.Sub(Vec3{2, -1, 3}).Length() > 1e-9 {
+		t.Errorf("TestLeastSquaresVec3 exact %v %v", x, err)
+	}
+
+	// small rows are not mistaken for singular ones
+	small := []Vec3{}
+	for _, r := range rows[:3] {
+		small = append(small, r.MultScalar(1e-4))
+	}
+	x, err = LeastSquaresVec3(small, []float64{3e-4, 5e-4, 2e-4})
+	if err != nil || x.Sub(Vec3{2, -1, 3}).Length() > 1e-6 {
+		t.Errorf("TestLeastSquaresVec3 small %v %v", x, err)
+	}
+
+	// the rows only span a plane
+	_, err = LeastSquaresVec3([]Vec3{{1, 0, 0}, {0, 1, 0}, {1, 1, 0}}, []float64{1, 2, 3})
+	if err != ErrSingularMatrix {
+		t.Errorf("TestLeastSquaresVec3 singular %v", err)
+	}
+	_, err = LeastSquaresVec3([]Vec3{{1, 0, 0}, {0, 1, 0}, {1, 1, 1e-12}}, []float64{1, 2, 3})
+	if err != ErrSingularMatrix {
+		t.Errorf("TestLeastSquaresVec3 near singular %v", err)
+	}
+
+	// well conditioned rows with a small column, the normal equations would
+	// square the condition number and lose the last column
+	want := Vec3{1, -2, 3}
+	rows = []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1e-5}, {1, 1, 1e-5}}
+	b = b[:0]
+	for _, r := range rows {
+		b = append(b, r.Dot(want))
+	}
+	x, err = LeastSquaresVec3(rows, b)
+	if err != nil || x.Sub(want).Length() > 1e-6 {
+		t.Errorf("TestLeastSquaresVec3 ill scaled %v %v", x, err)
+	}
+
+	// one right hand side value is needed for each row
+	if _, err = LeastSquaresVec3(rows, b[:3]); err != ErrLengthMismatch {
+		t.Errorf("TestLeastSquaresVec3 short b %v", err)
+	}
+	if _, err = LeastSquaresVec3(rows, append(b, 1)); err != ErrLengthMismatch {
+		t.Errorf("TestLeastSquaresVec3 long b %v", err)
+	}
+	if _, err = LeastSquaresVec3(nil, nil); err != ErrSingularMatrix {
+		t.Errorf("TestLeastSquaresVec3 empty %v", err)
+	}
+}
+
+func TestLeastSquaresVec4(t *testing.T) {
+	want := Vec4{1, 2, -1, 0.5}
+	rows := []Vec4{{1, 0, 0, 1}, {0, 1, 0, 1}, {0, 0, 1, 1}, {1, 1, 1, 1}, {2, -1, 0, 1}}
+	b := []float64{}
+	for _, r := range rows {
+		b = append(b, r.Dot(want))
+	}
+	x, err := LeastSquaresVec4(rows, b)
+	if err != nil || x.Sub(want).Length() > 1e-9 {
+		t.Errorf("TestLeastSquaresVec4 %v %v", x, err)
+	}
+
+	small := []Vec4{}
+	for _, r := range rows {
+		small = append(small, r.MultScalar(1e-4))
+	}
+	x, err = LeastSquaresVec4(small, []float64{1e-4 * b[0], 1e-4 * b[1], 1e-4 * b[2], 1e-4 * b[3], 1e-4 * b[4]})
+	if err != nil || x.Sub(want).Length() > 1e-6 {
+		t.Errorf("TestLeastSquaresVec4 small %v %v", x, err)
+	}
+
+	_, err = LeastSquaresVec4(rows[:3], b[:3])
+	if err != ErrSingularMatrix {
+		t.Errorf("TestLeastSquaresVec4 singular %v", err)
+	}
+	if _, err = LeastSquaresVec4(rows, b[:4]); err != ErrLengthMismatch {
+		t.Errorf("TestLeastSquaresVec4 short b %v", err)
+	}
+
+	ill := []Vec4{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1e-5}, {1, 1, 1, 1e-5}}
+	b = b[:0]
+	for _, r := range ill {
+		b = append(b, r.Dot(want))
+	}
+	x, err = LeastSquaresVec4(ill, b)
+	if err != nil || x.Sub(want).Length() > 1e-6 {
+		t.Errorf("TestLeastSquaresVec4 ill scaled %v %v", x, err)
+	}
+}
@@ -8,8 +8,25 @@ import (
 
 const (
 	epsilon = 0.000000001
+
+	// Quaternions closer than this are treated as parallel by Slerp
+	slerpEpsilon = 0.000001
+
+	// Angles smaller than this are evaluated with a Taylor series
+	smallAngle = 0.0001
+
+	// Matrices with more shear or projection than this can't be decomposed
+	decomposeEpsilon = 0.000001
+
+	// Equality epsilon for the float32 types
+	epsilon32 = 0.00001
 )
 
+// Return true if the float is neither NaN nor infinite.
+func isFinite(a float64) bool {
+	return !math.IsNaN(a) && !math.IsInf(a, 0)
+}
+
 // Checks if two floats are equal. Doing a comparision using a small epsilon value
 func closeEq(a, b, eps float64) bool {
 	if a > b {
@@ -19,6 +36,30 @@ func closeEq(a, b, eps float64) bool {
 	}
 }
 
+// Checks if two float32s are equal. Doing a comparision using a small epsilon value
+func closeEq32(a, b, eps float32) bool {
+	if a > b {
+		return ((a - b) < eps)
+	} else {
+		return ((b - a) < eps)
+	}
+}
+
+// Square root of a float32
+func sqrt32(a float32) float32 {
+	return float32(math.Sqrt(float64(a)))
+}
+
+// Sine of a float32 angle (radians)
+func sin32(a float32) float32 {
+	return float32(math.Sin(float64(a)))
+}
+
+// Cosine of a float32 angle (radians)
+func cos32(a float32) float32 {
+	return float32(math.Cos(float64(a)))
+}
+
 // Calculate the determinant of a 2x2 matrix.
 // Values are givein in Row-Major order
 func det2x2(x, y, z, w float64) float64 {
@@ -36,6 +77,31 @@ func det3x3(a1, a2, a3, b1, b2, b3, c1, c2, c3 float64) float64 {
 		c1*det2x2(a2, a3, b2, b3))
 }
 
+// Calculate the determinant of a float32 2x2 matrix.
+// Values are given in Row-Major order
+func det2x2f(x, y, z, w float32) float32 {
+	return x*w - y*z
+}
+
+// Calculate the determinant of a float32 3x3 matrix.
+// Values are given in Row-Major order
+func det3x3f(a1, a2, a3, b1, b2, b3, c1, c2, c3 float32) float32 {
+	return (a1*det2x2f(b2, b3, c2, c3) -
+		b1*det2x2f(a2, a3, c2, c3) +
+		c1*det2x2f(a2, a3, b2, b3))
+}
+
+// Return the largest scale factor applied by the upper 3x3 of the matrix.
+// This is the length of the longest column.
+func maxScale(m Mat4) float64 {
+	var out float64
+	for col := 0; col < 3; col++ {
+		x, y, z, _ := m.Col(col)
+		out = math.Max(out, x*x+y*y+z*z)
+	}
+	return math.Sqrt(out)
+}
+
 // Convert from a degree to a radian
 func Radians(a float64) float64 {
 	return a * math.Pi / 180.0
@@ -0,0 +1,102 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+// The types which can be checked for NaN and infinite elements.
+type finiteChecker interface {
+	IsFinite() bool
+	HasNaN() bool
+}
+
+func TestIsFinite(t *testing.T) {
+	inf, nan := math.Inf(1), math.NaN()
+	inf32, nan32 := float32(math.Inf(-1)), float32(math.NaN())
+	unit := Vec3{1, 0, 0}
+
+	cases := []struct {
+		v              finiteChecker
+		finite, hasNaN bool
+	}{
+		{Vec2{3, 4}, true, false},
+		{Vec2{nan, 1}, false, true},
+		{Vec2{1, inf}, false, false},
+		{Vec2f{3, 4}, true, false},
+		{Vec2f{nan32, 1}, false, true},
+		{Vec2f{1, inf32}, false, false},
+		{Vec3{3, 4, 0}, true, false},
+		{Vec3{nan, 1, 1}, false, true},
+		{Vec3{1, inf, 1}, false, false},
+		{Vec3f{3, 4, 0}, true, false},
+		{Vec3f{nan32, 1, 1}, false, true},
+		{Vec3f{1, inf32, 1}, false, false},
+		{Vec4{3, 4, 0, 0}, true, false},
+		{Vec4{nan, 1, 1, 1}, false, true},
+		{Vec4{1, inf, 1, 1}, false, false},
+		{Vec4f{3, 4, 0, 0}, true, false},
+		{Vec4f{nan32, 1, 1, 1}, false, true},
+		{Vec4f{1, inf32, 1, 1}, false, false},
+
+		{Mat2{[4]float64{1, 0, 0, 1}}, true, false},
+		{Mat2{[4]float64{1, 0, 0, -inf}}, false, false},
+		{Mat2{[4]float64{1, nan, 0, 1}}, false, true},
+		{Mat3Identity, true, false},
+		{Mat3{[9]float64{1, 0, 0, 0, 1, 0, 0, 0, -inf}}, false, false},
+		{Mat3{[9]float64{1, nan, 0, 0, 1, 0, 0, 0, 1}}, false, true},
+		{Mat3fIdentity, true, false},
+		{Mat3f{[9]float32{1, 0, 0, 0, 1, 0, 0, 0, inf32}}, false, false},
+		{Mat3f{[9]float32{1, nan32, 0, 0, 1, 0, 0, 0, 1}}, false, true},
+		{Mat4Identity, true, false},
+		{Mat4{[16]float64{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, -inf}}, false, false},
+		{Mat4{[16]float64{1, nan, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}}, false, true},
+		{Mat4fIdentity, true, false},
+		{Mat4f{[16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, inf32}}, false, false},
+		{Mat4f{[16]float32{1, nan32, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}}, false, true},
+
+		{Quat{1, 0, 0, 0}, true, false},
+		{Quat{1, 0, inf, 0}, false, false},
+		{Quat{1, 0, 0, nan}, false, true},
+		{Quatf{1, 0, 0, 0}, true, false},
+		{Quatf{1, 0, inf32, 0}, false, false},
+		{Quatf{1, 0, 0, nan32}, false, true},
+		{DualQuatIdentity, true, false},
+		{DualQuat{QuatIdentity, Quat{0, inf, 0, 0}}, false, false},
+		{DualQuat{Quat{1, 0, 0, nan}, Quat{}}, false, true},
+
+		{Ray{Vec3{}, unit}, true, false},
+		{Ray{Vec3{}, Vec3{inf, 0, 0}}, false, false},
+		{Ray{Vec3{0, nan, 0}, unit}, false, true},
+		{AABB{Vec3{-1, -1, -1}, Vec3{1, 1, 1}}, true, false},
+		{AABB{Vec3{-1, -1, -1}, Vec3{1, 1, inf}}, false, false},
+		{AABB{Vec3{nan, -1, -1}, Vec3{1, 1, 1}}, false, true},
+		{Sphere{unit, 2}, true, false},
+		{Sphere{unit, inf}, false, false},
+		{Sphere{unit, nan}, false, true},
+		{Plane{unit, 2}, true, false},
+		{Plane{unit, -inf}, false, false},
+		{Plane{Vec3{nan, 0, 0}, 2}, false, true},
+		{Frustum{}, true, false},
+		{Frustum{[6]Plane{5: {unit, inf}}}, false, false},
+		{Frustum{[6]Plane{2: {unit, nan}}}, false, true},
+		{OBB{Vec3{}, Vec3{1, 2, 3}, QuatIdentity}, true, false},
+		{OBB{Vec3{}, Vec3{1, inf, 3}, QuatIdentity}, false, false},
+		{OBB{Vec3{}, Vec3{1, 2, 3}, Quat{nan, 0, 0, 0}}, false, true},
+		{Triangle{Vec3{}, unit, Vec3{0, 1, 0}}, true, false},
+		{Triangle{Vec3{}, unit, Vec3{0, inf, 0}}, false, false},
+		{Triangle{Vec3{}, Vec3{nan, 0, 0}, Vec3{0, 1, 0}}, false, true},
+		{Segment{Vec3{}, unit}, true, false},
+		{Segment{Vec3{}, Vec3{-inf, 0, 0}}, false, false},
+		{Segment{Vec3{0, 0, nan}, unit}, false, true},
+		{Capsule{Vec3{}, unit, 1}, true, false},
+		{Capsule{Vec3{}, unit, inf}, false, false},
+		{Capsule{Vec3{}, unit, nan}, false, true},
+	}
+
+	for testIndex, c := range cases {
+		if c.v.IsFinite() != c.finite || c.v.HasNaN() != c.hasNaN {
+			t.Errorf("TestIsFinite %d %T %v", testIndex, c.v, c.v)
+		}
+	}
+}
@@ -0,0 +1,463 @@
+package lmath
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	mat2Dim = 2
+)
+
+var (
+	Mat2Identity = Mat2{[4]float64{
+		1, 0,
+		0, 1}}
+)
+
+type Mat2 struct {
+	mat [4]float64
+}
+
+// New Mat2 with the given values.
+// Row-Order.
+func NewMat2(
+	m11, m12,
+	m21, m22 float64) *Mat2 {
+
+	// 0   1
+	// 2   3
+	out := Mat2{}
+	out.mat[0] = m11
+	out.mat[1] = m12
+	out.mat[2] = m21
+	out.mat[3] = m22
+	return &out
+}
+
+// Load the matrix with 4 floats.
+// Specified in Row-Major order.
+func (this *Mat2) Load(m [4]float64) *Mat2 {
+	this.mat = m
+	return this
+}
+
+// Load the matrix with 4 floats.
+// Specified in Row-Major order.
+func (this *Mat2) Load32(m [4]float32) *Mat2 {
+	for k, v := range m {
+		this.mat[k] = float64(v)
+	}
+	return this
+}
+
+// Retrieve a 4 float array of all the values of the matrix.
+// Returned in Row-Major order.
+func (this Mat2) Dump() (m [4]float64) {
+	m = this.mat
+	return
+}
+
+// Retrieve a 4 float64 array of all the values of the matrix.
+// Returned in Col-Major order.
+func (this Mat2) DumpOpenGL() (m [4]float64) {
+	m[0], m[1] = this.Col(0)
+	m[2], m[3] = this.Col(1)
+	return
+}
+
+// Retrieve a 4 float32 array of all the values of the matrix.
+// Returned in Col-Major order.
+func (this Mat2) DumpOpenGLf32() (m [4]float32) {
+	m[0] = float32(this.mat[0])
+	m[1] = float32(this.mat[2])
+
+	m[2] = float32(this.mat[1])
+	m[3] = float32(this.mat[3])
+	return
+}
+
+// Return a copy of this matrix.
+// Carbon-copy of all elements
+func (this Mat2) Copy() Mat2 {
+	return this
+}
+
+// Compare this matrix to the other.
+// Return true if all elements between them are the same.
+// Equality is measured using an epsilon (< 0.0000001).
+func (this Mat2) Eq(other Mat2) bool {
+	for k, _ := range this.mat {
+		if closeEq(this.mat[k], other.mat[k], epsilon) == false {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal if all elements are equal within the tolerance
+func (this Mat2) ApproxEq(other Mat2, tol Tolerance) bool {
+	for k := range this.mat {
+		if !tol.Eq(this.mat[k], other.mat[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Retrieve the element at row and column.
+// 0 indexed.
+// Does not do any bounds checking.
+func (this Mat2) Get(row, col int) float64 {
+	return this.mat[row*mat2Dim+col]
+}
+
+// Set the value at the specified column and row.
+// 0 indexed.
+// Does not do any bounds checking.
+func (this *Mat2) Set(row, col int, value float64) *Mat2 {
+	this.mat[row*mat2Dim+col] = value
+	return this
+}
+
+// Retrieve the element at the given index assuming a linear array.
+// (i.e matrix[0], matrix[3]).
+// 0 indexed.
+func (this Mat2) At(index int) float64 {
+	return this.mat[index]
+}
+
+// Set the element of the matrix specified at the index to the given value.
+// 0 indexed.
+// Return a pointer to the 'this'
+func (this *Mat2) SetAt(index int, value float64) *Mat2 {
+	this.mat[index] = value
+	return this
+}
+
+// Set the specified row of the matrix to the given x,y values.
+// 0 indexed.
+// Does not do bounds checking of the row.
+func (this *Mat2) SetRow(row int, x, y float64) *Mat2 {
+	this.mat[row*mat2Dim] = x
+	this.mat[row*mat2Dim+1] = y
+	return this
+}
+
+// Set the specified column of the matrix to the given x,y values.
+// 0 indexed.
+// Does not do bounds checking on the col.
+func (this *Mat2) SetCol(col int, x, y float64) *Mat2 {
+	this.mat[mat2Dim*0+col] = x
+	this.mat[mat2Dim*1+col] = y
+	return this
+}
+
+// Retrieve the x,y elements from the specified row.
+// 0 indexed.
+// Does not bounds check the row.
+func (this Mat2) Row(row int) (x, y float64) {
+	x = this.mat[row*mat2Dim]
+	y = this.mat[row*mat2Dim+1]
+	return
+}
+
+// Retrieve the x,y elements from the specified column.
+// 0 indexed.
+// Does not bounds check the column.
+func (this Mat2) Col(col int) (x, y float64) {
+	x = this.mat[mat2Dim*0+col]
+	y = this.mat[mat2Dim*1+col]
+	return
+}
+
+// Add in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+func (this Mat2) AddScalar(val float64) Mat2 {
+	this.AddInScalar(val)
+	return this
+}
+
+// Add in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+func (this *Mat2) AddInScalar(val float64) *Mat2 {
+	for k, _ := range this.mat {
+		this.mat[k] += val
+	}
+	return this
+}
+
+// Subtract in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+func (this Mat2) SubScalar(val float64) Mat2 {
+	this.SubInScalar(val)
+	return this
+}
+
+// Subtract in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+func (this *Mat2) SubInScalar(val float64) *Mat2 {
+	for k, _ := range this.mat {
+		this.mat[k] -= val
+	}
+	return this
+}
+
+// Multiplies in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+func (this Mat2) MultScalar(val float64) Mat2 {
+	this.MultInScalar(val)
+	return this
+}
+
+// Multiplies in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+func (this *Mat2) MultInScalar(val float64) *Mat2 {
+	for k, _ := range this.mat {
+		this.mat[k] *= val
+	}
+	return this
+}
+
+// Divides in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+//
+//	precondition: val > 0
+func (this Mat2) DivScalar(val float64) Mat2 {
+	this.DivInScalar(val)
+	return this
+}
+
+// Divides in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+//
+//	precondition: val > 0
+func (this *Mat2) DivInScalar(val float64) *Mat2 {
+	for k, _ := range this.mat {
+		this.mat[k] /= val
+	}
+	return this
+}
+
+// Adds the two matrices together ( ie.  this + other).
+// Return a new matrix with the result.
+func (this Mat2) Add(other Mat2) Mat2 {
+	this.AddIn(other)
+	return this
+}
+
+// Adds the two matrices together ( ie.  this + other).
+// Stores the result in this.
+// Returns this.
+func (this *Mat2) AddIn(other Mat2) *Mat2 {
+	for k, _ := range this.mat {
+		this.mat[k] += other.mat[k]
+	}
+	return this
+}
+
+// Subtract the two matrices together ( ie.  this - other).
+// Return a new matrix with the result.
+func (this Mat2) Sub(other Mat2) Mat2 {
+	this.SubIn(other)
+	return this
+}
+
+// Subtract the two matrices together ( ie.  this - other).
+// Stores the result in this.
+// Returns this.
+func (this *Mat2) SubIn(other Mat2) *Mat2 {
+	for k, _ := range this.mat {
+		this.mat[k] -= other.mat[k]
+	}
+	return this
+}
+
+// Multiply the two matrices together ( ie.  this * other).
+// Return a new matrix with the result.
+func (this Mat2) Mult(other Mat2) Mat2 {
+	this.MultIn(other)
+	return this
+}
+
+// Multiplies the two matrices together ( ie.  this * other).
+// Stores the result in this.
+// Returns this.
+func (this *Mat2) MultIn(o Mat2) *Mat2 {
+	// 0   1
+	// 2   3
+	m := *this
+	this.mat[0] = m.mat[0]*o.mat[0] + m.mat[1]*o.mat[2]
+	this.mat[1] = m.mat[0]*o.mat[1] + m.mat[1]*o.mat[3]
+
+	this.mat[2] = m.mat[2]*o.mat[0] + m.mat[3]*o.mat[2]
+	this.mat[3] = m.mat[2]*o.mat[1] + m.mat[3]*o.mat[3]
+	return this
+}
+
+// Returns a new matrix which is transpose to this.
+func (this Mat2) Transpose() Mat2 {
+	this.TransposeIn()
+	return this
+}
+
+// Take the transpose of this matrix.
+func (this *Mat2) TransposeIn() *Mat2 {
+	this.mat[1], this.mat[2] = this.mat[2], this.mat[1]
+	return this
+}
+
+// Get the determinant of the matrix.
+func (this Mat2) Determinant() float64 {
+	return det2x2(this.mat[0], this.mat[1], this.mat[2], this.mat[3])
+}
+
+// Returns a new matrix which is the Adjoint matrix of this.
+func (this Mat2) Adjoint() Mat2 {
+	// a b        d  -b
+	// c d  ==>  -c   a
+	this.mat[0], this.mat[3] = this.mat[3], this.mat[0]
+	this.mat[1] = -this.mat[1]
+	this.mat[2] = -this.mat[2]
+	return this
+}
+
+// Returns a new matrix which is the inverse matrix of this.
+// Use HasInverse to check if an inverse exists.
+func (this Mat2) Inverse() Mat2 {
+	det := this.Determinant()
+	return this.Adjoint().DivScalar(det)
+}
+
+// Returns true if the inverse of this matrix exists false otherwise.
+// Internally it checks to see if the determinant is zero.
+func (this Mat2) HasInverse() bool {
+	return !closeEq(this.Determinant(), 0, epsilon)
+}
+
+// Return the inverse of the matrix and true, or the zero matrix and false when
+// the matrix is singular or the inverse is not finite. Unlike HasInverse the
+// singular check is relative to the size of the entries, as in Mat3.LU.
+func (this Mat2) TryInverse() (Mat2, bool) {
+	a := [16]float64{}
+	copy(a[:], this.mat[:])
+	if _, ok := luDecompose(&a, 2, epsilon); !ok {
+		return Mat2{}, false
+	}
+	out := this.Inverse()
+	if !out.IsFinite() {
+		return Mat2{}, false
+	}
+	return out, true
+}
+
+// Sets the matrix to the identity matrix.
+func (this *Mat2) ToIdentity() *Mat2 {
+	this.mat = [4]float64{
+		1, 0,
+		0, 1,
+	}
+	return this
+}
+
+//==============================================================================
+
+// Return true if the matrix is the identity matrix.
+func (this Mat2) IsIdentity() bool {
+	return this.Eq(Mat2Identity)
+}
+
+// Check to see if the matrix is a valid rotation matrix.
+//
+//	The two properties it checks are
+//	1) Determinant() == 1
+//	2) m*m.Transpose  == Identity
+func (this Mat2) IsRotation() bool {
+	return closeEq(this.Determinant(), 1, epsilon) && this.Mult(this.Transpose()).IsIdentity()
+}
+
+// Return true if no element is NaN or infinite.
+func (this Mat2) IsFinite() bool {
+	for _, v := range this.mat {
+		if !isFinite(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if any element is NaN.
+func (this Mat2) HasNaN() bool {
+	for _, v := range this.mat {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Implement the Stringer interface
+// Prints out each row of the matrix on its own line
+func (this Mat2) String() string {
+	return fmt.Sprintf("%f %f\n%f %f",
+		this.mat[0], this.mat[1],
+		this.mat[2], this.mat[3])
+}
+
+// =============================================================================
+
+// Create a 2D scaling matrix for Mat2. Overwrites all values in the matrix.
+func (this *Mat2) ToScale(x, y float64) *Mat2 {
+	this.ToIdentity()
+	this.Set(0, 0, x)
+	this.Set(1, 1, y)
+	return this
+}
+
+// Create a 2D shearing matrix for Mat2. Overwrites all values in the matrix.
+// The diagonal is zero, matching Mat3.ToShear.
+//
+//	0 x
+//	y 0
+func (this *Mat2) ToShear(x, y float64) *Mat2 {
+	this.ToIdentity()
+	this.Set(0, 0, 0)
+	this.Set(1, 1, 0)
+
+	this.Set(0, 1, x)
+	this.Set(1, 0, y)
+	return this
+}
+
+// Multiplies the Vec2 against the matrix ( ie. result = Matrix * Vec).
+// Returns a new vector with the result.
+func (this Mat2) MultVec2(v Vec2) (out Vec2) {
+	// 0   1
+	// 2   3
+	out.Set(
+		this.mat[0]*v.X+this.mat[1]*v.Y,
+		this.mat[2]*v.X+this.mat[3]*v.Y,
+	)
+	return
+}
+
+// =============================================================================
+
+// Set this as a counter-clockwise rotation matrix with the given angle (radians)
+// cos  -sin
+// sin   cos
+func (this *Mat2) FromAngle(angle float64) *Mat2 {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+	return this.Load([4]float64{
+		c, -s,
+		s, c,
+	})
+}
+
+// Return the angle (radians) of this rotation matrix.
+// Assumes the matrix is a valid rotation matrix.
+// The returned angle is in the range [-pi,pi]
+func (this Mat2) Angle() float64 {
+	return math.Atan2(this.mat[2], this.mat[0])
+}
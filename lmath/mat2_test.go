@@ -0,0 +1,453 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+// Test creation with 4 values
+// Test Equals with matrices
+func TestNewMat2(t *testing.T) {
+	m := NewMat2(
+		1, 2,
+		3, 4)
+	m2 := NewMat2(
+		1, 2,
+		3, 4)
+	if m.Eq(*m2) == false {
+		t.Errorf("TestNewMat2 ")
+	}
+	if m.Eq(Mat2Identity) {
+		t.Errorf("TestNewMat2 identity")
+	}
+}
+
+// Test Get
+// Test Set
+func TestGetterSetterMat2(t *testing.T) {
+	cases := []struct {
+		x, y          int
+		wantBeforeSet float64
+		wantAfterSet  float64
+	}{
+		{0, 0, 1, 10},
+		{1, 0, 2, 20},
+		{0, 1, 3, 30},
+		{1, 1, 4, 40},
+	}
+
+	for testIndex, c := range cases {
+		orig := &Mat2{[4]float64{1, 2, 3, 4}}
+		get := orig.Get(c.y, c.x)
+		if get != c.wantBeforeSet {
+			t.Errorf("TestGetterSetterMat2 wantBeforeSet %d %v", testIndex, get)
+		}
+
+		get = orig.Set(c.y, c.x, c.wantAfterSet).Get(c.y, c.x)
+		if get != c.wantAfterSet {
+			t.Errorf("TestGetterSetterMat2 wantAfterSet %d %v", testIndex, get)
+		}
+	}
+
+	orig := &Mat2{[4]float64{1, 2, 3, 4}}
+	for k, _ := range orig.Dump() {
+		get := orig.At(k)
+		if get != float64(k+1) {
+			t.Errorf("TestGetterSetterMat2 At %d %v", k, get)
+		}
+
+		orig.SetAt(k, float64((k+1)*10))
+		get = orig.At(k)
+		if get != float64((k+1)*10) {
+			t.Errorf("TestGetterSetterMat2 SetAt %d", k)
+		}
+	}
+}
+
+// Test Load Array
+// Test Dump
+func TestLoadDumpMat2(t *testing.T) {
+	cases := []struct {
+		loadArray [4]float64
+	}{
+		{[4]float64{1, 2, 3, 4}},
+		{[4]float64{0, 0, 0, 0}},
+		{[4]float64{-1, -2, -3, -4}},
+	}
+
+	m := &Mat2{}
+	for testIndex, c := range cases {
+		m.Load(c.loadArray)
+		get := m.Dump()
+
+		for k, _ := range get {
+			if get[k] != c.loadArray[k] {
+				t.Errorf("TestLoadDumpMat2 %d", testIndex)
+				break
+			}
+		}
+
+		m.Load32([4]float32{float32(c.loadArray[0]), float32(c.loadArray[1]),
+			float32(c.loadArray[2]), float32(c.loadArray[3])})
+		get = m.Dump()
+		for k, _ := range get {
+			if get[k] != c.loadArray[k] {
+				t.Errorf("TestLoad32Mat2 %d", testIndex)
+				break
+			}
+		}
+	}
+
+	m.Load([4]float64{1, 3, 2, 4})
+	get := m.DumpOpenGL()
+	for k, _ := range get {
+		if get[k] != cases[0].loadArray[k] {
+			t.Errorf("TestDumpOpenGLMat2")
+			break
+		}
+	}
+
+	get2 := m.DumpOpenGLf32()
+	for k, _ := range get {
+		if !closeEq(float64(get2[k]), cases[0].loadArray[k], epsilon) {
+			t.Errorf("TestDumpOpenGLf32Mat2")
+			break
+		}
+	}
+}
+
+func TestRowColMat2(t *testing.T) {
+	m := &Mat2{[4]float64{1, 2, 3, 4}}
+	if x, y := m.Row(0); x != 1 || y != 2 {
+		t.Errorf("TestRowMat2 0")
+	}
+	if x, y := m.Row(1); x != 3 || y != 4 {
+		t.Errorf("TestRowMat2 1")
+	}
+	if x, y := m.Col(0); x != 1 || y != 3 {
+		t.Errorf("TestColMat2 0")
+	}
+	if x, y := m.Col(1); x != 2 || y != 4 {
+		t.Errorf("TestColMat2 1")
+	}
+
+	m.SetRow(0, -1, -2)
+	if x, y := m.Row(0); x != -1 || y != -2 {
+		t.Errorf("TestSetRowMat2")
+	}
+	m.SetCol(1, -5, -6)
+	if x, y := m.Col(1); x != -5 || y != -6 {
+		t.Errorf("TestSetColMat2")
+	}
+}
+
+func TestScalarMat2(t *testing.T) {
+	cases := []struct {
+		orig  [4]float64
+		value float64
+	}{
+		{[4]float64{0, 0, 0, 0}, 0},
+		{[4]float64{0, 0, 0, 0}, 1},
+		{[4]float64{1, 2, 3, 4}, -1},
+		{[4]float64{1, 2, 3, 4}, 2},
+	}
+
+	m := Mat2{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		add := m.AddScalar(c.value).Dump()
+		sub := m.SubScalar(c.value).Dump()
+		mult := m.MultScalar(c.value).Dump()
+		for k, _ := range c.orig {
+			if add[k] != c.orig[k]+c.value ||
+				sub[k] != c.orig[k]-c.value ||
+				mult[k] != c.orig[k]*c.value {
+				t.Errorf("TestScalarMat2 %d %d", testIndex, k)
+				break
+			}
+		}
+		if c.value != 0 {
+			div := m.DivScalar(c.value).Dump()
+			for k, _ := range c.orig {
+				if div[k] != c.orig[k]/c.value {
+					t.Errorf("TestDivScalarMat2 %d %d", testIndex, k)
+					break
+				}
+			}
+		}
+
+		if m.AddInScalar(c.value) != &m || m.SubInScalar(c.value) != &m ||
+			m.MultInScalar(1) != &m || m.DivInScalar(1) != &m {
+			t.Errorf("TestScalarInMat2 %d", testIndex)
+		}
+		get := m.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k] {
+				t.Errorf("TestScalarInMat2 %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestAddSubMat2(t *testing.T) {
+	cases := []struct {
+		orig, other, wantAdd, wantSub [4]float64
+	}{
+		{[4]float64{0, 0, 0, 0}, [4]float64{1, 2, 3, 4}, [4]float64{1, 2, 3, 4}, [4]float64{-1, -2, -3, -4}},
+		{[4]float64{1, 2, 3, 4}, [4]float64{1, 2, 3, 4}, [4]float64{2, 4, 6, 8}, [4]float64{0, 0, 0, 0}},
+		{[4]float64{1, -2, 3, -4}, [4]float64{-1, 2, -3, 4}, [4]float64{0, 0, 0, 0}, [4]float64{2, -4, 6, -8}},
+	}
+
+	orig := Mat2{}
+	other := Mat2{}
+	for testIndex, c := range cases {
+		orig.Load(c.orig)
+		other.Load(c.other)
+
+		get := orig.Add(other).Dump()
+		get2 := orig.Sub(other).Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.wantAdd[k] || get2[k] != c.wantSub[k] {
+				t.Errorf("TestAddSubMat2 %d %d", testIndex, k)
+				break
+			}
+		}
+
+		if orig.AddIn(other) != &orig || orig.Dump() != c.wantAdd {
+			t.Errorf("TestAddInMat2 %d", testIndex)
+		}
+		orig.Load(c.orig)
+		if orig.SubIn(other) != &orig || orig.Dump() != c.wantSub {
+			t.Errorf("TestSubInMat2 %d", testIndex)
+		}
+	}
+}
+
+func TestMultMat2(t *testing.T) {
+	cases := []struct {
+		orig, other, want [4]float64
+	}{
+		{[4]float64{0, 0, 0, 0}, [4]float64{1, 2, 3, 4}, [4]float64{0, 0, 0, 0}},
+		{[4]float64{1, 0, 0, 1}, [4]float64{1, 2, 3, 4}, [4]float64{1, 2, 3, 4}},
+		{[4]float64{1, 2, 3, 4}, [4]float64{1, 0, 0, 1}, [4]float64{1, 2, 3, 4}},
+		{[4]float64{1, 2, 3, 4}, [4]float64{1, 2, 3, 4}, [4]float64{7, 10, 15, 22}},
+		{[4]float64{5, 6, 7, 8}, [4]float64{1, 2, 3, 4}, [4]float64{23, 34, 31, 46}},
+	}
+
+	orig := Mat2{}
+	other := Mat2{}
+	for testIndex, c := range cases {
+		orig.Load(c.orig)
+		other.Load(c.other)
+
+		get := orig.Mult(other).Dump()
+		for k, _ := range c.orig {
+			if closeEq(get[k], c.want[k], epsilon) == false {
+				t.Errorf("TestMultMat2 %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := orig.MultIn(other)
+		if ret_mat2 != &orig || orig.Dump() != c.want {
+			t.Errorf("TestMultInMat2 %d", testIndex)
+		}
+	}
+}
+
+func TestIdentityMat2(t *testing.T) {
+	m := &Mat2{}
+	m.Load([4]float64{1, 2, 3, 4})
+	if m.IsIdentity() {
+		t.Errorf("TestIdentityMat2 IsIdentity")
+	}
+	m.ToIdentity()
+	if m.Dump() != [4]float64{1, 0, 0, 1} || !m.IsIdentity() {
+		t.Errorf("TestIdentityMat2")
+	}
+}
+
+func TestTransposeMat2(t *testing.T) {
+	m := &Mat2{}
+	m.Load([4]float64{1, 2, 3, 4})
+	if m.Transpose().Dump() != [4]float64{1, 3, 2, 4} {
+		t.Errorf("TestTransposeMat2")
+	}
+	if m.TransposeIn() != m || m.Dump() != [4]float64{1, 3, 2, 4} {
+		t.Errorf("TestTransposeInMat2")
+	}
+}
+
+func TestInverseMat2(t *testing.T) {
+	cases := []struct {
+		orig, wantAdjoint, want [4]float64
+		wantDet                 float64
+		want_inverse_flag       bool
+	}{
+		{[4]float64{1, 2, 3, 4}, [4]float64{4, -2, -3, 1}, [4]float64{-2, 1, 1.5, -0.5}, -2, true},
+		{[4]float64{1, 0, 0, 1}, [4]float64{1, 0, 0, 1}, [4]float64{1, 0, 0, 1}, 1, true},
+		{[4]float64{2, 0, 0, 4}, [4]float64{4, 0, 0, 2}, [4]float64{0.5, 0, 0, 0.25}, 8, true},
+		{[4]float64{1, 2, 2, 4}, [4]float64{4, -2, -2, 1}, [4]float64{}, 0, false},
+		{[4]float64{0, 0, 0, 0}, [4]float64{0, 0, 0, 0}, [4]float64{}, 0, false},
+	}
+
+	m := &Mat2{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		if get := m.Determinant(); !closeEq(get, c.wantDet, epsilon) {
+			t.Errorf("TestDeterminantMat2 %d %v", testIndex, get)
+		}
+		if get := m.Adjoint().Dump(); get != c.wantAdjoint {
+			t.Errorf("TestAdjointMat2 %d %v", testIndex, get)
+		}
+		if get := m.HasInverse(); get != c.want_inverse_flag {
+			t.Errorf("TestInverseMat2 %d %v", testIndex, get)
+			continue
+		}
+		if c.want_inverse_flag == false {
+			continue
+		}
+
+		inv := m.Inverse()
+		get := inv.Dump()
+		for k, _ := range c.want {
+			if closeEq(get[k], c.want[k], epsilon) == false {
+				t.Errorf("TestInverseMat2 %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+		if !m.Mult(inv).IsIdentity() {
+			t.Errorf("TestInverseMat2 identity %d", testIndex)
+		}
+	}
+}
+
+func TestToScaleShearMat2(t *testing.T) {
+	m := &Mat2{}
+	if m.ToScale(2, -3).Dump() != [4]float64{2, 0, 0, -3} {
+		t.Errorf("TestToScaleMat2")
+	}
+	if get := m.MultVec2(Vec2{1, 1}); !get.Eq(Vec2{2, -3}) {
+		t.Errorf("TestToScaleMat2 MultVec2 %v", get)
+	}
+	if m.ToShear(2, 3).Dump() != [4]float64{0, 2, 3, 0} {
+		t.Errorf("TestToShearMat2")
+	}
+	if get := m.MultVec2(Vec2{1, 1}); !get.Eq(Vec2{2, 3}) {
+		t.Errorf("TestToShearMat2 MultVec2 %v", get)
+	}
+}
+
+func TestMultVec2Mat2(t *testing.T) {
+	cases := []struct {
+		orig_mat     [4]float64
+		orig_v, want Vec2
+	}{
+		{[4]float64{1, 0, 0, 1}, Vec2{1, 0}, Vec2{1, 0}},
+		{[4]float64{2, 0, 0, 2}, Vec2{1, 1}, Vec2{2, 2}},
+		{[4]float64{1, 2, 3, 4}, Vec2{1, 0}, Vec2{1, 3}},
+		{[4]float64{1, 2, 3, 4}, Vec2{1, 2}, Vec2{5, 11}},
+	}
+
+	m := Mat2{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.MultVec2(c.orig_v)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestMultVec2Mat2 %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+	}
+}
+
+func TestAngleMat2(t *testing.T) {
+	cases := []struct {
+		angle     float64
+		start_vec Vec2
+		want      Vec2
+		wantAngle float64
+	}{
+		{0, Vec2{1, 0}, Vec2{1, 0}, 0},
+		{90, Vec2{1, 0}, Vec2{0, 1}, 90},
+		{-90, Vec2{1, 0}, Vec2{0, -1}, -90},
+		{180, Vec2{1, 0}, Vec2{-1, 0}, 180},
+		{45, Vec2{0, 1}, Vec2{-math.Sqrt(2) / 2, math.Sqrt(2) / 2}, 45},
+		{270, Vec2{1, 0}, Vec2{0, -1}, -90},
+	}
+
+	m := &Mat2{}
+	for testIndex, c := range cases {
+		m.FromAngle(Radians(c.angle))
+		if !m.IsRotation() {
+			t.Errorf("TestAngleMat2 IsRotation %d", testIndex)
+		}
+
+		get := m.MultVec2(c.start_vec)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestFromAngleMat2 %d %v", testIndex, get)
+		}
+
+		// Must agree with the 2D rotation of Mat3
+		m3 := Mat3{}
+		m3.ToRotateZ(Radians(c.angle))
+		if !m3.UpperMat2().Eq(*m) {
+			t.Errorf("TestFromAngleMat2 Mat3 %d", testIndex)
+		}
+
+		if get := Degrees(m.Angle()); !closeEq(get, c.wantAngle, epsilon) {
+			t.Errorf("TestAngleMat2 %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestUpperMat2Mat3(t *testing.T) {
+	m3 := Mat3{}
+	m3.Load([9]float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	if m3.UpperMat2().Dump() != [4]float64{1, 2, 4, 5} {
+		t.Errorf("TestUpperMat2Mat3")
+	}
+
+	m2 := NewMat2(-1, -2, -3, -4)
+	if m3.SetUpperMat2(*m2) != &m3 {
+		t.Errorf("TestSetUpperMat2Mat3")
+	}
+	if m3.Dump() != [9]float64{-1, -2, 3, -3, -4, 6, 7, 8, 9} {
+		t.Errorf("TestSetUpperMat2Mat3 %v", m3.Dump())
+	}
+}
+
+func TestStringMat2(t *testing.T) {
+	m := NewMat2(1, 2, 3, 4)
+	want := "1.000000 2.000000\n3.000000 4.000000"
+	if m.String() != want {
+		t.Errorf("TestStringMat2 %v", m.String())
+	}
+}
+
+func TestTryInverseMat2(t *testing.T) {
+	cases := []struct {
+		m, want Mat2
+		ok      bool
+	}{
+		{Mat2{[4]float64{1, 0, 0, 1}}, Mat2{[4]float64{1, 0, 0, 1}}, true},
+		{Mat2{[4]float64{2, 0, 0, 3}}, Mat2{[4]float64{0.5, 0, 0, 0.3333333333333333}}, true},
+		{Mat2{[4]float64{1, 1, 1, 1}}, Mat2{}, false},
+		{Mat2{}, Mat2{}, false},
+		// singular is judged relative to the entries, not by the determinant
+		{Mat2{[4]float64{1e-6, 0, 0, 1e-6}}, Mat2{[4]float64{1e6, 0, 0, 1e6}}, true},
+		{Mat2{[4]float64{1e10, 2e10, 2e10, 4e10}}, Mat2{}, false},
+	}
+
+	for testIndex, c := range cases {
+		got, ok := c.m.TryInverse()
+		if ok != c.ok {
+			t.Errorf("TestTryInverseMat2 %d ok %v", testIndex, ok)
+		}
+		for k := 0; k < 4; k++ {
+			if !closeEq(got.At(k), c.want.At(k), 1e-6) {
+				t.Errorf("TestTryInverseMat2 %d\n%v\n%v", testIndex, got, c.want)
+				break
+			}
+		}
+	}
+}
@@ -111,6 +111,16 @@ func (this Mat3) Eq(other Mat3) bool {
 	return true
 }
 
+// Equal if all elements are equal within the tolerance
+func (this Mat3) ApproxEq(other Mat3, tol Tolerance) bool {
+	for k := range this.mat {
+		if !tol.Eq(this.mat[k], other.mat[k]) {
+			return false
+		}
+	}
+	return true
+}
+
 // Retrieve the element at row and column.
 // 0 indexed.
 // Does not do any bounds checking.
@@ -392,6 +402,20 @@ func (this Mat3) HasInverse() bool {
 	return !closeEq(this.Determinant(), 0, epsilon)
 }
 
+// Return the inverse of the matrix and true, or the zero matrix and false when
+// the matrix is singular or the inverse is not finite. Unlike HasInverse the
+// singular check is relative to the size of the entries, see LU.
+func (this Mat3) TryInverse() (Mat3, bool) {
+	if _, _, _, ok := this.LU(); !ok {
+		return Mat3{}, false
+	}
+	out := this.Inverse()
+	if !out.IsFinite() {
+		return Mat3{}, false
+	}
+	return out, true
+}
+
 // Sets the matrix to the identity matrix.
 func (this *Mat3) ToIdentity() *Mat3 {
 	this.mat = [9]float64{
@@ -427,6 +451,26 @@ func (this Mat3) IsRotation() bool {
 	return closeEq(this.Determinant(), 1, epsilon) && this.Mult(this.Transpose()).IsIdentity()
 }
 
+// Return true if no element is NaN or infinite.
+func (this Mat3) IsFinite() bool {
+	for _, v := range this.mat {
+		if !isFinite(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if any element is NaN.
+func (this Mat3) HasNaN() bool {
+	for _, v := range this.mat {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
+
 // Implement the Stringer interface
 // Prints out each row of the matrix on its own line
 func (this Mat3) String() string {
@@ -482,6 +526,25 @@ func (this *Mat3) ToRotateZ(angle float64) *Mat3 {
 
 //==============================================================================
 
+// Return the upper 2x2 matrix as a Mat2
+func (this Mat3) UpperMat2() (out Mat2) {
+	out.Load([4]float64{
+		this.Get(0, 0), this.Get(0, 1),
+		this.Get(1, 0), this.Get(1, 1),
+	})
+	return out
+}
+
+// Set the upper 2x2 matrix to the provided Mat2
+func (this *Mat3) SetUpperMat2(m Mat2) *Mat3 {
+	this.Set(0, 0, m.Get(0, 0))
+	this.Set(0, 1, m.Get(0, 1))
+
+	this.Set(1, 0, m.Get(1, 0))
+	this.Set(1, 1, m.Get(1, 1))
+	return this
+}
+
 // Multiplies the Vec3 against the matrix ( ie. result = Matrix * Vec).
 // Returns a new vector with the result.
 func (this Mat3) MultVec3(v Vec3) (out Vec3) {
@@ -496,6 +559,58 @@ func (this Mat3) MultVec3(v Vec3) (out Vec3) {
 	return
 }
 
+// Multiplies the Vec2 against the matrix treating it as a point [x,y,1]
+// ( ie. result = Matrix * Vec). Translation is applied.
+// Returns a new vector with the result.
+func (this Mat3) MultVec2(v Vec2) (out Vec2) {
+	// 0   1   2
+	// 3   4   5
+	// 6   7   8
+	out.Set(
+		this.mat[0]*v.X+this.mat[1]*v.Y+this.mat[2],
+		this.mat[3]*v.X+this.mat[4]*v.Y+this.mat[5],
+	)
+	return
+}
+
+// Multiplies the Vec2 against the matrix treating it as a direction [x,y,0]
+// ( ie. result = Matrix * Vec). Translation is not applied.
+// Returns a new vector with the result.
+func (this Mat3) MultVec2Dir(v Vec2) (out Vec2) {
+	out.Set(
+		this.mat[0]*v.X+this.mat[1]*v.Y,
+		this.mat[3]*v.X+this.mat[4]*v.Y,
+	)
+	return
+}
+
+// Transform the 2D point by the matrix, treating it as [x,y,1].
+// Translation is applied and the result is divided by the third component,
+// so this is correct for 2D projective matrices as well as affine ones.
+// Returns a new vector with the result.
+func (this Mat3) TransformPoint(p Vec2) Vec2 {
+	v := this.MultVec3(Vec3{p.X, p.Y, 1})
+	if v.Z != 1 {
+		return Vec2{v.X / v.Z, v.Y / v.Z}
+	}
+	return Vec2{v.X, v.Y}
+}
+
+// Transform the 2D direction by the matrix, treating it as [x,y,0].
+// Only the upper 2x2 is applied, translation is ignored.
+// Returns a new vector with the result.
+func (this Mat3) TransformDirection(d Vec2) Vec2 {
+	return this.MultVec2Dir(d)
+}
+
+// Transform the 2D normal by the inverse-transpose of the upper 2x2, so that
+// it stays perpendicular to the line under non-uniform scaling.
+// The upper 2x2 must be invertible.
+// Returns a new unit length vector with the result.
+func (this Mat3) TransformNormal(n Vec2) Vec2 {
+	return this.UpperMat2().Inverse().Transpose().MultVec2(n).Normalize()
+}
+
 // =============================================================================
 
 // Return a rotation matrix which rotates a vector about the axis [x,y,z] with
@@ -512,6 +627,32 @@ func (this *Mat3) FromAxisAngle(angle, x, y, z float64) *Mat3 {
 		z*x*t - y*s, z*y*t + x*s, c + z*z*t})
 }
 
+// Return the rotation matrix which turns the direction a onto the direction b,
+// see QuatFromTo.
+func Mat3FromTo(a, b Vec3) Mat3 {
+	return QuatFromTo(a, b).Mat3()
+}
+
+// Return the rotation matrix which turns Vec3Forward onto forward and Vec3Up
+// towards up, see QuatLookRotation.
+func Mat3LookRotation(forward, up Vec3) Mat3 {
+	// both are normalized first so only the angle between them matters
+	f := forward.SafeNormalize(Vec3{})
+	r := up.SafeNormalize(Vec3{}).Cross(f)
+	if f == (Vec3{}) || r.Length() < epsilon {
+		return Mat3FromTo(Vec3Forward, forward)
+	}
+	r = r.Normalize()
+	u := f.Cross(r)
+
+	// the columns are where the X, Y and Z axes end up
+	m := Mat3{}
+	m.SetCol(0, r.X, r.Y, r.Z)
+	m.SetCol(1, u.X, u.Y, u.Z)
+	m.SetCol(2, f.X, f.Y, f.Z)
+	return m
+}
+
 // Set this as a rotation matrix using the specified pitch,yaw, and roll paramters.
 // Angles are in radians.
 func (this *Mat3) FromEuler(pitch, yaw, roll float64) *Mat3 {
@@ -549,15 +690,22 @@ func (this *Mat3) FromEuler(pitch, yaw, roll float64) *Mat3 {
 // Return the axis (radians) and axis of this rotation matrix.
 // Assumes the matrix is a valid rotation matrix.
 func (this Mat3) AxisAngle() (angle, x, y, z float64) {
+	return mat3AxisAngle(this, epsilon)
+}
+
+// Return the angle and axis of the rotation matrix, treating the matrix as
+// symmetric when the difference of the off diagonal terms is below eps.
+// Shared with the float32 matrices which need a larger eps.
+func mat3AxisAngle(this Mat3, eps float64) (angle, x, y, z float64) {
 	// Reference
 	// http://www.euclideanspace.com/maths/geometry/rotations/conversions/matrixToAngle/
 	m00, m01, m02 := this.Get(0, 0), this.Get(0, 1), this.Get(0, 2)
 	m10, m11, m12 := this.Get(1, 0), this.Get(1, 1), this.Get(1, 2)
 	m20, m21, m22 := this.Get(2, 0), this.Get(2, 1), this.Get(2, 2)
 
-	if closeEq(math.Abs(m01-m10), 0, epsilon) &&
-		closeEq(math.Abs(m02-m20), 0, epsilon) &&
-		closeEq(math.Abs(m12-m21), 0, epsilon) {
+	if closeEq(math.Abs(m01-m10), 0, eps) &&
+		closeEq(math.Abs(m02-m20), 0, eps) &&
+		closeEq(math.Abs(m12-m21), 0, eps) {
 		// singularity check
 		// Checking for cases in which the angle is either 0 or 180
 
@@ -579,7 +727,7 @@ func (this Mat3) AxisAngle() (angle, x, y, z float64) {
 		yz := (m12 + m21) / 4
 
 		if (xx > yy) && (xx > zz) { // m[0][0] is the largest diagonal term
-			if xx < epsilon {
+			if xx < eps {
 				x = 0
 				y = math.Sqrt(2) / 2
 				z = math.Sqrt(2) / 2
@@ -589,7 +737,7 @@ func (this Mat3) AxisAngle() (angle, x, y, z float64) {
 				z = xz / x
 			}
 		} else if yy > zz { // m[1][1] is the largest diagonal term
-			if yy < epsilon {
+			if yy < eps {
 				x = math.Sqrt(2) / 2
 				y = 0
 				z = math.Sqrt(2) / 2
@@ -599,7 +747,7 @@ func (this Mat3) AxisAngle() (angle, x, y, z float64) {
 				z = yz / y
 			}
 		} else { // m[2][2] is the largest diagonal term so base result on this
-			if zz < epsilon {
+			if zz < eps {
 				x = math.Sqrt(2) / 2
 				y = math.Sqrt(2) / 2
 				z = 0
@@ -628,59 +776,7 @@ func (this Mat3) AxisAngle() (angle, x, y, z float64) {
 // The returned euler angle may not be the exact angle in which you supplied
 // but they can be used to make an equilvalent rotation matrix.
 func (this Mat3) Euler() (pitch, yaw, roll float64) {
-	// The method for calculating the euler angles from a rotation matrix
-	// uses the method described in this document
-	// http://staff.city.ac.uk/~sbbh653/publications/euler.pdf
-
-	// The rotation matrix we are using will be of the following form
-	// cos(x) is abbreviated as cx ( similarily sin(x) = sx)
-	// This corresponds to the pitch => yaw => roll rotation matrix
-	// cz*cy       cz*sy*sx - sz*cx         sz*sx + cz*cx*sy   | r11 r12 r13
-	// sz*cy       cz*cx + sx*sy*sz         sz*sy*cx - cz*sx   | r21 r22 r23
-	// -sy         sx*cy                    cx*cy              | r31 r32 r33
-
-	// We want to determine the x,y,z angles
-	// 1) Find the 'y' angle
-	//      This is easily accomplished because term r31 is simply '-sin(y)'
-	// 2) There are two possible angles for y because
-	//      sin(y) == sin(pi - y)
-	// 3) To find the value of x, we observe the following
-	//      r32/r33 = tan(x)
-	//      (sin(x)cos(y)) / (cos(x)cos(y))
-	//      (sin(x)/cos(x)) == tan(x) by defn.
-	// 4) We can also calculate x and z by.
-	//      x = atan2(r32,r33) == atan2( (sin(x)cos(y)) / (cos(x)cos(y)) )
-	//      z = atan2(r21,r11) == atan2( (sin(z)cos(y)) / (cos(z)cos(y)) )
-
-	var x, y, z float64
-	r31 := this.Get(2, 0)
-	if closeEq(r31, 1, epsilon) {
-		// we are in gimbal lock
-		z = 0
-		y = -math.Pi / 2
-		x = -z + math.Atan2(-this.Get(0, 1), -this.Get(0, 2))
-	} else if closeEq(r31, -1, epsilon) {
-		// we are in gimbal lock
-		z = 0
-		y = math.Pi / 2
-		x = z + math.Atan2(this.Get(0, 1), this.Get(0, 2))
-	} else {
-		y = -math.Asin(r31)
-		cos_y := math.Cos(y)
-		x = math.Atan2(this.Get(2, 1)/cos_y, this.Get(2, 2)/cos_y)
-		z = math.Atan2(this.Get(1, 0)/cos_y, this.Get(0, 0)/cos_y)
-
-		// There are two alternative values for y,here is the second option
-		// y2 := math.Pi - y
-		// cos_y2 := math.Cos(y2)
-		// x2 := math.Atan2(this.Get(2, 1)/cos_y2, this.Get(2, 2)/cos_y2)
-		// z2 := math.Atan2(this.Get(1, 0)/cos_y2, this.Get(0, 0)/cos_y2)
-	}
-
-	pitch = x
-	yaw = y
-	roll = z
-	return
+	return this.EulerOrder(ExtrinsicXYZ)
 }
 
 // Creates a rotation matrix from the given quaternion. Return this
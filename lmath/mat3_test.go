@@ -696,6 +696,48 @@ func TestMultVec3Mat3(t *testing.T) {
 	}
 }
 
+func TestMultVec2Mat3(t *testing.T) {
+	cases := []struct {
+		orig_mat            [9]float64
+		orig_v, want, wantD Vec2
+	}{
+		{[9]float64{1, 0, 0, 0, 1, 0, 0, 0, 1}, Vec2{1, 0}, Vec2{1, 0}, Vec2{1, 0}},
+		{[9]float64{2, 0, 0, 0, 2, 0, 0, 0, 1}, Vec2{1, 1}, Vec2{2, 2}, Vec2{2, 2}},
+		{[9]float64{1, 0, 3, 0, 1, -4, 0, 0, 1}, Vec2{1, 1}, Vec2{4, -3}, Vec2{1, 1}},
+		{[9]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, Vec2{1, 2}, Vec2{8, 20}, Vec2{5, 14}},
+	}
+
+	m := Mat3{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.MultVec2(c.orig_v)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestMultVec2Mat3 %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+		get = m.MultVec2Dir(c.orig_v)
+		if get.Eq(c.wantD) == false {
+			t.Errorf("TestMultVec2DirMat3 %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+	}
+
+	// The 2D transform helpers should compose with MultVec2
+	m.ToRotateZ(Radians(90))
+	if get := m.MultVec2(Vec2{1, 0}); !get.Eq(Vec2{0, 1}) {
+		t.Errorf("TestMultVec2Mat3 rotate %v", get)
+	}
+	m.ToTranslate(2, 3)
+	if get := m.MultVec2(Vec2{1, 1}); !get.Eq(Vec2{3, 4}) {
+		t.Errorf("TestMultVec2Mat3 translate %v", get)
+	}
+	if get := m.MultVec2Dir(Vec2{1, 1}); !get.Eq(Vec2{1, 1}) {
+		t.Errorf("TestMultVec2DirMat3 translate %v", get)
+	}
+	m.ToScale(2, -1)
+	if get := m.MultVec2(Vec2{1, 1}); !get.Eq(Vec2{2, -1}) {
+		t.Errorf("TestMultVec2Mat3 scale %v", get)
+	}
+}
+
 func TestFromAxisAngleMat3(t *testing.T) {
 	cases := []struct {
 		angle     float64
@@ -958,3 +1000,135 @@ func TestEulerMat3(t *testing.T) {
 		t.Errorf("TestEulerMat3 %d %f %f %f", testIndex, x, y, z)
 	}
 }
+
+func TestTransformPointMat3(t *testing.T) {
+	cases := []struct {
+		orig_mat     [9]float64
+		orig_v, want Vec2
+	}{
+		{[9]float64{1, 0, 2, 0, 1, 3, 0, 0, 1}, Vec2{1, 1}, Vec2{3, 4}},
+		{[9]float64{2, 0, 0, 0, 3, 0, 0, 0, 1}, Vec2{1, 1}, Vec2{2, 3}},
+		// projective, divided by the third component
+		{[9]float64{1, 0, 0, 0, 1, 0, 1, 0, 1}, Vec2{1, 4}, Vec2{0.5, 2}},
+		{[9]float64{2, 0, 0, 0, 2, 0, 0, 0, 2}, Vec2{3, 4}, Vec2{3, 4}},
+	}
+
+	m := Mat3{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.TransformPoint(c.orig_v)
+		if get.Sub(c.want).Length() > 1e-9 {
+			t.Errorf("TestTransformPointMat3 %d %v %v", testIndex, get, c.want)
+		}
+	}
+}
+
+func TestTransformDirectionMat3(t *testing.T) {
+	cases := []struct {
+		orig_mat     [9]float64
+		orig_v, want Vec2
+	}{
+		{[9]float64{1, 0, 2, 0, 1, 3, 0, 0, 1}, Vec2{1, 1}, Vec2{1, 1}},
+		{[9]float64{2, 0, 5, 0, 3, 5, 0, 0, 1}, Vec2{1, 1}, Vec2{2, 3}},
+		{[9]float64{0, -1, 5, 1, 0, 5, 0, 0, 1}, Vec2{1, 0}, Vec2{0, 1}},
+	}
+
+	m := Mat3{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.TransformDirection(c.orig_v)
+		if get.Sub(c.want).Length() > 1e-9 {
+			t.Errorf("TestTransformDirectionMat3 %d %v %v", testIndex, get, c.want)
+		}
+	}
+}
+
+func TestTransformNormalMat3(t *testing.T) {
+	cases := []struct {
+		orig_mat        [9]float64
+		normal, tangent Vec2
+	}{
+		{[9]float64{1, 0, 2, 0, 4, 3, 0, 0, 1}, Vec2{1, 1}, Vec2{1, -1}},
+		{[9]float64{3, 1, 0, 0, 1, 0, 0, 0, 1}, Vec2{0, 1}, Vec2{1, 0}},
+		{[9]float64{2, 1, 7, -1, 3, 7, 0, 0, 1}, Vec2{1, 2}, Vec2{2, -1}},
+	}
+
+	m := Mat3{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.TransformNormal(c.normal)
+		tangent := m.TransformDirection(c.tangent)
+		if !closeEq(get.Length(), 1, epsilon) || !closeEq(get.Dot(tangent), 0, epsilon) {
+			t.Errorf("TestTransformNormalMat3 %d %v %v", testIndex, get, tangent)
+		}
+	}
+}
+
+func TestTryInverseMat3(t *testing.T) {
+	cases := []struct {
+		m, want Mat3
+		ok      bool
+	}{
+		{Mat3Identity, Mat3Identity, true},
+		{Mat3{[9]float64{2, 0, 0, 0, 3, 0, 0, 0, 4}}, Mat3{[9]float64{0.5, 0, 0, 0, 0.3333333333333333, 0, 0, 0, 0.25}}, true},
+		{Mat3{[9]float64{1, 1, 1, 1, 1, 1, 1, 1, 1}}, Mat3{}, false},
+		{Mat3{}, Mat3{}, false},
+		// singular is judged relative to the entries, not by the determinant
+		{Mat3{[9]float64{1e-4, 0, 0, 0, 1e-4, 0, 0, 0, 1e-4}}, Mat3{[9]float64{1e4, 0, 0, 0, 1e4, 0, 0, 0, 1e4}}, true},
+		{Mat3{[9]float64{1e10, 2e10, 3e10, 4e10, 5e10, 6e10, 7e10, 8e10, 9e10}}, Mat3{}, false},
+	}
+
+	for testIndex, c := range cases {
+		got, ok := c.m.TryInverse()
+		if ok != c.ok {
+			t.Errorf("TestTryInverseMat3 %d ok %v", testIndex, ok)
+		}
+		for k := 0; k < 9; k++ {
+			if !closeEq(got.At(k), c.want.At(k), 1e-6) {
+				t.Errorf("TestTryInverseMat3 %d\n%v\n%v", testIndex, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMat3FromTo(t *testing.T) {
+	cases := []struct {
+		a, b Vec3
+	}{
+		{Vec3{1, 0, 0}, Vec3{0, 1, 0}},
+		{Vec3{1, 2, 3}, Vec3{-3, 0.5, 2}},
+		{Vec3{0, 1, 0}, Vec3{0, -1, 0}},
+	}
+
+	for testIndex, c := range cases {
+		m := Mat3FromTo(c.a, c.b)
+		got := m.MultVec3(c.a.Normalize())
+		if got.Sub(c.b.Normalize()).Length() > 1e-9 || !closeEq(m.Determinant(), 1, 1e-9) {
+			t.Errorf("TestMat3FromTo %d %v %v", testIndex, got, c.b)
+		}
+	}
+}
+
+func TestMat3LookRotation(t *testing.T) {
+	cases := []struct {
+		forward, up Vec3
+	}{
+		{Vec3Forward, Vec3Up},
+		{Vec3{1, 2, 3}, Vec3{0, 1, 0.5}},
+		{Vec3{0, -1, 0}, Vec3Up},
+		{Vec3{}, Vec3Up},
+		{Vec3{1e-10, 0, 0}, Vec3{0, 1e-10, 0}},
+	}
+
+	for testIndex, c := range cases {
+		m := Mat3LookRotation(c.forward, c.up)
+		if !closeEq(m.Determinant(), 1, 1e-9) || !m.Mult(m.Transpose()).ApproxEq(Mat3Identity, DefaultTolerance) {
+			t.Errorf("TestMat3LookRotation %d not a rotation\n%v", testIndex, m)
+		}
+		q := QuatLookRotation(c.forward, c.up)
+		if !q.Mat3().ApproxEq(m, DefaultTolerance) {
+			t.Errorf("TestMat3LookRotation %d\n%v\n%v", testIndex, m, q.Mat3())
+		}
+	}
+}
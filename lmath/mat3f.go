@@ -0,0 +1,648 @@
+package lmath
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	Mat3fIdentity = Mat3f{[9]float32{
+		1, 0, 0,
+		0, 1, 0,
+		0, 0, 1}}
+)
+
+// A float32 3x3 matrix with the same semantics as Mat3.
+// Matrices which are more involved to build (axis-angle, euler angles) can be
+// made with Mat3 and converted using Mat3.Mat3f.
+type Mat3f struct {
+	mat [9]float32
+}
+
+// New Mat3f with the given values.
+// Row-Order.
+func NewMat3f(
+	m11, m12, m13,
+	m21, m22, m23,
+	m31, m32, m33 float32) *Mat3f {
+
+	// 0   1   2   3
+	// 4   5   6   7
+	// 8   9   10  11
+	// 12  13  14  15
+	out := Mat3f{}
+	out.mat[0] = m11
+	out.mat[1] = m12
+	out.mat[2] = m13
+	out.mat[3] = m21
+	out.mat[4] = m22
+	out.mat[5] = m23
+	out.mat[6] = m31
+	out.mat[7] = m32
+	out.mat[8] = m33
+	return &out
+}
+
+// Load the matrix with 9 floats.
+// Specified in Row-Major order.
+func (this *Mat3f) Load(m [9]float32) *Mat3f {
+	this.mat = m
+	return this
+}
+
+// Retrieve a 9 float array of all the values of the matrix.
+// Returned in Row-Major order.
+func (this Mat3f) Dump() (m [9]float32) {
+	m = this.mat
+	return
+}
+
+// Retrieve a 9 float32 array of all the values of the matrix.
+// Returned in Col-Major order.
+func (this Mat3f) DumpOpenGL() (m [9]float32) {
+	m[0], m[1], m[2] = this.Col(0)
+	m[3], m[4], m[5] = this.Col(1)
+	m[6], m[7], m[8] = this.Col(2)
+	return
+}
+
+// Return a copy of this matrix.
+// Carbon-copy of all elements
+func (this Mat3f) Copy() Mat3f {
+	return this
+}
+
+// Compare this matrix to the other.
+// Return true if all elements between them are the same.
+// Equality is measured using an epsilon32 (< 0.00001).
+func (this Mat3f) Eq(other Mat3f) bool {
+	for k, _ := range this.mat {
+		if closeEq32(this.mat[k], other.mat[k], epsilon32) == false {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal if all elements are equal within the tolerance
+func (this Mat3f) ApproxEq(other Mat3f, tol Tolerance) bool {
+	for k := range this.mat {
+		if !tol.Eq32(this.mat[k], other.mat[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Retrieve the element at row and column.
+// 0 indexed.
+// Does not do any bounds checking.
+func (this Mat3f) Get(row, col int) float32 {
+	return this.mat[row*mat3Dim+col]
+}
+
+// Set the value at the specified column and row.
+// 0 indexed.
+// Does not do any bounds checking.
+func (this *Mat3f) Set(row, col int, value float32) *Mat3f {
+	this.mat[row*mat3Dim+col] = value
+	return this
+}
+
+// Retrieve the element at the given index assuming a linear array.
+// (i.e matrix[0], matrix[5]).
+// 0 indexed.
+func (this Mat3f) At(index int) float32 {
+	return this.mat[index]
+}
+
+// Set the element of the matrix specified at the index to the given value.
+// 0 indexed.
+// Return a pointer to the 'this'
+func (this *Mat3f) SetAt(index int, value float32) *Mat3f {
+	this.mat[index] = value
+	return this
+}
+
+// Set the specified row of the matrix to the given x,y,z values.
+// 0 indexed.
+// Does not do bounds checking of the row.
+func (this *Mat3f) SetRow(row int, x, y, z float32) *Mat3f {
+	this.mat[row*mat3Dim] = x
+	this.mat[row*mat3Dim+1] = y
+	this.mat[row*mat3Dim+2] = z
+	return this
+}
+
+// Set the specified column of the matrix to the given x,y,z values.
+// 0 indexed.
+// Does not do bounds checking on the col.
+func (this *Mat3f) SetCol(col int, x, y, z float32) *Mat3f {
+	this.mat[mat3Dim*0+col] = x
+	this.mat[mat3Dim*1+col] = y
+	this.mat[mat3Dim*2+col] = z
+	return this
+}
+
+// Retrieve the x,y,z elements from the specified row.
+// 0 indexed.
+// Does not bounds check the row.
+func (this Mat3f) Row(row int) (x, y, z float32) {
+	x = this.mat[row*mat3Dim]
+	y = this.mat[row*mat3Dim+1]
+	z = this.mat[row*mat3Dim+2]
+	return
+}
+
+// Retrieve the x,y,z elements from the specified column.
+// 0 indexed.
+// Does not bounds check the column.
+func (this Mat3f) Col(col int) (x, y, z float32) {
+	x = this.mat[mat3Dim*0+col]
+	y = this.mat[mat3Dim*1+col]
+	z = this.mat[mat3Dim*2+col]
+	return
+}
+
+// Add in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+func (this Mat3f) AddScalar(val float32) Mat3f {
+	this.AddInScalar(val)
+	return this
+}
+
+// Add in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+func (this *Mat3f) AddInScalar(val float32) *Mat3f {
+	for k, _ := range this.mat {
+		this.mat[k] += val
+	}
+	return this
+}
+
+// Subtract in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+func (this Mat3f) SubScalar(val float32) Mat3f {
+	this.SubInScalar(val)
+	return this
+}
+
+// Subtract in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+func (this *Mat3f) SubInScalar(val float32) *Mat3f {
+	for k, _ := range this.mat {
+		this.mat[k] -= val
+	}
+	return this
+}
+
+// Multiplies in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+func (this Mat3f) MultScalar(val float32) Mat3f {
+	this.MultInScalar(val)
+	return this
+}
+
+// Multiplies in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+func (this *Mat3f) MultInScalar(val float32) *Mat3f {
+	for k, _ := range this.mat {
+		this.mat[k] *= val
+	}
+	return this
+}
+
+// Divides in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+//
+//	precondition: val > 0
+func (this Mat3f) DivScalar(val float32) Mat3f {
+	this.DivInScalar(val)
+	return this
+}
+
+// Divides in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+//
+//	precondition: val > 0
+func (this *Mat3f) DivInScalar(val float32) *Mat3f {
+	for k, _ := range this.mat {
+		this.mat[k] /= val
+	}
+	return this
+}
+
+// Adds the two matrices together ( ie.  this + other).
+// Return a new matrix with the result.
+func (this Mat3f) Add(other Mat3f) Mat3f {
+	this.AddIn(other)
+	return this
+}
+
+// Adds the two matrices together ( ie.  this + other).
+// Stores the result in this.
+// Returns this.
+func (this *Mat3f) AddIn(other Mat3f) *Mat3f {
+	for k, _ := range this.mat {
+		this.mat[k] += other.mat[k]
+	}
+	return this
+}
+
+// Subtract the two matrices together ( ie.  this - other).
+// Return a new matrix with the result.
+func (this Mat3f) Sub(other Mat3f) Mat3f {
+	this.SubIn(other)
+	return this
+}
+
+// Subtract the two matrices together ( ie.  this - other).
+// Stores the result in this.
+// Returns this.
+func (this *Mat3f) SubIn(other Mat3f) *Mat3f {
+	for k, _ := range this.mat {
+		this.mat[k] -= other.mat[k]
+	}
+	return this
+}
+
+// Multiply the two matrices together ( ie.  this * other).
+// Return a new matrix with the result.
+func (this Mat3f) Mult(other Mat3f) Mat3f {
+	this.MultIn(other)
+	return this
+}
+
+// Multiplies the two matrices together ( ie.  this * other).
+// Stores the result in this.
+// Returns this.
+func (this *Mat3f) MultIn(o Mat3f) *Mat3f {
+	// 0   1   2
+	// 3   4   5
+	// 6   7   8
+	m := *this
+	this.mat[0] = m.mat[0]*o.mat[0] + m.mat[1]*o.mat[3] + m.mat[2]*o.mat[6]
+	this.mat[1] = m.mat[0]*o.mat[1] + m.mat[1]*o.mat[4] + m.mat[2]*o.mat[7]
+	this.mat[2] = m.mat[0]*o.mat[2] + m.mat[1]*o.mat[5] + m.mat[2]*o.mat[8]
+
+	this.mat[3] = m.mat[3]*o.mat[0] + m.mat[4]*o.mat[3] + m.mat[5]*o.mat[6]
+	this.mat[4] = m.mat[3]*o.mat[1] + m.mat[4]*o.mat[4] + m.mat[5]*o.mat[7]
+	this.mat[5] = m.mat[3]*o.mat[2] + m.mat[4]*o.mat[5] + m.mat[5]*o.mat[8]
+
+	this.mat[6] = m.mat[6]*o.mat[0] + m.mat[7]*o.mat[3] + m.mat[8]*o.mat[6]
+	this.mat[7] = m.mat[6]*o.mat[1] + m.mat[7]*o.mat[4] + m.mat[8]*o.mat[7]
+	this.mat[8] = m.mat[6]*o.mat[2] + m.mat[7]*o.mat[5] + m.mat[8]*o.mat[8]
+
+	return this
+}
+
+// Returns a new matrix which is transpose to this.
+func (this Mat3f) Transpose() Mat3f {
+	this.TransposeIn()
+	return this
+}
+
+// Take the transpose of this matrix.
+func (this *Mat3f) TransposeIn() *Mat3f {
+	// TODO: can definitely be way more efficient
+	// by only exchanging the column entries.
+	m00, m01, m02 := this.Row(0)
+	m10, m11, m12 := this.Row(1)
+	m20, m21, m22 := this.Row(2)
+
+	this.SetCol(0, m00, m01, m02)
+	this.SetCol(1, m10, m11, m12)
+	this.SetCol(2, m20, m21, m22)
+
+	return this
+}
+
+// Get the determinant of the matrix.
+// Uses a straight-up Cramers-Rule implementation.
+func (this Mat3f) Determinant() float32 {
+	// 0   1   2
+	// 3   4   5
+	// 6   7   8
+
+	// Use Cramer's rule to calculate the determinant
+	return det3x3f(
+		this.mat[0], this.mat[1], this.mat[2],
+		this.mat[3], this.mat[4], this.mat[5],
+		this.mat[6], this.mat[7], this.mat[8])
+}
+
+// Returns a new matrix which is the Adjoint matrix of this.
+func (this Mat3f) Adjoint() Mat3f {
+	a1, a2, a3 := this.mat[0], this.mat[1], this.mat[2]
+	b1, b2, b3 := this.mat[3], this.mat[4], this.mat[5]
+	c1, c2, c3 := this.mat[6], this.mat[7], this.mat[8]
+
+	// 0 1 2 3          a1 a2 a3 a4
+	// 4 5 6 7          b1 b2 b3 b4
+	// 8 9 10 11        c1 c2 c3 c4
+	// 12 13 14 15      d1 d2 d3 d4
+
+	// 0 1 2          a1 a2 a3
+	// 3 4 5          b1 b2 b3
+	// 6 7 8          c1 c2 c3
+	this.mat[0] = det2x2f(b2, b3, c2, c3)
+	this.mat[1] = -det2x2f(b1, b3, c1, c3)
+	this.mat[2] = det2x2f(b1, b2, c1, c2)
+
+	this.mat[3] = -det2x2f(a2, a3, c2, c3)
+	this.mat[4] = det2x2f(a1, a3, c1, c3)
+	this.mat[5] = -det2x2f(a1, a2, c1, c2)
+
+	this.mat[6] = det2x2f(a2, a3, b2, b3)
+	this.mat[7] = -det2x2f(a1, a3, b1, b3)
+	this.mat[8] = det2x2f(a1, a2, b1, b2)
+
+	this.TransposeIn()
+	return this
+}
+
+// Returns a new matrix which is the inverse matrix of this.
+// The bool flag is false if an inverse does not exist.
+func (this Mat3f) Inverse() Mat3f {
+	// TODO: Needs further testing
+	// Try out with rotation matrices.
+	// The inverse of a valid rotation matrix should just be the transpose
+	det := this.Determinant()
+	return this.Adjoint().DivScalar(det)
+}
+
+// Returns true if the inverse of this matrix exists false otherwise.
+// Internally it checks to see if the determinant is zero.
+func (this Mat3f) HasInverse() bool {
+	return !closeEq32(this.Determinant(), 0, epsilon32)
+}
+
+// Return the inverse of the matrix and true, or the zero matrix and false when
+// the matrix is singular or the inverse is not finite. Unlike HasInverse the
+// singular check is relative to the size of the entries, see Mat3.LU.
+func (this Mat3f) TryInverse() (Mat3f, bool) {
+	a := this.Mat3().linalg()
+	if _, ok := luDecompose(&a, mat3Dim, epsilon32); !ok {
+		return Mat3f{}, false
+	}
+	out := this.Inverse()
+	if !out.IsFinite() {
+		return Mat3f{}, false
+	}
+	return out, true
+}
+
+// Sets the matrix to the identity matrix.
+func (this *Mat3f) ToIdentity() *Mat3f {
+	this.mat = [9]float32{
+		1, 0, 0,
+		0, 1, 0,
+		0, 0, 1,
+	}
+	return this
+}
+
+// Return true if no element is NaN or infinite.
+func (this Mat3f) IsFinite() bool {
+	for _, v := range this.mat {
+		if !isFinite(float64(v)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if any element is NaN.
+func (this Mat3f) HasNaN() bool {
+	for _, v := range this.mat {
+		if math.IsNaN(float64(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Implement the Stringer interface
+// Prints out each row of the matrix on its own line
+func (this Mat3f) String() string {
+	return fmt.Sprintf("%f %f %f\n%f %f %f\n%f %f %f",
+		this.mat[0], this.mat[1], this.mat[2],
+		this.mat[3], this.mat[4], this.mat[5],
+		this.mat[6], this.mat[7], this.mat[8])
+}
+
+// =============================================================================
+// Create a 2D translation matrix for Mat3f. Overwrites all values in the matrix.
+func (this *Mat3f) ToTranslate(x, y float32) *Mat3f {
+	this.ToIdentity()
+	this.Set(0, 2, x)
+	this.Set(1, 2, y)
+	return this
+}
+
+// Create a 2D scaling matrix for Mat3f. Overwrites all values in the matrix.
+func (this *Mat3f) ToScale(x, y float32) *Mat3f {
+	this.ToIdentity()
+	this.Set(0, 0, x)
+	this.Set(1, 1, y)
+	return this
+}
+
+// Create a 2D shearing matrix for Mat3f. Overwrites all values in the matrix.
+//
+//	0 x 0
+//	y 0 0
+//	0 0 1
+func (this *Mat3f) ToShear(x, y float32) *Mat3f {
+	this.ToIdentity()
+	this.Set(0, 0, 0)
+	this.Set(1, 1, 0)
+
+	this.Set(0, 1, x)
+	this.Set(1, 0, y)
+	return this
+}
+
+// Create a 2D rotation matrix about the Z axis
+// cos  -sin   0
+// sin   cos     0
+// 0     0     1
+func (this *Mat3f) ToRotateZ(angle float32) *Mat3f {
+	this.ToIdentity()
+	this.Set(0, 0, cos32(angle))
+	this.Set(0, 1, -sin32(angle))
+	this.Set(1, 0, sin32(angle))
+	this.Set(1, 1, cos32(angle))
+	return this
+}
+
+// Multiplies the Vec3f against the matrix ( ie. result = Matrix * Vec).
+// Returns a new vector with the result.
+func (this Mat3f) MultVec3(v Vec3f) (out Vec3f) {
+	// 0   1   2
+	// 3   4   5
+	// 6   7   8
+	out.Set(
+		this.mat[0]*v.X+this.mat[1]*v.Y+this.mat[2]*v.Z,
+		this.mat[3]*v.X+this.mat[4]*v.Y+this.mat[5]*v.Z,
+		this.mat[6]*v.X+this.mat[7]*v.Y+this.mat[8]*v.Z,
+	)
+	return
+}
+
+// Multiplies the Vec2f against the matrix treating it as a point [x,y,1]
+// ( ie. result = Matrix * Vec). Translation is applied.
+// Returns a new vector with the result.
+func (this Mat3f) MultVec2(v Vec2f) (out Vec2f) {
+	// 0   1   2
+	// 3   4   5
+	// 6   7   8
+	out.Set(
+		this.mat[0]*v.X+this.mat[1]*v.Y+this.mat[2],
+		this.mat[3]*v.X+this.mat[4]*v.Y+this.mat[5],
+	)
+	return
+}
+
+// Multiplies the Vec2f against the matrix treating it as a direction [x,y,0]
+// ( ie. result = Matrix * Vec). Translation is not applied.
+// Returns a new vector with the result.
+func (this Mat3f) MultVec2Dir(v Vec2f) (out Vec2f) {
+	out.Set(
+		this.mat[0]*v.X+this.mat[1]*v.Y,
+		this.mat[3]*v.X+this.mat[4]*v.Y,
+	)
+	return
+}
+
+// Transform the 2D point by the matrix, treating it as [x,y,1].
+// Translation is applied and the result is divided by the third component,
+// so this is correct for 2D projective matrices as well as affine ones.
+// Returns a new vector with the result.
+func (this Mat3f) TransformPoint(p Vec2f) Vec2f {
+	v := this.MultVec3(Vec3f{p.X, p.Y, 1})
+	if v.Z != 1 {
+		return Vec2f{v.X / v.Z, v.Y / v.Z}
+	}
+	return Vec2f{v.X, v.Y}
+}
+
+// Transform the 2D direction by the matrix, treating it as [x,y,0].
+// Only the upper 2x2 is applied, translation is ignored.
+// Returns a new vector with the result.
+func (this Mat3f) TransformDirection(d Vec2f) Vec2f {
+	return this.MultVec2Dir(d)
+}
+
+// Creates a rotation matrix from the given quaternion. Return this
+func (this *Mat3f) FromQuat(q *Quatf) *Mat3f {
+	*this = q.Mat3f()
+	return this
+}
+
+// Returns the quaternion represented by this rotation matrix.
+func (this Mat3f) Quatf() Quatf {
+	q := Quatf{}
+	q.FromMat3(this)
+	return q
+}
+
+// Set this as a rotation matrix about the axis [x,y,z] with the given angle
+// (radians). See Mat3.FromAxisAngle
+func (this *Mat3f) FromAxisAngle(angle, x, y, z float32) *Mat3f {
+	m := Mat3{}
+	m.FromAxisAngle(float64(angle), float64(x), float64(y), float64(z))
+	*this = m.Mat3f()
+	return this
+}
+
+// Return the angle (radians) and axis of this rotation matrix.
+// See Mat3.AxisAngle
+func (this Mat3f) AxisAngle() (angle, x, y, z float32) {
+	a, x64, y64, z64 := mat3AxisAngle(this.Mat3(), epsilon32)
+	return float32(a), float32(x64), float32(y64), float32(z64)
+}
+
+// Set this as a rotation matrix using the specified pitch, yaw and roll
+// (radians). See Mat3.FromEuler
+func (this *Mat3f) FromEuler(pitch, yaw, roll float32) *Mat3f {
+	m := Mat3{}
+	m.FromEuler(float64(pitch), float64(yaw), float64(roll))
+	*this = m.Mat3f()
+	return this
+}
+
+// Return the pitch, yaw and roll euler angles (radians) of the rotation.
+// See Mat3.Euler
+func (this Mat3f) Euler() (pitch, yaw, roll float32) {
+	p, y, r := this.Mat3().Euler()
+	return float32(p), float32(y), float32(r)
+}
+
+// Set this as the rotation from the euler angles (radians) applied in the
+// given order. See Mat3.FromEulerOrder
+func (this *Mat3f) FromEulerOrder(order EulerOrder, a, b, c float32) *Mat3f {
+	m := Mat3{}
+	m.FromEulerOrder(order, float64(a), float64(b), float64(c))
+	*this = m.Mat3f()
+	return this
+}
+
+// Return the euler angles (radians) of the rotation in the given order.
+// See Mat3.EulerOrder
+func (this Mat3f) EulerOrder(order EulerOrder) (a, b, c float32) {
+	a64, b64, c64 := this.Mat3().EulerOrder(order)
+	return float32(a64), float32(b64), float32(c64)
+}
+
+// Return true if the matrix is the identity matrix. See Mat3.IsIdentity
+func (this Mat3f) IsIdentity() bool {
+	return this.Mat3().IsIdentity()
+}
+
+// Check to see if the matrix is a valid rotation matrix. See Mat3.IsRotation
+func (this Mat3f) IsRotation() bool {
+	return this.Mat3().IsRotation()
+}
+
+// Return the upper 2x2 matrix. There is no float32 Mat2 so the float64 Mat2
+// is used.
+func (this Mat3f) UpperMat2() Mat2 {
+	return this.Mat3().UpperMat2()
+}
+
+// Set the upper 2x2 matrix. Return this
+func (this *Mat3f) SetUpperMat2(m Mat2) *Mat3f {
+	m3 := this.Mat3()
+	m3.SetUpperMat2(m)
+	*this = m3.Mat3f()
+	return this
+}
+
+// Transform the 2D normal by the inverse transpose of the upper 2x2 and
+// normalize the result. See Mat3.TransformNormal
+func (this Mat3f) TransformNormal(n Vec2f) Vec2f {
+	return this.Mat3().TransformNormal(n.Vec2()).Vec2f()
+}
+
+// Load the matrix with 9 float64 values.
+// Specified in Row-Major order.
+func (this *Mat3f) Load64(m [9]float64) *Mat3f {
+	for k, v := range m {
+		this.mat[k] = float32(v)
+	}
+	return this
+}
+
+// convert to the float64 Mat3
+func (this Mat3f) Mat3() (out Mat3) {
+	for k, v := range this.mat {
+		out.mat[k] = float64(v)
+	}
+	return
+}
+
+// convert to the float32 Mat3f
+func (this Mat3) Mat3f() (out Mat3f) {
+	return *out.Load64(this.mat)
+}
@@ -0,0 +1,1085 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+// Test creation with 16 values
+// Test Equals with matrices
+func TestNewMat3f(t *testing.T) {
+	m := NewMat3f(
+		1, 2, 3,
+		5, 6, 7,
+		9, 10, 11)
+	m2 := NewMat3f(
+		1, 2, 3,
+		5, 6, 7,
+		9, 10, 11)
+	if m.Eq(*m2) == false {
+		t.Errorf("TestNewMat3f ")
+	}
+}
+
+// Test Get
+// Test Set
+func TestGetterSetterMat3f(t *testing.T) {
+	cases := []struct {
+		orig          *Mat3f
+		x, y          int
+		wantBeforeSet float32
+		wantAfterSet  float32
+	}{
+		{&Mat3f{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}}, 0, 0, 1, 10},
+		{&Mat3f{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}}, 1, 0, 2, 20},
+		{&Mat3f{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}}, 2, 0, 3, 30},
+
+		{&Mat3f{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}}, 0, 1, 4, 40},
+		{&Mat3f{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}}, 1, 1, 5, 50},
+		{&Mat3f{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}}, 2, 1, 6, 60},
+
+		{&Mat3f{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}}, 0, 2, 7, 70},
+		{&Mat3f{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}}, 1, 2, 8, 80},
+		{&Mat3f{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}}, 2, 2, 9, 90},
+	}
+
+	for testIndex, c := range cases {
+		get := c.orig.Get(c.y, c.x)
+		if get != c.wantBeforeSet {
+			t.Errorf("TestGetterSetterMat3f wantBeforeSet %d %v", testIndex, get)
+		}
+
+		get = c.orig.Set(c.y, c.x, c.wantAfterSet).Get(c.y, c.x)
+		if get != c.wantAfterSet {
+			t.Errorf("TestGetterSetterMat3f wantAfterSet %d %v", testIndex, get)
+		}
+	}
+
+	orig := &Mat3f{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}}
+	for k, _ := range orig.Dump() {
+		get := orig.At(k)
+		if get != float32(k+1) {
+			t.Errorf("TestGetterSetterMat3f At %d %v", k, get)
+		}
+
+		orig.SetAt(k, float32((k+1)*10))
+		get = orig.At(k)
+		if get != float32((k+1)*10) {
+			t.Errorf("TestGetterSetterMat3f SetAt %d", k)
+		}
+	}
+}
+
+// Test Load Array
+// Test Dump
+// Test Dump
+func TestLoadDumpMat3f(t *testing.T) {
+	cases := []struct {
+		loadArray [9]float32
+	}{
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{[9]float32{-1, -2, -3, -4, -5, -6, -7, -8, -9}},
+	}
+
+	m := &Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.loadArray)
+		get := m.Dump()
+
+		for k, _ := range get {
+			if get[k] != c.loadArray[k] {
+				t.Errorf("TestLoadDumpMat3f %d", testIndex)
+				break
+			}
+		}
+	}
+
+	// 1 2 3
+	// 4 5 6
+	// 7 8 9
+	m.Load([9]float32{1, 4, 7, 2, 5, 8, 3, 6, 9})
+	get := m.DumpOpenGL()
+	for k, _ := range get {
+		if !closeEq32(get[k], cases[0].loadArray[k], epsilon32) {
+			t.Errorf("TestDumpOpenGLMat3f")
+			break
+		}
+	}
+
+}
+
+func TestRowMat3f(t *testing.T) {
+	cases := []struct {
+		orig     [9]float32
+		rowIndex int
+		want     [3]float32
+	}{
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 0, [3]float32{1, 2, 3}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 1, [3]float32{4, 5, 6}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 2, [3]float32{7, 8, 9}},
+	}
+
+	m := &Mat3f{}
+	var x, y, z float32
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		x, y, z = m.Row(c.rowIndex)
+		if x != c.want[0] || y != c.want[1] || z != c.want[2] {
+			t.Errorf("TestRowMat3f %d", testIndex)
+		}
+	}
+}
+
+func TestSetRowMat3f(t *testing.T) {
+	cases := []struct {
+		orig     [9]float32
+		rowIndex int
+		x, y, z  float32
+	}{
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 0, -1, -2, -3},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 1, -4, -5, -6},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 2, -7, -8, -9},
+	}
+
+	m := &Mat3f{}
+	var x, y, z float32
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		m.SetRow(c.rowIndex, c.x, c.y, c.z)
+		x, y, z = m.Row(c.rowIndex)
+		if x != c.x || y != c.y || z != c.z {
+			t.Errorf("TestSetRowMat3f %d", testIndex)
+		}
+	}
+}
+
+func TestColMat3f(t *testing.T) {
+	cases := []struct {
+		orig     [9]float32
+		colIndex int
+		want     [3]float32
+	}{
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 0, [3]float32{1, 4, 7}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 1, [3]float32{2, 5, 8}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 2, [3]float32{3, 6, 9}},
+	}
+
+	m := &Mat3f{}
+	var x, y, z float32
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		x, y, z = m.Col(c.colIndex)
+		if x != c.want[0] || y != c.want[1] || z != c.want[2] {
+			t.Errorf("TestColMat3f %d", testIndex)
+		}
+	}
+}
+
+func TestSetColMat3f(t *testing.T) {
+	cases := []struct {
+		orig     [9]float32
+		colIndex int
+		x, y, z  float32
+	}{
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 0, -1, -2, -3},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 1, -5, -6, -7},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 2, -9, -10, -11},
+	}
+
+	m := &Mat3f{}
+	var x, y, z float32
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		m.SetCol(c.colIndex, c.x, c.y, c.z)
+		x, y, z = m.Col(c.colIndex)
+		if x != c.x || y != c.y || z != c.z {
+			t.Errorf("TestSetColMat3f %d", testIndex)
+		}
+	}
+}
+
+func TestAddScalarMat3f(t *testing.T) {
+	cases := []struct {
+		orig  [9]float32
+		value float32
+	}{
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, 0},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, 1},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, -1},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 0},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 1},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, -1},
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		ret_mat := m.AddScalar(c.value)
+
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]+c.value {
+				t.Errorf("TestAddScalarMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.AddInScalar(c.value)
+		if ret_mat2 != &m {
+			t.Errorf("TestAddInScalarMat3f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]+c.value {
+				t.Errorf("TestAddInScalarMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestSubScalarMat3f(t *testing.T) {
+	cases := []struct {
+		orig  [9]float32
+		value float32
+	}{
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, 0},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, 1},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, -1},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 0},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 1},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, -1},
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		ret_mat := m.SubScalar(c.value)
+
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]-c.value {
+				t.Errorf("TestSubScalarMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.SubInScalar(c.value)
+		if ret_mat2 != &m {
+			t.Errorf("TestSubInScalarMat3f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]-c.value {
+				t.Errorf("TestSubInScalarMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestMultScalarMat3f(t *testing.T) {
+	cases := []struct {
+		orig  [9]float32
+		value float32
+	}{
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, 0},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, 1},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, -1},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 0},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 1},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, -1},
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		ret_mat := m.MultScalar(c.value)
+
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]*c.value {
+				t.Errorf("TestMultScalarMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.MultInScalar(c.value)
+		if ret_mat2 != &m {
+			t.Errorf("TestMultInScalarMat3f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]*c.value {
+				t.Errorf("TestMultInScalarMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestDivScalarMat3f(t *testing.T) {
+	cases := []struct {
+		orig  [9]float32
+		value float32
+	}{
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, 1},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, -1},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 1},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, -1},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, 2},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, -2},
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		ret_mat := m.DivScalar(c.value)
+
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]/c.value {
+				t.Errorf("TestDivScalarMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.DivInScalar(c.value)
+		if ret_mat2 != &m {
+			t.Errorf("TestDivInScalarMat3f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]/c.value {
+				t.Errorf("TestDivInScalarMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestAddMat3f(t *testing.T) {
+	cases := []struct {
+		orig, other [9]float32
+	}{
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{-1, -2, -3, -4, -5, -6, -7, -8, -9}},
+	}
+
+	m := Mat3f{}
+	m2 := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		m2.Load(c.other)
+
+		ret_mat := m.Add(m2)
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if closeEq32(get[k], c.orig[k]+c.other[k], epsilon32) == false {
+				t.Errorf("TestAddMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.AddIn(m2)
+		if ret_mat2 != &m {
+			t.Errorf("TestAddInMat3f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if closeEq32(get[k], c.orig[k]+c.other[k], epsilon32) == false {
+				t.Errorf("TestAddInMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestSubMat3f(t *testing.T) {
+	cases := []struct {
+		orig, other [9]float32
+	}{
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{-1, -2, -3, -4, -5, -6, -7, -8, -9}},
+	}
+
+	m := Mat3f{}
+	m2 := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		m2.Load(c.other)
+
+		ret_mat := m.Sub(m2)
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if closeEq32(get[k], c.orig[k]-c.other[k], epsilon32) == false {
+				t.Errorf("TestSubMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.SubIn(m2)
+		if ret_mat2 != &m {
+			t.Errorf("TestSubInMat3f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if closeEq32(get[k], c.orig[k]-c.other[k], epsilon32) == false {
+				t.Errorf("TestSubInMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestMultMat3f(t *testing.T) {
+	cases := []struct {
+		orig, other, want [9]float32
+	}{
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}},
+
+		{[9]float32{1, 0, 0, 0, 1, 0, 0, 0, 1},
+			[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{1, 0, 0, 0, 1, 0, 0, 0, 1},
+			[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{30, 36, 42, 66, 81, 96, 102, 126, 150}},
+
+		{[9]float32{5, 6, 7, 8, 1, 2, 3, 4, 13},
+			[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{78, 96, 114, 26, 37, 48, 110, 130, 150}},
+
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{5, 6, 7, 8, 1, 2, 3, 4, 13},
+			[9]float32{30, 20, 50, 78, 53, 116, 126, 86, 182}},
+	}
+
+	orig := Mat3f{}
+	other := Mat3f{}
+	for testIndex, c := range cases {
+		orig.Load(c.orig)
+		other.Load(c.other)
+
+		ret_mat := orig.Mult(other)
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if closeEq32(get[k], c.want[k], epsilon32) == false {
+				t.Errorf("TestMultMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := orig.MultIn(other)
+		if ret_mat2 != &orig {
+			t.Errorf("TestMultInMat3f %d", testIndex)
+		}
+	}
+}
+
+func TestIdentityMat3f(t *testing.T) {
+	m := &Mat3f{}
+	m.Load([9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	m.ToIdentity()
+
+	get := m.Dump()
+	want := [9]float32{1, 0, 0, 0, 1, 0, 0, 0, 1}
+	for k, _ := range get {
+		if want[k] != get[k] {
+			t.Errorf("TestIdentity %d", k)
+			break
+		}
+	}
+}
+
+func TestTransposeMat3f(t *testing.T) {
+	cases := []struct {
+		orig, want [9]float32
+	}{
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}},
+
+		{[9]float32{1, 0, 0, 0, 1, 0, 0, 0, 1},
+			[9]float32{1, 0, 0, 0, 1, 0, 0, 0, 1}},
+
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[9]float32{1, 4, 7, 2, 5, 8, 3, 6, 9}},
+	}
+
+	orig := &Mat3f{}
+	for testIndex, c := range cases {
+		orig.Load(c.orig)
+		get := orig.Transpose().Dump()
+
+		for k, _ := range c.want {
+			if closeEq32(get[k], c.want[k], epsilon32) == false {
+				t.Errorf("TestTransposeMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		orig.Load(c.orig)
+		get = orig.TransposeIn().Dump()
+		for k, _ := range c.want {
+			if closeEq32(get[k], c.want[k], epsilon32) == false {
+				t.Errorf("TestTransposeInMat3f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestDeterminantMat3f(t *testing.T) {
+	want := float32(0.554020973727016224)
+	m := &Mat3f{}
+	m.Load([9]float32{
+		0.5 * 0.5,
+		-0.5 * 0.866,
+		0.866,
+
+		0.866*0.866*0.866 + 0.5*0.5,
+		-0.866*0.866*0.866 + 0.5*0.5,
+		0.866 * 0.5,
+
+		-0.5*0.866*0.5 + 0.866*0.866,
+		0.5*0.866*0.866 + 0.866*0.5,
+		-0.5 * 0.5})
+
+	get := m.Determinant()
+	if closeEq32(get, want, epsilon32) == false {
+		t.Errorf("TestDeterminantMat3f %v", get)
+	}
+}
+
+func TestAdjointMat3f(t *testing.T) {
+	m := &Mat3f{}
+	m.Load([9]float32{
+		0.5 * 0.5,
+		-0.5 * 0.866,
+		0.866,
+
+		0.866*0.866*0.866 + 0.5*0.5,
+		-0.866*0.866*0.866 + 0.5*0.5,
+		0.866 * 0.5,
+
+		-0.5*0.866*0.5 + 0.866*0.866,
+		0.5*0.866*0.866 + 0.866*0.5,
+		-0.5 * 0.5})
+
+	m2 := m.Adjoint()
+	get := m2.Dump()
+	want := [9]float32{
+		-0.249989, 0.591459, 0.158445,
+		0.455852, -0.524473, 0.670684,
+		0.939841, -0.432981, 0.289602}
+
+	for k, _ := range want {
+		// NOTE: the check uses lower precision because
+		// the adjoint values I got from wolframalpha
+		// only went up to 6 places
+		if closeEq32(get[k], want[k], 0.0001) == false {
+			t.Errorf("TestAdjointMat3f %d %v %v", k, get[k], want[k])
+			break
+		}
+	}
+}
+
+func TestInverseMat3f(t *testing.T) {
+	m := &Mat3f{}
+	cases := []struct {
+		orig, want        [9]float32
+		want_inverse_flag bool
+	}{
+		{[9]float32{
+			0.5 * 0.5,
+			-0.5 * 0.866,
+			0.866,
+
+			0.866*0.866*0.866 + 0.5*0.5,
+			-0.866*0.866*0.866 + 0.5*0.5,
+			0.866 * 0.5,
+
+			-0.5*0.866*0.5 + 0.866*0.866,
+			0.5*0.866*0.866 + 0.866*0.5,
+			-0.5 * 0.5}, [9]float32{
+			-0.451227, 1.06758, 0.285991,
+			0.822806, -0.946666, 1.21058,
+			1.6964, -0.781524, 0.522727}, true},
+		{[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}, false},
+		{[9]float32{1, 0, 0, 0, 1, 0, 0, 0, 1},
+			[9]float32{1, 0, 0, 0, 1, 0, 0, 0, 1}, true},
+		{[9]float32{
+			cos32(math.Pi / 2), -sin32(math.Pi / 2), 0,
+			sin32(math.Pi / 2), cos32(math.Pi / 2), 0,
+			0, 0, 1},
+			[9]float32{
+				cos32(math.Pi / 2), sin32(math.Pi / 2), 0,
+				-sin32(math.Pi / 2), cos32(math.Pi / 2), 0,
+				0, 0, 1}, true},
+	}
+
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		get_inverse_flag := m.HasInverse()
+		if get_inverse_flag != c.want_inverse_flag {
+			t.Errorf("TestInverseMat3f %d %v", testIndex, get_inverse_flag)
+			continue
+		}
+		if get_inverse_flag == false {
+			continue
+		}
+
+		m2 := m.Inverse()
+		get := m2.Dump()
+		for k, _ := range c.want {
+			if closeEq32(get[k], c.want[k], 0.0001) == false {
+				t.Errorf("TestInverseMat3f %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+	}
+}
+
+func TestMultVec3Mat3f(t *testing.T) {
+	cases := []struct {
+		orig_mat     [9]float32
+		orig_v, want Vec3f
+	}{
+		{[9]float32{1, 0, 0, 0, 1, 0, 0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{[9]float32{2, 0, 0, 0, 2, 0, 0, 0, 2}, Vec3f{1, 0, 0}, Vec3f{2, 0, 0}},
+		{[9]float32{2, 0, 0, 0, 2, 0, 0, 0, 2}, Vec3f{1, 1, 1}, Vec3f{2, 2, 2}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, Vec3f{1, 0, 0}, Vec3f{1, 4, 7}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, Vec3f{1, 2, 3}, Vec3f{14, 32, 50}},
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.MultVec3(c.orig_v)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestMultVec3Mat3f %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+	}
+}
+
+func TestMultVec2Mat3f(t *testing.T) {
+	cases := []struct {
+		orig_mat            [9]float32
+		orig_v, want, wantD Vec2f
+	}{
+		{[9]float32{1, 0, 0, 0, 1, 0, 0, 0, 1}, Vec2f{1, 0}, Vec2f{1, 0}, Vec2f{1, 0}},
+		{[9]float32{2, 0, 0, 0, 2, 0, 0, 0, 1}, Vec2f{1, 1}, Vec2f{2, 2}, Vec2f{2, 2}},
+		{[9]float32{1, 0, 3, 0, 1, -4, 0, 0, 1}, Vec2f{1, 1}, Vec2f{4, -3}, Vec2f{1, 1}},
+		{[9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}, Vec2f{1, 2}, Vec2f{8, 20}, Vec2f{5, 14}},
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.MultVec2(c.orig_v)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestMultVec2Mat3f %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+		get = m.MultVec2Dir(c.orig_v)
+		if get.Eq(c.wantD) == false {
+			t.Errorf("TestMultVec2DirMat3f %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+	}
+
+	// The 2D transform helpers should compose with MultVec2
+	m.ToRotateZ(float32(Radians(float64(90))))
+	if get := m.MultVec2(Vec2f{1, 0}); !get.Eq(Vec2f{0, 1}) {
+		t.Errorf("TestMultVec2Mat3f rotate %v", get)
+	}
+	m.ToTranslate(2, 3)
+	if get := m.MultVec2(Vec2f{1, 1}); !get.Eq(Vec2f{3, 4}) {
+		t.Errorf("TestMultVec2Mat3f translate %v", get)
+	}
+	if get := m.MultVec2Dir(Vec2f{1, 1}); !get.Eq(Vec2f{1, 1}) {
+		t.Errorf("TestMultVec2DirMat3f translate %v", get)
+	}
+	m.ToScale(2, -1)
+	if get := m.MultVec2(Vec2f{1, 1}); !get.Eq(Vec2f{2, -1}) {
+		t.Errorf("TestMultVec2Mat3f scale %v", get)
+	}
+}
+
+func TestTransformPointMat3f(t *testing.T) {
+	cases := []struct {
+		orig_mat     [9]float32
+		orig_v, want Vec2f
+	}{
+		{[9]float32{1, 0, 2, 0, 1, 3, 0, 0, 1}, Vec2f{1, 1}, Vec2f{3, 4}},
+		{[9]float32{2, 0, 0, 0, 3, 0, 0, 0, 1}, Vec2f{1, 1}, Vec2f{2, 3}},
+		// projective, divided by the third component
+		{[9]float32{1, 0, 0, 0, 1, 0, 1, 0, 1}, Vec2f{1, 4}, Vec2f{0.5, 2}},
+		{[9]float32{2, 0, 0, 0, 2, 0, 0, 0, 2}, Vec2f{3, 4}, Vec2f{3, 4}},
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.TransformPoint(c.orig_v)
+		if get.Sub(c.want).Length() > 1e-9 {
+			t.Errorf("TestTransformPointMat3f %d %v %v", testIndex, get, c.want)
+		}
+	}
+}
+
+func TestTransformDirectionMat3f(t *testing.T) {
+	cases := []struct {
+		orig_mat     [9]float32
+		orig_v, want Vec2f
+	}{
+		{[9]float32{1, 0, 2, 0, 1, 3, 0, 0, 1}, Vec2f{1, 1}, Vec2f{1, 1}},
+		{[9]float32{2, 0, 5, 0, 3, 5, 0, 0, 1}, Vec2f{1, 1}, Vec2f{2, 3}},
+		{[9]float32{0, -1, 5, 1, 0, 5, 0, 0, 1}, Vec2f{1, 0}, Vec2f{0, 1}},
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.TransformDirection(c.orig_v)
+		if get.Sub(c.want).Length() > 1e-9 {
+			t.Errorf("TestTransformDirectionMat3f %d %v %v", testIndex, get, c.want)
+		}
+	}
+}
+
+func TestTryInverseMat3f(t *testing.T) {
+	cases := []struct {
+		m, want Mat3f
+		ok      bool
+	}{
+		{Mat3fIdentity, Mat3fIdentity, true},
+		{Mat3f{[9]float32{2, 0, 0, 0, 3, 0, 0, 0, 4}}, Mat3f{[9]float32{0.5, 0, 0, 0, 0.3333333333333333, 0, 0, 0, 0.25}}, true},
+		{Mat3f{[9]float32{1, 1, 1, 1, 1, 1, 1, 1, 1}}, Mat3f{}, false},
+		{Mat3f{}, Mat3f{}, false},
+		// singular is judged relative to the entries, not by the determinant
+		{Mat3f{[9]float32{1e-2, 0, 0, 0, 1e-2, 0, 0, 0, 1e-2}}, Mat3f{[9]float32{1e2, 0, 0, 0, 1e2, 0, 0, 0, 1e2}}, true},
+		{Mat3f{[9]float32{1e10, 2e10, 3e10, 4e10, 5e10, 6e10, 7e10, 8e10, 9e10}}, Mat3f{}, false},
+	}
+
+	for testIndex, c := range cases {
+		got, ok := c.m.TryInverse()
+		if ok != c.ok {
+			t.Errorf("TestTryInverseMat3f %d ok %v", testIndex, ok)
+		}
+		for k := 0; k < 9; k++ {
+			if !closeEq32(got.At(k), c.want.At(k), 1e-6) {
+				t.Errorf("TestTryInverseMat3f %d\n%v\n%v", testIndex, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestFromAxisAngleMat3f(t *testing.T) {
+	cases := []struct {
+		angle     float32
+		axis      Vec3f
+		start_vec Vec3f
+		want      Vec3f
+	}{
+
+		//test basic rotations using a [1,0,0] vector
+		{90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{90, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{-90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{-90, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{360, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{180, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //7
+
+		//test basic rotations using a [0,1,0] vector
+		{90, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{90, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}},
+		{-90, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{-90, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{360, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{180, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}}, //15
+
+		// test negative axes
+		{90, Vec3f{0, -1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{90, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{90, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{-90, Vec3f{0, -1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{-90, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{-90, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{360, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{180, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //23
+
+		// test arbitraty axis
+		{360, Vec3f{1, 1, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{90, Vec3f{1, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0.5, 0.5, -0.7071067811}},
+		{45, Vec3f{1, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0.85355339059, 0.1464466094067, -0.5}}, //26
+	}
+
+	m := &Mat3f{}
+	for testIndex, c := range cases {
+		c.axis.NormalizeIn()
+		m.FromAxisAngle(float32(Radians(float64(c.angle))), c.axis.X, c.axis.Y, c.axis.Z)
+
+		get := m.MultVec3(c.start_vec)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestFromAxisAngleMat3f %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+	}
+}
+
+func TestAxisAngleMat3f(t *testing.T) {
+	cases := []struct {
+		angle, x, y, z float32
+	}{
+		//test basic rotations using a [1,0,0] vector
+		{90, 1, 0, 0},
+		{90, 0, 1, 0},
+		{90, 0, 0, 1},
+		{45, 1, 0, 0},
+		{45, 0, 1, 0},
+		{45, 0, 0, 1}, //5
+		{180, 1, 0, 0},
+		{180, 0, 1, 0},
+		{180, 0, 0, 1},
+		{90, 1, 1, 0},
+		{90, 1, 1, 0}, //10
+		{90, 0, -1, 1},
+		{45, 1, 0, 1},
+		{45, 0, 1, 0},
+		{45, 1, 0, 1},
+		{180, 1, -2, 0}, //15
+		{180, 0, 1, 20},
+		{180, 0, 20, 1},
+		{180, -4, 4, 1},
+	}
+
+	m := &Mat3f{}
+	for testIndex, c := range cases {
+		v := Vec3f{c.x, c.y, c.z}
+		v.NormalizeIn()
+		m.FromAxisAngle(float32(Radians(float64(c.angle))), v.X, v.Y, v.Z)
+		get_angle, get_x, get_y, get_z := m.AxisAngle()
+
+		if !closeEq32(float32(Degrees(float64(get_angle))), c.angle, epsilon32) ||
+			!closeEq32(get_x, v.X, epsilon32) ||
+			!closeEq32(get_y, v.Y, epsilon32) ||
+			!closeEq32(get_z, v.Z, epsilon32) {
+
+			if closeEq32(get_angle, math.Pi, epsilon32) &&
+				closeEq32(float32(math.Abs(float64(get_x))-math.Abs(float64(v.X))), 0, epsilon32) &&
+				closeEq32(float32(math.Abs(float64(get_y))-math.Abs(float64(v.Y))), 0, epsilon32) &&
+				closeEq32(float32(math.Abs(float64(get_z))-math.Abs(float64(v.Z))), 0, epsilon32) {
+				continue
+			} else {
+				t.Errorf("TestAxisAngleMat3f %d %v \n%f %f %f %f\n%f %f %f %f\n",
+					testIndex, v, float32(Degrees(float64(get_angle))), get_x, get_y, get_z, c.angle, v.X, v.Y, v.Z)
+			}
+		}
+	}
+}
+
+func TestFromEulerMat3f(t *testing.T) {
+	common_cases := []struct {
+		pitch, yaw, roll float32
+		start_vec        Vec3f
+		want             Vec3f
+	}{
+		{180, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 180, 0, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //2
+		{180, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{0, 0, 180, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}}, //5
+		{180, 0, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, -1}},
+		{0, 0, 180, Vec3f{0, 0, 1}, Vec3f{0, 0, 1}}, //8
+
+		{180, 0, 0, Vec3f{-1, 0, 0}, Vec3f{-1, 0, 0}},
+		{0, 180, 0, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}}, //11
+		{180, 0, 0, Vec3f{0, -1, 0}, Vec3f{0, 1, 0}},
+		{0, 180, 0, Vec3f{0, -1, 0}, Vec3f{0, -1, 0}},
+		{0, 0, 180, Vec3f{0, -1, 0}, Vec3f{0, 1, 0}}, //14
+		{180, 0, 0, Vec3f{0, 0, -1}, Vec3f{0, 0, 1}},
+		{0, 180, 0, Vec3f{0, 0, -1}, Vec3f{0, 0, 1}},
+		{0, 0, 180, Vec3f{0, 0, -1}, Vec3f{0, 0, -1}}, //17
+
+		{0, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{0, 0, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, 1}}, //2
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}},
+
+		//test basic rotations using a [0,1,0] vector
+		// pitch,yaw,roll
+		{0, 0, 90, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}},
+		{0, 90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{0, 0, -90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{0, -90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}}, //6
+
+		// test basic rotation using a [1,0,0] vector
+		{0, 0, 90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{0, 90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, -90, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{0, -90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //13
+
+		// basic rotation using a non major axis vector
+		{0, 0, 90, Vec3f{1, 1, 0}, Vec3f{-1, 1, 0}},
+		{0, 90, 0, Vec3f{1, -1, 0}, Vec3f{0, -1, -1}},
+		{90, 0, 0, Vec3f{-1, -1, 0}, Vec3f{-1, 0, -1}}, //16
+
+		// two rotations
+		{90, 0, 45, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{90, 45, 0, Vec3f{0, 0, 1}, Vec3f{0, -1, 0}},
+		{45, 90, 0, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}}, //20
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range common_cases {
+		// m = EulerToMat3(float32(Radians(float64(c.yaw))), float32(Radians(float64(c.pitch))), float32(Radians(float64(c.roll))))
+		m.FromEuler(float32(Radians(float64(c.pitch))), float32(Radians(float64(c.yaw))), float32(Radians(float64(c.roll))))
+		get := m.MultVec3(c.start_vec)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestFromEulerMat3f %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+	}
+}
+
+func TestEulerMat3f(t *testing.T) {
+	common_cases := []struct {
+		pitch, yaw, roll float32
+		start_vec        Vec3f
+		want             Vec3f
+	}{
+		{180, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 180, 0, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //2
+		{180, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{0, 0, 180, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}}, //5
+		{180, 0, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, -1}},
+		{0, 0, 180, Vec3f{0, 0, 1}, Vec3f{0, 0, 1}}, //8
+
+		{180, 0, 0, Vec3f{-1, 0, 0}, Vec3f{-1, 0, 0}},
+		{0, 180, 0, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}}, //11
+		{180, 0, 0, Vec3f{0, -1, 0}, Vec3f{0, 1, 0}},
+		{0, 180, 0, Vec3f{0, -1, 0}, Vec3f{0, -1, 0}},
+		{0, 0, 180, Vec3f{0, -1, 0}, Vec3f{0, 1, 0}}, //14
+		{180, 0, 0, Vec3f{0, 0, -1}, Vec3f{0, 0, 1}},
+		{0, 180, 0, Vec3f{0, 0, -1}, Vec3f{0, 0, 1}},
+		{0, 0, 180, Vec3f{0, 0, -1}, Vec3f{0, 0, -1}}, //17
+
+		{0, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{0, 0, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, 1}}, //20
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}},
+
+		//test basic rotations using a [0,1,0] vector
+		// pitch,yaw,roll
+		{0, 0, 90, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}}, //22
+		{0, 90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{0, 0, -90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{0, -90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}}, //28
+
+		// test basic rotation using a [1,0,0] vector
+		{0, 0, 90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}}, //29
+		{0, 90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, -90, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{0, -90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //35
+
+		// basic rotation using a non major axis vector
+		{0, 0, 90, Vec3f{1, 1, 0}, Vec3f{-1, 1, 0}},
+		{0, 90, 0, Vec3f{1, -1, 0}, Vec3f{0, -1, -1}},
+		{90, 0, 0, Vec3f{-1, -1, 0}, Vec3f{-1, 0, -1}}, //38
+
+		// two rotations
+		{90, 0, 45, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}}, //39
+		{90, 45, 0, Vec3f{0, 0, 1}, Vec3f{0, -1, 0}},
+		{45, 90, 0, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}}, //42
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range common_cases {
+		m.FromEuler(float32(Radians(float64(c.pitch))), float32(Radians(float64(c.yaw))), float32(Radians(float64(c.roll))))
+		x, y, z := m.Euler()
+
+		if closeEq32(float32(Degrees(float64(x))), c.pitch, epsilon32) && closeEq32(float32(Degrees(float64(y))), c.yaw, epsilon32) && closeEq32(float32(Degrees(float64(z))), c.roll, epsilon32) {
+			continue
+		}
+
+		// The euler angles we got back didn't match, but lets see if the rotation
+		// matrix it makes is still equivalent
+		m.FromEuler(x, y, z)
+		get := m.MultVec3(c.start_vec)
+		if get.Eq(c.want) {
+			continue
+		}
+
+		t.Errorf("TestEulerMat3f %d %f %f %f", testIndex, x, y, z)
+	}
+}
+
+func TestTransformNormalMat3f(t *testing.T) {
+	cases := []struct {
+		orig_mat        [9]float32
+		normal, tangent Vec2f
+	}{
+		{[9]float32{1, 0, 2, 0, 4, 3, 0, 0, 1}, Vec2f{1, 1}, Vec2f{1, -1}},
+		{[9]float32{3, 1, 0, 0, 1, 0, 0, 0, 1}, Vec2f{0, 1}, Vec2f{1, 0}},
+		{[9]float32{2, 1, 7, -1, 3, 7, 0, 0, 1}, Vec2f{1, 2}, Vec2f{2, -1}},
+	}
+
+	m := Mat3f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.TransformNormal(c.normal)
+		tangent := m.TransformDirection(c.tangent)
+		if !closeEq32(get.Length(), 1, epsilon32) || !closeEq32(get.Dot(tangent), 0, epsilon32) {
+			t.Errorf("TestTransformNormalMat3f %d %v %v", testIndex, get, tangent)
+		}
+	}
+}
@@ -130,6 +130,16 @@ func (this Mat4) Eq(other Mat4) bool {
 	return true
 }
 
+// Equal if all elements are equal within the tolerance
+func (this Mat4) ApproxEq(other Mat4, tol Tolerance) bool {
+	for k := range this.mat {
+		if !tol.Eq(this.mat[k], other.mat[k]) {
+			return false
+		}
+	}
+	return true
+}
+
 // Retrieve the element at row and column.
 // 0 indexed.
 // Does not do any bounds checking.
@@ -444,6 +454,20 @@ func (this Mat4) HasInverse() bool {
 	return !closeEq(this.Determinant(), 0, epsilon)
 }
 
+// Return the inverse of the matrix and true, or the zero matrix and false when
+// the matrix is singular or the inverse is not finite. Unlike HasInverse the
+// singular check is relative to the size of the entries, see LU.
+func (this Mat4) TryInverse() (Mat4, bool) {
+	if _, _, _, ok := this.LU(); !ok {
+		return Mat4{}, false
+	}
+	out := this.Inverse()
+	if !out.IsFinite() {
+		return Mat4{}, false
+	}
+	return out, true
+}
+
 // Sets the matrix to the identity matrix.
 func (this *Mat4) ToIdentity() *Mat4 {
 	this.mat = [16]float64{
@@ -481,6 +505,26 @@ func (this Mat4) IsRotation() bool {
 	return closeEq(this.Determinant(), 1, epsilon) && this.Mult(this.Transpose()).IsIdentity()
 }
 
+// Return true if no element is NaN or infinite.
+func (this Mat4) IsFinite() bool {
+	for _, v := range this.mat {
+		if !isFinite(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if any element is NaN.
+func (this Mat4) HasNaN() bool {
+	for _, v := range this.mat {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
+
 // Implement the Stringer interface
 // Prints out each row of the matrix on its own line
 func (this *Mat4) String() string {
@@ -565,6 +609,133 @@ func (this *Mat4) ToRotateZ(angle float64) *Mat4 {
 	return this
 }
 
+// Create a perspective projection matrix. Overwrites all values in the matrix.
+// fovy is the vertical field of view (radians), aspect is width/height.
+// near and far are the (positive) distances to the clipping planes.
+// Equivalent to gluPerspective; the camera looks down the -Z axis and depth
+// is mapped into the OpenGL [-1,1] range.
+func (this *Mat4) ToPerspective(fovy, aspect, near, far float64) *Mat4 {
+	return this.ToPerspectiveClip(fovy, aspect, near, far, ClipSpaceOpenGL)
+}
+
+// Create a perspective projection matrix targeting the given clip space.
+// Overwrites all values in the matrix.
+// See ToPerspective for a description of the parameters.
+func (this *Mat4) ToPerspectiveClip(fovy, aspect, near, far float64, clip ClipSpace) *Mat4 {
+	// f/aspect  0    0    0
+	// 0         f    0    0
+	// 0         0    A    B
+	// 0         0    -1   0
+	f := 1 / math.Tan(fovy/2)
+	a, b := perspectiveDepth(near, far, clip)
+	this.Load([16]float64{
+		f / aspect, 0, 0, 0,
+		0, f, 0, 0,
+		0, 0, a, b,
+		0, 0, -1, 0,
+	})
+	if clip.FlipY {
+		this.mat[5] = -this.mat[5]
+	}
+	return this
+}
+
+// Create a perspective projection matrix from the view volume bounds.
+// Overwrites all values in the matrix.
+// left,right,bottom,top are specified on the near plane.
+// near and far are the (positive) distances to the clipping planes.
+// Equivalent to glFrustum.
+func (this *Mat4) ToFrustum(left, right, bottom, top, near, far float64) *Mat4 {
+	return this.ToFrustumClip(left, right, bottom, top, near, far, ClipSpaceOpenGL)
+}
+
+// Create a perspective projection matrix from the view volume bounds
+// targeting the given clip space. Overwrites all values in the matrix.
+// See ToFrustum for a description of the parameters.
+func (this *Mat4) ToFrustumClip(left, right, bottom, top, near, far float64, clip ClipSpace) *Mat4 {
+	// 2n/(r-l)   0          (r+l)/(r-l)    0
+	// 0          2n/(t-b)   (t+b)/(t-b)    0
+	// 0          0          A              B
+	// 0          0          -1             0
+	a, b := perspectiveDepth(near, far, clip)
+	this.Load([16]float64{
+		2 * near / (right - left), 0, (right + left) / (right - left), 0,
+		0, 2 * near / (top - bottom), (top + bottom) / (top - bottom), 0,
+		0, 0, a, b,
+		0, 0, -1, 0,
+	})
+	if clip.FlipY {
+		this.mat[5] = -this.mat[5]
+		this.mat[6] = -this.mat[6]
+	}
+	return this
+}
+
+// Create an orthographic projection matrix. Overwrites all values in the matrix.
+// near and far are the distances to the clipping planes along the -Z axis.
+// Equivalent to glOrtho.
+func (this *Mat4) ToOrtho(left, right, bottom, top, near, far float64) *Mat4 {
+	return this.ToOrthoClip(left, right, bottom, top, near, far, ClipSpaceOpenGL)
+}
+
+// Create an orthographic projection matrix targeting the given clip space.
+// Overwrites all values in the matrix.
+// The InfiniteFar option is ignored as an orthographic projection needs a
+// finite depth range.
+func (this *Mat4) ToOrthoClip(left, right, bottom, top, near, far float64, clip ClipSpace) *Mat4 {
+	// 2/(r-l)   0         0    -(r+l)/(r-l)
+	// 0         2/(t-b)   0    -(t+b)/(t-b)
+	// 0         0         A    B
+	// 0         0         0    1
+	dn, df := clip.depthRange()
+	a := (dn - df) / (far - near)
+	b := dn + a*near
+	this.Load([16]float64{
+		2 / (right - left), 0, 0, -(right + left) / (right - left),
+		0, 2 / (top - bottom), 0, -(top + bottom) / (top - bottom),
+		0, 0, a, b,
+		0, 0, 0, 1,
+	})
+	if clip.FlipY {
+		this.mat[5] = -this.mat[5]
+		this.mat[7] = -this.mat[7]
+	}
+	return this
+}
+
+// Return the A,B terms of the depth row of a perspective matrix.
+// A view-space depth z is mapped onto (A*z + B) / -z, which places the near
+// and far planes at the ends of the clip space depth range.
+func perspectiveDepth(near, far float64, clip ClipSpace) (a, b float64) {
+	dn, df := clip.depthRange()
+	if clip.InfiniteFar {
+		return -df, (dn - df) * near
+	}
+	b = (dn - df) * near * far / (far - near)
+	a = b/near - dn
+	return
+}
+
+// Create a view matrix positioned at eye looking towards center.
+// Overwrites all values in the matrix.
+// up is the approximate up direction and must not be parallel to the view
+// direction. Equivalent to gluLookAt; the camera looks down its -Z axis.
+func (this *Mat4) ToLookAt(eye, center, up Vec3) *Mat4 {
+	// s is the camera right vector, u the camera up vector and f the
+	// direction the camera is looking.
+	f := center.Sub(eye).Normalize()
+	s := f.Cross(up).Normalize()
+	u := s.Cross(f)
+
+	this.Load([16]float64{
+		s.X, s.Y, s.Z, -s.Dot(eye),
+		u.X, u.Y, u.Z, -u.Dot(eye),
+		-f.X, -f.Y, -f.Z, f.Dot(eye),
+		0, 0, 0, 1,
+	})
+	return this
+}
+
 //==============================================================================
 
 // Return the upper 3x3 matrix as a Mat3
@@ -596,6 +767,8 @@ func (this *Mat4) SetUpperMat3(m Mat3) *Mat4 {
 
 // Multiplies the Vec3 against the matrix ( ie. result = Matrix * Vec).
 // Returns a new vector with the result.
+// The bottom row is ignored so this is only correct for affine matrices, use
+// TransformPoint for projections.
 func (this Mat4) MultVec3(v Vec3) (out Vec3) {
 	// 0   1   2   3
 	// 4   5   6   7
@@ -625,6 +798,33 @@ func (this Mat4) MultVec4(v Vec4) (out Vec4) {
 	return
 }
 
+// Transform the point by the matrix, treating it as [x,y,z,1].
+// Translation is applied and the result is divided by w, so this is correct
+// for projection matrices as well as affine ones.
+// Returns a new vector with the result.
+func (this Mat4) TransformPoint(p Vec3) Vec3 {
+	v := this.MultVec4(Vec4{p.X, p.Y, p.Z, 1})
+	if v.W != 1 {
+		return Vec3{v.X / v.W, v.Y / v.W, v.Z / v.W}
+	}
+	return Vec3{v.X, v.Y, v.Z}
+}
+
+// Transform the direction by the matrix, treating it as [x,y,z,0].
+// Only the upper 3x3 is applied, translation is ignored.
+// Returns a new vector with the result.
+func (this Mat4) TransformDirection(d Vec3) Vec3 {
+	return this.UpperMat3().MultVec3(d)
+}
+
+// Transform the surface normal by the inverse-transpose of the upper 3x3, so
+// that it stays perpendicular to the surface under non-uniform scaling.
+// The upper 3x3 must be invertible.
+// Returns a new unit length vector with the result.
+func (this Mat4) TransformNormal(n Vec3) Vec3 {
+	return this.UpperMat3().Inverse().Transpose().MultVec3(n).Normalize()
+}
+
 // =============================================================================
 
 // Return a rotation matrix which rotates a vector about the axis [x,y,z] with
@@ -642,6 +842,20 @@ func (this *Mat4) FromAxisAngle(angle, x, y, z float64) *Mat4 {
 		0, 0, 0, 1})
 }
 
+// Return the rotation matrix which turns the direction a onto the direction b,
+// see QuatFromTo.
+func Mat4FromTo(a, b Vec3) Mat4 {
+	return QuatFromTo(a, b).Mat4()
+}
+
+// Return the rotation matrix which turns Vec3Forward onto forward and Vec3Up
+// towards up, see QuatLookRotation.
+func Mat4LookRotation(forward, up Vec3) Mat4 {
+	m := Mat4Identity
+	m.SetUpperMat3(Mat3LookRotation(forward, up))
+	return m
+}
+
 // Set this as a rotation matrix using the specified pitch,yaw, and roll paramters.
 // Angles are in radians.
 func (this *Mat4) FromEuler(pitch, yaw, roll float64) *Mat4 {
@@ -687,138 +901,14 @@ func (this *Mat4) FromEuler(pitch, yaw, roll float64) *Mat4 {
 // Return the axis (radians) and axis of this rotation matrix.
 // Assumes the matrix is a valid rotation matrix.
 func (this Mat4) AxisAngle() (angle, x, y, z float64) {
-	// Reference
-	// http://www.euclideanspace.com/maths/geometry/rotations/conversions/matrixToAngle/
-	m00, m01, m02 := this.Get(0, 0), this.Get(0, 1), this.Get(0, 2)
-	m10, m11, m12 := this.Get(1, 0), this.Get(1, 1), this.Get(1, 2)
-	m20, m21, m22 := this.Get(2, 0), this.Get(2, 1), this.Get(2, 2)
-
-	if closeEq(math.Abs(m01-m10), 0, epsilon) &&
-		closeEq(math.Abs(m02-m20), 0, epsilon) &&
-		closeEq(math.Abs(m12-m21), 0, epsilon) {
-		// singularity check
-		// Checking for cases in which the angle is either 0 or 180
-
-		if this.IsIdentity() {
-			// If the angle is 0, then the rotation matrix will be the identity matrix
-			// A 0 angle means that there is an arbitrary axis.
-			angle, x, y, z = 0, 1, 0, 0
-			return
-		}
-
-		// Angle is 180, we need to find the axis it rotates around
-		angle = math.Pi
-
-		xx := (m00 + 1) / 2
-		yy := (m11 + 1) / 2
-		zz := (m22 + 1) / 2
-		xy := (m01 + m10) / 4
-		xz := (m02 + m20) / 4
-		yz := (m12 + m21) / 4
-
-		if (xx > yy) && (xx > zz) { // m[0][0] is the largest diagonal term
-			if xx < epsilon {
-				x = 0
-				y = math.Sqrt(2) / 2
-				z = math.Sqrt(2) / 2
-			} else {
-				x = math.Sqrt(xx)
-				y = xy / x
-				z = xz / x
-			}
-		} else if yy > zz { // m[1][1] is the largest diagonal term
-			if yy < epsilon {
-				x = math.Sqrt(2) / 2
-				y = 0
-				z = math.Sqrt(2) / 2
-			} else {
-				y = math.Sqrt(yy)
-				x = xy / y
-				z = yz / y
-			}
-		} else { // m[2][2] is the largest diagonal term so base result on this
-			if zz < epsilon {
-				x = math.Sqrt(2) / 2
-				y = math.Sqrt(2) / 2
-				z = 0
-			} else {
-				z = math.Sqrt(zz)
-				x = xz / z
-				y = yz / z
-			}
-		}
-		return
-	}
-
-	// no singularity; therefore calculate as normal
-	angle = math.Acos((m00 + m11 + m22 - 1) / 2)
-	A := (m21 - m12)
-	B := (m02 - m20)
-	C := (m10 - m01)
-
-	x = A / math.Sqrt(A*A+B*B+C*C)
-	y = B / math.Sqrt(A*A+B*B+C*C)
-	z = C / math.Sqrt(A*A+B*B+C*C)
-	return
+	return mat3AxisAngle(this.UpperMat3(), epsilon)
 }
 
 // Return the pitch,yaw and roll values for the given rotation matrix.
 // The returned euler angle may not be the exact angle in which you supplied
 // but they can be used to make an equilvalent rotation matrix.
 func (this Mat4) Euler() (pitch, yaw, roll float64) {
-	// The method for calculating the euler angles from a rotation matrix
-	// uses the method described in this document
-	// http://staff.city.ac.uk/~sbbh653/publications/euler.pdf
-
-	// The rotation matrix we are using will be of the following form
-	// cos(x) is abbreviated as cx ( similarily sin(x) = sx)
-	// This corresponds to the pitch => yaw => roll rotation matrix
-	// cz*cy       cz*sy*sx - sz*cx         sz*sx + cz*cx*sy   | r11 r12 r13
-	// sz*cy       cz*cx + sx*sy*sz         sz*sy*cx - cz*sx   | r21 r22 r23
-	// -sy         sx*cy                    cx*cy              | r31 r32 r33
-
-	// We want to determine the x,y,z angles
-	// 1) Find the 'y' angle
-	//      This is easily accomplished because term r31 is simply '-sin(y)'
-	// 2) There are two possible angles for y because
-	//      sin(y) == sin(pi - y)
-	// 3) To find the value of x, we observe the following
-	//      r32/r33 = tan(x)
-	//      (sin(x)cos(y)) / (cos(x)cos(y))
-	//      (sin(x)/cos(x)) == tan(x) by defn.
-	// 4) We can also calculate x and z by.
-	//      x = atan2(r32,r33) == atan2( (sin(x)cos(y)) / (cos(x)cos(y)) )
-	// 		z = atan2(r21,r11) == atan2( (sin(z)cos(y)) / (cos(z)cos(y)) )
-
-	var x, y, z float64
-	r31 := this.Get(2, 0)
-	if closeEq(r31, 1, epsilon) {
-		// we are in gimbal lock
-		z = 0
-		y = -math.Pi / 2
-		x = -z + math.Atan2(-this.Get(0, 1), -this.Get(0, 2))
-	} else if closeEq(r31, -1, epsilon) {
-		// we are in gimbal lock
-		z = 0
-		y = math.Pi / 2
-		x = z + math.Atan2(this.Get(0, 1), this.Get(0, 2))
-	} else {
-		y = -math.Asin(r31)
-		cos_y := math.Cos(y)
-		x = math.Atan2(this.Get(2, 1)/cos_y, this.Get(2, 2)/cos_y)
-		z = math.Atan2(this.Get(1, 0)/cos_y, this.Get(0, 0)/cos_y)
-
-		// There are two alternative values for y,here is the second option
-		// y2 := math.Pi - y
-		// cos_y2 := math.Cos(y2)
-		// x2 := math.Atan2(this.Get(2, 1)/cos_y2, this.Get(2, 2)/cos_y2)
-		// z2 := math.Atan2(this.Get(1, 0)/cos_y2, this.Get(0, 0)/cos_y2)
-	}
-
-	pitch = x
-	yaw = y
-	roll = z
-	return
+	return this.EulerOrder(ExtrinsicXYZ)
 }
 
 // Creates a rotation matrix from the given quaternion. Return this
@@ -833,3 +923,83 @@ func (this Mat4) Quat() Quat {
 	q.FromMat4(this)
 	return q
 }
+
+// Set the matrix to the composition of a translation, rotation and scale
+// (ie. T * R * S), so the scale is applied first. r must be unit length.
+// Return this
+func (this *Mat4) FromTRS(t Vec3, r Quat, s Vec3) *Mat4 {
+	*this = r.Mat4()
+	for row := 0; row < 3; row++ {
+		this.mat[row*4+0] *= s.X
+		this.mat[row*4+1] *= s.Y
+		this.mat[row*4+2] *= s.Z
+	}
+	this.mat[3] = t.X
+	this.mat[7] = t.Y
+	this.mat[11] = t.Z
+	return this
+}
+
+// Decompose the matrix into a translation, rotation and scale such that
+// FromTRS(t, r, s) rebuilds the matrix.
+// A reflection (negative determinant) is returned as a negative X scale.
+// ok is false when the matrix can't be cleanly decomposed: it has shear, a
+// zero scale or a projective bottom row. The values returned are then a best
+// effort, with the rotation made orthogonal by Gram-Schmidt.
+func (this Mat4) Decompose() (t Vec3, r Quat, s Vec3, ok bool) {
+	ok = true
+	t = Vec3{this.mat[3], this.mat[7], this.mat[11]}
+	if !closeEq(this.mat[12], 0, decomposeEpsilon) || !closeEq(this.mat[13], 0, decomposeEpsilon) ||
+		!closeEq(this.mat[14], 0, decomposeEpsilon) || !closeEq(this.mat[15], 1, decomposeEpsilon) {
+		ok = false
+	}
+
+	var c [3]Vec3
+	for col := range c {
+		x, y, z, _ := this.Col(col)
+		c[col] = Vec3{x, y, z}
+	}
+
+	// Gram-Schmidt, any part of a column along the previous ones is shear
+	s.X = c[0].Length()
+	if s.X < decomposeEpsilon {
+		return t, QuatIdentity, s, false
+	}
+	c[0].DivInScalar(s.X)
+
+	shearXY := c[0].Dot(c[1])
+	c[1] = c[1].Sub(c[0].MultScalar(shearXY))
+	s.Y = c[1].Length()
+	if s.Y < decomposeEpsilon {
+		return t, QuatIdentity, s, false
+	}
+	c[1].DivInScalar(s.Y)
+
+	shearXZ := c[0].Dot(c[2])
+	shearYZ := c[1].Dot(c[2])
+	c[2] = c[2].Sub(c[0].MultScalar(shearXZ)).Sub(c[1].MultScalar(shearYZ))
+	s.Z = c[2].Length()
+	if s.Z < decomposeEpsilon {
+		return t, QuatIdentity, s, false
+	}
+	c[2].DivInScalar(s.Z)
+
+	if math.Abs(shearXY)/s.Y > decomposeEpsilon ||
+		math.Abs(shearXZ)/s.Z > decomposeEpsilon ||
+		math.Abs(shearYZ)/s.Z > decomposeEpsilon {
+		ok = false
+	}
+
+	// A left handed basis is a reflection, move it into the X scale
+	if c[0].Cross(c[1]).Dot(c[2]) < 0 {
+		s.X = -s.X
+		c[0] = c[0].MultScalar(-1)
+	}
+
+	rot := Mat3{}
+	for col := range c {
+		rot.SetCol(col, c[col].X, c[col].Y, c[col].Z)
+	}
+	r.FromMat3(rot)
+	return
+}
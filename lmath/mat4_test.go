@@ -787,6 +787,319 @@ func TestToShearMat4(t *testing.T) {
 	}
 }
 
+func TestToPerspectiveMat4(t *testing.T) {
+	cases := []struct {
+		fovy, aspect, near, far float64
+		want                    [16]float64
+	}{
+		{90, 1, 1, 3, [16]float64{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -2, -3,
+			0, 0, -1, 0,
+		}},
+		{90, 2, 1, 3, [16]float64{
+			0.5, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -2, -3,
+			0, 0, -1, 0,
+		}},
+		{60, 1, 0.1, 100, [16]float64{
+			math.Sqrt(3), 0, 0, 0,
+			0, math.Sqrt(3), 0, 0,
+			0, 0, -100.1 / 99.9, -20 / 99.9,
+			0, 0, -1, 0,
+		}},
+	}
+
+	m := &Mat4{}
+	for testIndex, c := range cases {
+		m.ToPerspective(Radians(c.fovy), c.aspect, c.near, c.far)
+		get := m.Dump()
+		for k, _ := range c.want {
+			if !closeEq(get[k], c.want[k], epsilon) {
+				t.Errorf("TestToPerspectiveMat4 %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+
+		// The near and far planes must map onto the OpenGL depth range [-1,1]
+		near := m.MultVec4(Vec4{0, 0, -c.near, 1})
+		far := m.MultVec4(Vec4{0, 0, -c.far, 1})
+		if !closeEq(near.Z/near.W, -1, epsilon) || !closeEq(far.Z/far.W, 1, epsilon) {
+			t.Errorf("TestToPerspectiveMat4 depth %d %v %v", testIndex, near, far)
+		}
+	}
+}
+
+func TestToFrustumMat4(t *testing.T) {
+	cases := []struct {
+		left, right, bottom, top, near, far float64
+		want                                [16]float64
+	}{
+		{-1, 1, -1, 1, 1, 3, [16]float64{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -2, -3,
+			0, 0, -1, 0,
+		}},
+		{0, 2, 0, 1, 1, 3, [16]float64{
+			1, 0, 1, 0,
+			0, 2, 1, 0,
+			0, 0, -2, -3,
+			0, 0, -1, 0,
+		}},
+	}
+
+	m := &Mat4{}
+	for testIndex, c := range cases {
+		m.ToFrustum(c.left, c.right, c.bottom, c.top, c.near, c.far)
+		get := m.Dump()
+		for k, _ := range c.want {
+			if !closeEq(get[k], c.want[k], epsilon) {
+				t.Errorf("TestToFrustumMat4 %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+
+		// The corners of the near plane must map onto the corners of the NDC cube
+		v := m.MultVec4(Vec4{c.left, c.bottom, -c.near, 1})
+		v.DivInScalar(v.W)
+		if !v.Eq(Vec4{-1, -1, -1, 1}) {
+			t.Errorf("TestToFrustumMat4 bottom-left %d %v", testIndex, v)
+		}
+		v = m.MultVec4(Vec4{c.right, c.top, -c.near, 1})
+		v.DivInScalar(v.W)
+		if !v.Eq(Vec4{1, 1, -1, 1}) {
+			t.Errorf("TestToFrustumMat4 top-right %d %v", testIndex, v)
+		}
+	}
+
+	// A symmetric frustum is the same as a perspective matrix
+	p := &Mat4{}
+	p.ToPerspective(Radians(90), 1, 1, 3)
+	if !p.Eq(*m.ToFrustum(-1, 1, -1, 1, 1, 3)) {
+		t.Errorf("TestToFrustumMat4 perspective")
+	}
+}
+
+func TestToOrthoMat4(t *testing.T) {
+	cases := []struct {
+		left, right, bottom, top, near, far float64
+		want                                [16]float64
+	}{
+		{-1, 1, -1, 1, 1, 3, [16]float64{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -1, -2,
+			0, 0, 0, 1,
+		}},
+		{0, 2, 0, 4, -1, 1, [16]float64{
+			1, 0, 0, -1,
+			0, 0.5, 0, -1,
+			0, 0, -1, 0,
+			0, 0, 0, 1,
+		}},
+	}
+
+	m := &Mat4{}
+	for testIndex, c := range cases {
+		m.ToOrtho(c.left, c.right, c.bottom, c.top, c.near, c.far)
+		get := m.Dump()
+		for k, _ := range c.want {
+			if !closeEq(get[k], c.want[k], epsilon) {
+				t.Errorf("TestToOrthoMat4 %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+
+		near := m.MultVec3(Vec3{c.left, c.bottom, -c.near})
+		far := m.MultVec3(Vec3{c.right, c.top, -c.far})
+		if !near.Eq(Vec3{-1, -1, -1}) || !far.Eq(Vec3{1, 1, 1}) {
+			t.Errorf("TestToOrthoMat4 %d %v %v", testIndex, near, far)
+		}
+	}
+}
+
+func TestProjectionClipSpaceMat4(t *testing.T) {
+	cases := []struct {
+		clip     ClipSpace
+		wantNear float64
+		wantFar  float64
+		wantTopY float64
+	}{
+		{ClipSpaceOpenGL, -1, 1, 1},
+		{ClipSpaceD3D, 0, 1, 1},
+		{ClipSpaceVulkan, 0, 1, -1},
+		{ClipSpace{ReverseZ: true}, 1, -1, 1},
+		{ClipSpace{DepthZeroToOne: true, ReverseZ: true}, 1, 0, 1},
+		{ClipSpace{DepthZeroToOne: true, FlipY: true, ReverseZ: true}, 1, 0, -1},
+	}
+
+	near, far := 0.5, 20.0
+	left, right, bottom, top := -2.0, 1.0, -1.0, 3.0
+	ndc := func(m *Mat4, v Vec3) Vec3 {
+		p := m.MultVec4(Vec4{v.X, v.Y, v.Z, 1})
+		return Vec3{p.X / p.W, p.Y / p.W, p.Z / p.W}
+	}
+
+	m := &Mat4{}
+	for testIndex, c := range cases {
+		// Perspective
+		m.ToPerspectiveClip(Radians(90), 1, near, far, c.clip)
+		if get := ndc(m, Vec3{0, near, -near}); !get.Eq(Vec3{0, c.wantTopY, c.wantNear}) {
+			t.Errorf("TestProjectionClipSpaceMat4 perspective near %d %v", testIndex, get)
+		}
+		if get := ndc(m, Vec3{0, far, -far}); !get.Eq(Vec3{0, c.wantTopY, c.wantFar}) {
+			t.Errorf("TestProjectionClipSpaceMat4 perspective far %d %v", testIndex, get)
+		}
+
+		// Frustum
+		m.ToFrustumClip(left, right, bottom, top, near, far, c.clip)
+		if get := ndc(m, Vec3{left, top, -near}); !get.Eq(Vec3{-1, c.wantTopY, c.wantNear}) {
+			t.Errorf("TestProjectionClipSpaceMat4 frustum near %d %v", testIndex, get)
+		}
+		s := far / near
+		if get := ndc(m, Vec3{right * s, bottom * s, -far}); !get.Eq(Vec3{1, -c.wantTopY, c.wantFar}) {
+			t.Errorf("TestProjectionClipSpaceMat4 frustum far %d %v", testIndex, get)
+		}
+
+		// Ortho
+		m.ToOrthoClip(left, right, bottom, top, near, far, c.clip)
+		if get := ndc(m, Vec3{left, top, -near}); !get.Eq(Vec3{-1, c.wantTopY, c.wantNear}) {
+			t.Errorf("TestProjectionClipSpaceMat4 ortho near %d %v", testIndex, get)
+		}
+		if get := ndc(m, Vec3{right, bottom, -far}); !get.Eq(Vec3{1, -c.wantTopY, c.wantFar}) {
+			t.Errorf("TestProjectionClipSpaceMat4 ortho far %d %v", testIndex, get)
+		}
+
+		// Infinite far plane. The near plane is unchanged and points far away
+		// approach the far end of the depth range.
+		inf := c.clip
+		inf.InfiniteFar = true
+		m.ToPerspectiveClip(Radians(90), 1, near, far, inf)
+		if get := ndc(m, Vec3{0, 0, -near}); !get.Eq(Vec3{0, 0, c.wantNear}) {
+			t.Errorf("TestProjectionClipSpaceMat4 infinite near %d %v", testIndex, get)
+		}
+		if get := ndc(m, Vec3{0, 0, -1e12}); !closeEq(get.Z, c.wantFar, 1e-9) {
+			t.Errorf("TestProjectionClipSpaceMat4 infinite far %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestToPerspectiveClipMat4(t *testing.T) {
+	cases := []struct {
+		clip ClipSpace
+		want [16]float64
+	}{
+		{ClipSpaceOpenGL, [16]float64{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -2, -3,
+			0, 0, -1, 0,
+		}},
+		{ClipSpaceVulkan, [16]float64{
+			1, 0, 0, 0,
+			0, -1, 0, 0,
+			0, 0, -1.5, -1.5,
+			0, 0, -1, 0,
+		}},
+		{ClipSpace{DepthZeroToOne: true, ReverseZ: true}, [16]float64{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, 0.5, 1.5,
+			0, 0, -1, 0,
+		}},
+		{ClipSpace{InfiniteFar: true}, [16]float64{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -1, -2,
+			0, 0, -1, 0,
+		}},
+		{ClipSpace{DepthZeroToOne: true, ReverseZ: true, InfiniteFar: true}, [16]float64{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, 0, 1,
+			0, 0, -1, 0,
+		}},
+	}
+
+	m := &Mat4{}
+	for testIndex, c := range cases {
+		m.ToPerspectiveClip(Radians(90), 1, 1, 3, c.clip)
+		get := m.Dump()
+		for k, _ := range c.want {
+			if !closeEq(get[k], c.want[k], epsilon) {
+				t.Errorf("TestToPerspectiveClipMat4 %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+	}
+}
+
+func TestToLookAtMat4(t *testing.T) {
+	cases := []struct {
+		eye, center, up Vec3
+		point           Vec3
+		want            Vec3
+	}{
+		{Vec3{0, 0, 5}, Vec3{0, 0, 0}, Vec3{0, 1, 0}, Vec3{0, 0, 0}, Vec3{0, 0, -5}},
+		{Vec3{0, 0, 5}, Vec3{0, 0, 0}, Vec3{0, 1, 0}, Vec3{1, 2, 5}, Vec3{1, 2, 0}},
+		{Vec3{1, 2, 3}, Vec3{1, 2, 0}, Vec3{0, 1, 0}, Vec3{0, 0, 0}, Vec3{-1, -2, -3}},
+		{Vec3{0, 0, 0}, Vec3{1, 0, 0}, Vec3{0, 1, 0}, Vec3{5, 0, 0}, Vec3{0, 0, -5}},
+		{Vec3{0, 0, 0}, Vec3{1, 0, 0}, Vec3{0, 1, 0}, Vec3{0, 0, 1}, Vec3{1, 0, 0}},
+		{Vec3{0, 0, 0}, Vec3{0, -1, 0}, Vec3{0, 0, -1}, Vec3{0, -2, 0}, Vec3{0, 0, -2}},
+		{Vec3{0, 0, 0}, Vec3{0, -1, 0}, Vec3{0, 0, -1}, Vec3{0, 0, -1}, Vec3{0, 1, 0}},
+	}
+
+	m := &Mat4{}
+	for testIndex, c := range cases {
+		m.ToLookAt(c.eye, c.center, c.up)
+		get := m.MultVec3(c.point)
+		if !get.Eq(c.want) {
+			t.Errorf("TestToLookAtMat4 %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+		r := m.UpperMat3()
+		if !r.Mult(r.Transpose()).Eq(Mat3Identity) || !closeEq(r.Determinant(), 1, epsilon) {
+			t.Errorf("TestToLookAtMat4 rotation %d \n%v\n\n", testIndex, m)
+		}
+	}
+}
+
+func TestProjectionDumpOpenGLMat4(t *testing.T) {
+	// OpenGL expects the matrices in column-major order
+	m := &Mat4{}
+	m.ToPerspective(Radians(90), 1, 1, 3)
+	want := [16]float64{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, -2, -1,
+		0, 0, -3, 0,
+	}
+	get := m.DumpOpenGL()
+	for k, _ := range want {
+		if !closeEq(get[k], want[k], epsilon) {
+			t.Errorf("TestProjectionDumpOpenGLMat4 perspective %d %v", k, get)
+			break
+		}
+	}
+
+	m.ToLookAt(Vec3{1, 2, 3}, Vec3{1, 2, 0}, Vec3{0, 1, 0})
+	want = [16]float64{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		-1, -2, -3, 1,
+	}
+	get = m.DumpOpenGL()
+	for k, _ := range want {
+		if !closeEq(get[k], want[k], epsilon) {
+			t.Errorf("TestProjectionDumpOpenGLMat4 lookAt %d %v", k, get)
+			break
+		}
+	}
+}
+
 func TestUpperMat3Mat4(t *testing.T) {
 	common_cases := []struct {
 		mat4_vals [16]float64
@@ -1103,3 +1416,225 @@ func TestEulerMat4(t *testing.T) {
 		t.Errorf("TestEulerMat4 %d %f %f %f", testIndex, x, y, z)
 	}
 }
+
+func TestTransformPointMat4(t *testing.T) {
+	persp := Mat4{}
+	persp.ToPerspective(math.Pi/2, 1, 1, 10)
+	trans := Mat4{}
+	trans.ToTranslate(1, 2, 3)
+
+	cases := []struct {
+		m            Mat4
+		orig_v, want Vec3
+	}{
+		{trans, Vec3{1, 1, 1}, Vec3{2, 3, 4}},
+		// the near and far planes map to -1 and 1 after the divide
+		{persp, Vec3{0, 0, -1}, Vec3{0, 0, -1}},
+		{persp, Vec3{0, 0, -10}, Vec3{0, 0, 1}},
+		{persp, Vec3{2, 2, -2}, Vec3{1, 1, 1.0 / 9}},
+		{persp, Vec3{5, -5, -10}, Vec3{0.5, -0.5, 1}},
+	}
+
+	for testIndex, c := range cases {
+		get := c.m.TransformPoint(c.orig_v)
+		if get.Sub(c.want).Length() > 1e-9 {
+			t.Errorf("TestTransformPointMat4 %d %v %v", testIndex, get, c.want)
+		}
+	}
+}
+
+func TestTransformDirectionMat4(t *testing.T) {
+	trans := Mat4{}
+	trans.ToTranslate(1, 2, 3)
+	scale := Mat4{}
+	scale.ToScale(2, 3, 4)
+	rot := Mat4{}
+	rot.FromAxisAngle(math.Pi/2, 0, 0, 1)
+
+	cases := []struct {
+		m            Mat4
+		orig_v, want Vec3
+	}{
+		{trans, Vec3{1, 0, 0}, Vec3{1, 0, 0}},
+		{scale, Vec3{1, 1, 1}, Vec3{2, 3, 4}},
+		{trans.Mult(rot), Vec3{1, 0, 0}, Vec3{0, 1, 0}},
+	}
+
+	for testIndex, c := range cases {
+		get := c.m.TransformDirection(c.orig_v)
+		if get.Sub(c.want).Length() > 1e-9 {
+			t.Errorf("TestTransformDirectionMat4 %d %v %v", testIndex, get, c.want)
+		}
+	}
+}
+
+func TestTransformNormalMat4(t *testing.T) {
+	scale := Mat4{}
+	scale.ToScale(1, 4, 1)
+	rot := Mat4{}
+	rot.FromAxisAngle(math.Pi/3, 1, 1, 0)
+	trans := Mat4{}
+	trans.ToTranslate(5, -2, 1)
+	m := trans.Mult(rot.Mult(scale))
+
+	cases := []struct {
+		normal, tangent1, tangent2 Vec3
+	}{
+		{Vec3{1, 1, 0}, Vec3{1, -1, 0}, Vec3{0, 0, 1}},
+		{Vec3{0, 1, 0}, Vec3{1, 0, 0}, Vec3{0, 0, 1}},
+		{Vec3{1, 2, 3}, Vec3{2, -1, 0}, Vec3{3, 0, -1}},
+	}
+
+	for testIndex, c := range cases {
+		get := m.TransformNormal(c.normal)
+		t1 := m.TransformDirection(c.tangent1)
+		t2 := m.TransformDirection(c.tangent2)
+		if !closeEq(get.Length(), 1, epsilon) ||
+			!closeEq(get.Dot(t1), 0, epsilon) ||
+			!closeEq(get.Dot(t2), 0, epsilon) {
+			t.Errorf("TestTransformNormalMat4 %d %v %v %v", testIndex, get, t1, t2)
+		}
+	}
+
+	// the normal must face the same way as a naively transformed one
+	n := m.TransformNormal(Vec3{0, 1, 0})
+	if n.Dot(m.TransformDirection(Vec3{0, 1, 0})) <= 0 {
+		t.Errorf("TestTransformNormalMat4 flipped %v", n)
+	}
+}
+
+func TestDecomposeMat4(t *testing.T) {
+	axis := Vec3{1, 2, 3}.Normalize()
+	q := Quat{}
+	q.FromAxisAngle(1.2, axis.X, axis.Y, axis.Z)
+	qz := Quat{}
+	qz.FromAxisAngle(math.Pi/2, 0, 0, 1)
+
+	cases := []struct {
+		trans Vec3
+		rot   Quat
+		scale Vec3
+	}{
+		{Vec3{0, 0, 0}, QuatIdentity, Vec3{1, 1, 1}},
+		{Vec3{1, 2, 3}, QuatIdentity, Vec3{2, 3, 4}},
+		{Vec3{-5, 0, 2}, qz, Vec3{1, 1, 1}},
+		{Vec3{1, -2, 3}, q, Vec3{0.5, 2, 7}},
+		{Vec3{1, -2, 3}, q, Vec3{-2, 1, 3}},
+		{Vec3{4, 4, 4}, qz, Vec3{-1, 2, 1}},
+	}
+
+	for testIndex, c := range cases {
+		m := Mat4{}
+		m.FromTRS(c.trans, c.rot, c.scale)
+
+		trans, rot, scale, ok := m.Decompose()
+		if !ok {
+			t.Errorf("TestDecomposeMat4 %d not ok", testIndex)
+			continue
+		}
+		if trans.Sub(c.trans).Length() > 1e-9 {
+			t.Errorf("TestDecomposeMat4 %d translation %v %v", testIndex, trans, c.trans)
+		}
+		// a reflection is always moved into the X scale
+		if scale.Sub(c.scale).Length() > 1e-9 {
+			rebuilt := Mat4{}
+			rebuilt.FromTRS(trans, rot, scale)
+			for k := 0; k < 16; k++ {
+				if !closeEq(rebuilt.At(k), m.At(k), 1e-9) {
+					t.Errorf("TestDecomposeMat4 %d scale %v %v\n%v\n%v", testIndex, scale, c.scale, &rebuilt, &m)
+					break
+				}
+			}
+			continue
+		}
+		if math.Abs(rot.Dot(c.rot)) < 1-1e-9 {
+			t.Errorf("TestDecomposeMat4 %d rotation %v %v", testIndex, rot, c.rot)
+		}
+	}
+}
+
+func TestDecomposeReflectionMat4(t *testing.T) {
+	// mirror in Y, the reflection is reported through the X scale
+	m := Mat4{}
+	m.ToScale(1, -1, 1)
+	_, rot, scale, ok := m.Decompose()
+	if !ok || scale.X > 0 || scale.Y < 0 || scale.Z < 0 {
+		t.Errorf("TestDecomposeReflectionMat4 %v %v %v", rot, scale, ok)
+	}
+	rebuilt := Mat4{}
+	rebuilt.FromTRS(Vec3{0, 0, 0}, rot, scale)
+	for k := 0; k < 16; k++ {
+		if !closeEq(rebuilt.At(k), m.At(k), 1e-9) {
+			t.Errorf("TestDecomposeReflectionMat4 \n%v\n%v", &rebuilt, &m)
+			break
+		}
+	}
+}
+
+func TestDecomposeFailMat4(t *testing.T) {
+	shear := Mat4{}
+	shear.Load([16]float64{1, 0.5, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1})
+	zero := Mat4{}
+	zero.ToScale(1, 0, 1)
+	persp := Mat4{}
+	persp.ToPerspective(math.Pi/2, 1, 1, 10)
+
+	cases := []Mat4{shear, zero, persp}
+	for testIndex, c := range cases {
+		if _, _, _, ok := c.Decompose(); ok {
+			t.Errorf("TestDecomposeFailMat4 %d\n%v", testIndex, &c)
+		}
+	}
+}
+
+func TestTryInverseMat4(t *testing.T) {
+	cases := []struct {
+		m, want Mat4
+		ok      bool
+	}{
+		{Mat4Identity, Mat4Identity, true},
+		{Mat4{[16]float64{2, 0, 0, 0, 0, 3, 0, 0, 0, 0, 4, 0, 0, 0, 0, 5}}, Mat4{[16]float64{0.5, 0, 0, 0, 0, 0.3333333333333333, 0, 0, 0, 0, 0.25, 0, 0, 0, 0, 0.2}}, true},
+		{Mat4{[16]float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}}, Mat4{}, false},
+		{Mat4{}, Mat4{}, false},
+		// singular is judged relative to the entries, not by the determinant
+		{Mat4{[16]float64{1e-3, 0, 0, 0, 0, 1e-3, 0, 0, 0, 0, 1e-3, 0, 0, 0, 0, 1e-3}}, Mat4{[16]float64{1e3, 0, 0, 0, 0, 1e3, 0, 0, 0, 0, 1e3, 0, 0, 0, 0, 1e3}}, true},
+		{Mat4{[16]float64{1e10, 2e10, 3e10, 4e10, 5e10, 6e10, 7e10, 8e10, 9e10, 10e10, 11e10, 12e10, 13e10, 14e10, 15e10, 16e10}}, Mat4{}, false},
+	}
+
+	for testIndex, c := range cases {
+		got, ok := c.m.TryInverse()
+		if ok != c.ok {
+			t.Errorf("TestTryInverseMat4 %d ok %v", testIndex, ok)
+		}
+		for k := 0; k < 16; k++ {
+			if !closeEq(got.At(k), c.want.At(k), 1e-6) {
+				t.Errorf("TestTryInverseMat4 %d\n%v\n%v", testIndex, &got, &c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMat4FromToLookRotation(t *testing.T) {
+	a, b := Vec3{1, 2, 3}, Vec3{-3, 0.5, 2}
+	m := Mat4FromTo(a, b)
+	if got := m.TransformDirection(a.Normalize()); got.Sub(b.Normalize()).Length() > 1e-9 {
+		t.Errorf("TestMat4FromToLookRotation FromTo %v %v", got, b)
+	}
+
+	m = Mat4LookRotation(b, Vec3Up)
+	if got := m.TransformDirection(Vec3Forward); got.Sub(b.Normalize()).Length() > 1e-9 {
+		t.Errorf("TestMat4FromToLookRotation forward %v %v", got, b)
+	}
+	if !m.UpperMat3().ApproxEq(Mat3LookRotation(b, Vec3Up), DefaultTolerance) {
+		t.Errorf("TestMat4FromToLookRotation upper\n%v", &m)
+	}
+	for _, k := range []int{3, 7, 11, 12, 13, 14} {
+		if m.At(k) != 0 {
+			t.Errorf("TestMat4FromToLookRotation %d %v", k, m.At(k))
+		}
+	}
+	if m.At(15) != 1 {
+		t.Errorf("TestMat4FromToLookRotation w %v", m.At(15))
+	}
+}
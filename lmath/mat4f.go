@@ -0,0 +1,823 @@
+package lmath
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	Mat4fIdentity = Mat4f{[16]float32{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1}}
+)
+
+// A float32 4x4 matrix with the same semantics as Mat4.
+// DumpOpenGL returns float32 values which can be uploaded to the GPU without
+// a conversion. Matrices which are more involved to build (projections,
+// axis-angle, euler angles) can be made with Mat4 and converted using
+// Mat4.Mat4f.
+type Mat4f struct {
+	mat [16]float32
+}
+
+// New Mat4f with the given values.
+// Row-Order.
+func NewMat4f(
+	m11, m12, m13, m14,
+	m21, m22, m23, m24,
+	m31, m32, m33, m34,
+	m41, m42, m43, m44 float32) *Mat4f {
+
+	// 0   1   2   3
+	// 4   5   6   7
+	// 8   9   10  11
+	// 12  13  14  15
+	out := Mat4f{}
+	out.mat[0] = m11
+	out.mat[1] = m12
+	out.mat[2] = m13
+	out.mat[3] = m14
+	out.mat[4] = m21
+	out.mat[5] = m22
+	out.mat[6] = m23
+	out.mat[7] = m24
+	out.mat[8] = m31
+	out.mat[9] = m32
+	out.mat[10] = m33
+	out.mat[11] = m34
+	out.mat[12] = m41
+	out.mat[13] = m42
+	out.mat[14] = m43
+	out.mat[15] = m44
+
+	return &out
+}
+
+// Load the matrix with 16 floats.
+// Specified in Row-Major order.
+func (this *Mat4f) Load(m [16]float32) *Mat4f {
+	this.mat = m
+	return this
+}
+
+// Retrieve a 16 float array of all the values of the matrix.
+// Returned in Row-Major order.
+func (this Mat4f) Dump() (m [16]float32) {
+	m = this.mat
+	return
+}
+
+// Retrieve a 16 float array of all the values of the matrix.
+// Returned in Col-Major order.
+func (this Mat4f) DumpOpenGL() (m [16]float32) {
+	m[0], m[1], m[2], m[3] = this.Col(0)
+	m[4], m[5], m[6], m[7] = this.Col(1)
+	m[8], m[9], m[10], m[11] = this.Col(2)
+	m[12], m[13], m[14], m[15] = this.Col(3)
+	return
+}
+
+// Return a copy of this matrix.
+// Carbon-copy of all elements
+func (this Mat4f) Copy() Mat4f {
+	return this
+}
+
+// Compare this matrix to the other.
+// Return true if all elements between them are the same.
+// Equality is measured using an epsilon32 (< 0.00001).
+func (this Mat4f) Eq(other Mat4f) bool {
+	for k, _ := range this.mat {
+		if closeEq32(this.mat[k], other.mat[k], epsilon32) == false {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal if all elements are equal within the tolerance
+func (this Mat4f) ApproxEq(other Mat4f, tol Tolerance) bool {
+	for k := range this.mat {
+		if !tol.Eq32(this.mat[k], other.mat[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Retrieve the element at row and column.
+// 0 indexed.
+// Does not do any bounds checking.
+func (this Mat4f) Get(row, col int) float32 {
+	return this.mat[row*mat4Dim+col]
+}
+
+// Set the value at the specified column and row.
+// 0 indexed.
+// Does not do any bounds checking.
+func (this *Mat4f) Set(row, col int, value float32) *Mat4f {
+	this.mat[row*mat4Dim+col] = value
+	return this
+}
+
+// Retrieve the element at the given index assuming a linear array.
+// (i.e matrix[0], matrix[5]).
+// 0 indexed.
+func (this Mat4f) At(index int) float32 {
+	return this.mat[index]
+}
+
+// Set the element of the matrix specified at the index to the given value.
+// 0 indexed.
+// Return a pointer to the 'this'
+func (this *Mat4f) SetAt(index int, value float32) *Mat4f {
+	this.mat[index] = value
+	return this
+}
+
+// Set the specified row of the matrix to the given x,y,z,w values.
+// 0 indexed.
+// Does not do bounds checking of the row.
+func (this *Mat4f) SetRow(row int, x, y, z, w float32) *Mat4f {
+	this.mat[row*mat4Dim] = x
+	this.mat[row*mat4Dim+1] = y
+	this.mat[row*mat4Dim+2] = z
+	this.mat[row*mat4Dim+3] = w
+	return this
+}
+
+// Set the specified column of the matrix to the given x,y,z,w values.
+// 0 indexed.
+// Does not do bounds checking on the col.
+func (this *Mat4f) SetCol(col int, x, y, z, w float32) *Mat4f {
+	this.mat[mat4Dim*0+col] = x
+	this.mat[mat4Dim*1+col] = y
+	this.mat[mat4Dim*2+col] = z
+	this.mat[mat4Dim*3+col] = w
+	return this
+}
+
+// Retrieve the x,y,z,w elements from the specified row.
+// 0 indexed.
+// Does not bounds check the row.
+func (this Mat4f) Row(row int) (x, y, z, w float32) {
+	x = this.mat[row*mat4Dim]
+	y = this.mat[row*mat4Dim+1]
+	z = this.mat[row*mat4Dim+2]
+	w = this.mat[row*mat4Dim+3]
+	return
+}
+
+// Retrieve the x,y,z,w elements from the specified column.
+// 0 indexed.
+// Does not bounds check the column.
+func (this Mat4f) Col(col int) (x, y, z, w float32) {
+	x = this.mat[mat4Dim*0+col]
+	y = this.mat[mat4Dim*1+col]
+	z = this.mat[mat4Dim*2+col]
+	w = this.mat[mat4Dim*3+col]
+	return
+}
+
+// Add in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+func (this Mat4f) AddScalar(val float32) Mat4f {
+	this.AddInScalar(val)
+	return this
+}
+
+// Add in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+func (this *Mat4f) AddInScalar(val float32) *Mat4f {
+	for k, _ := range this.mat {
+		this.mat[k] += val
+	}
+	return this
+}
+
+// Subtract in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+func (this Mat4f) SubScalar(val float32) Mat4f {
+	this.SubInScalar(val)
+	return this
+}
+
+// Subtract in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+func (this *Mat4f) SubInScalar(val float32) *Mat4f {
+	for k, _ := range this.mat {
+		this.mat[k] -= val
+	}
+	return this
+}
+
+// Multiplies in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+func (this Mat4f) MultScalar(val float32) Mat4f {
+	this.MultInScalar(val)
+	return this
+}
+
+// Multiplies in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+func (this *Mat4f) MultInScalar(val float32) *Mat4f {
+	for k, _ := range this.mat {
+		this.mat[k] *= val
+	}
+	return this
+}
+
+// Divides in a constant value to all the terms fo the matrix.
+// Return a new matrix with the result.
+//
+//	precondition: val > 0
+func (this Mat4f) DivScalar(val float32) Mat4f {
+	this.DivInScalar(val)
+	return this
+}
+
+// Divides in a constant value to all the terms fo the matrix.
+// Returns a pointer to 'this'.
+//
+//	precondition: val > 0
+func (this *Mat4f) DivInScalar(val float32) *Mat4f {
+	for k, _ := range this.mat {
+		this.mat[k] /= val
+	}
+	return this
+}
+
+// Adds the two matrices together ( ie.  this + other).
+// Return a new matrix with the result.
+func (this Mat4f) Add(other Mat4f) Mat4f {
+	this.AddIn(other)
+	return this
+}
+
+// Adds the two matrices together ( ie.  this + other).
+// Stores the result in this.
+// Returns this.
+func (this *Mat4f) AddIn(other Mat4f) *Mat4f {
+	for k, _ := range this.mat {
+		this.mat[k] += other.mat[k]
+	}
+	return this
+}
+
+// Subtract the two matrices together ( ie.  this - other).
+// Return a new matrix with the result.
+func (this Mat4f) Sub(other Mat4f) Mat4f {
+	this.SubIn(other)
+	return this
+}
+
+// Subtract the two matrices together ( ie.  this - other).
+// Stores the result in this.
+// Returns this.
+func (this *Mat4f) SubIn(other Mat4f) *Mat4f {
+	for k, _ := range this.mat {
+		this.mat[k] -= other.mat[k]
+	}
+	return this
+}
+
+// Multiply the two matrices together ( ie.  this * other).
+// Return a new matrix with the result.
+func (this Mat4f) Mult(other Mat4f) Mat4f {
+	this.MultIn(other)
+	return this
+}
+
+// Multiplies the two matrices together ( ie.  this * other).
+// Stores the result in this.
+// Returns this.
+func (this *Mat4f) MultIn(o Mat4f) *Mat4f {
+	// 0   1   2   3
+	// 4   5   6   7
+	// 8   9   10  11
+	// 12  13  14  15
+	m := *this
+	this.mat[0] = m.mat[0]*o.mat[0] + m.mat[1]*o.mat[4] + m.mat[2]*o.mat[8] + m.mat[3]*o.mat[12]
+	this.mat[1] = m.mat[0]*o.mat[1] + m.mat[1]*o.mat[5] + m.mat[2]*o.mat[9] + m.mat[3]*o.mat[13]
+	this.mat[2] = m.mat[0]*o.mat[2] + m.mat[1]*o.mat[6] + m.mat[2]*o.mat[10] + m.mat[3]*o.mat[14]
+	this.mat[3] = m.mat[0]*o.mat[3] + m.mat[1]*o.mat[7] + m.mat[2]*o.mat[11] + m.mat[3]*o.mat[15]
+
+	this.mat[4] = m.mat[4]*o.mat[0] + m.mat[5]*o.mat[4] + m.mat[6]*o.mat[8] + m.mat[7]*o.mat[12]
+	this.mat[5] = m.mat[4]*o.mat[1] + m.mat[5]*o.mat[5] + m.mat[6]*o.mat[9] + m.mat[7]*o.mat[13]
+	this.mat[6] = m.mat[4]*o.mat[2] + m.mat[5]*o.mat[6] + m.mat[6]*o.mat[10] + m.mat[7]*o.mat[14]
+	this.mat[7] = m.mat[4]*o.mat[3] + m.mat[5]*o.mat[7] + m.mat[6]*o.mat[11] + m.mat[7]*o.mat[15]
+
+	this.mat[8] = m.mat[8]*o.mat[0] + m.mat[9]*o.mat[4] + m.mat[10]*o.mat[8] + m.mat[11]*o.mat[12]
+	this.mat[9] = m.mat[8]*o.mat[1] + m.mat[9]*o.mat[5] + m.mat[10]*o.mat[9] + m.mat[11]*o.mat[13]
+	this.mat[10] = m.mat[8]*o.mat[2] + m.mat[9]*o.mat[6] + m.mat[10]*o.mat[10] + m.mat[11]*o.mat[14]
+	this.mat[11] = m.mat[8]*o.mat[3] + m.mat[9]*o.mat[7] + m.mat[10]*o.mat[11] + m.mat[11]*o.mat[15]
+
+	this.mat[12] = m.mat[12]*o.mat[0] + m.mat[13]*o.mat[4] + m.mat[14]*o.mat[8] + m.mat[15]*o.mat[12]
+	this.mat[13] = m.mat[12]*o.mat[1] + m.mat[13]*o.mat[5] + m.mat[14]*o.mat[9] + m.mat[15]*o.mat[13]
+	this.mat[14] = m.mat[12]*o.mat[2] + m.mat[13]*o.mat[6] + m.mat[14]*o.mat[10] + m.mat[15]*o.mat[14]
+	this.mat[15] = m.mat[12]*o.mat[3] + m.mat[13]*o.mat[7] + m.mat[14]*o.mat[11] + m.mat[15]*o.mat[15]
+
+	return this
+}
+
+// Returns a new matrix which is transpose to this.
+func (this Mat4f) Transpose() Mat4f {
+	this.TransposeIn()
+	return this
+}
+
+// Take the transpose of this matrix.
+func (this *Mat4f) TransposeIn() *Mat4f {
+	// TODO: can definitely be way more efficient
+	// by only exchanging the column entries.
+	m00, m01, m02, m03 := this.Row(0)
+	m10, m11, m12, m13 := this.Row(1)
+	m20, m21, m22, m23 := this.Row(2)
+	m30, m31, m32, m33 := this.Row(3)
+
+	this.SetCol(0, m00, m01, m02, m03)
+	this.SetCol(1, m10, m11, m12, m13)
+	this.SetCol(2, m20, m21, m22, m23)
+	this.SetCol(3, m30, m31, m32, m33)
+
+	return this
+}
+
+// Get the determinant of the matrix.
+// Uses a straight-up Cramers-Rule implementation.
+func (this Mat4f) Determinant() float32 {
+	// 0   1   2   3
+	// 4   5   6   7
+	// 8   9   10  11
+	// 12  13  14  15
+
+	// Use Cramer's rule to calculate the determinant
+	return (this.mat[0]*det3x3f(this.mat[5], this.mat[6], this.mat[7],
+		this.mat[9], this.mat[10], this.mat[11],
+		this.mat[13], this.mat[14], this.mat[15]) -
+
+		this.mat[4]*det3x3f(this.mat[1], this.mat[2], this.mat[3],
+			this.mat[9], this.mat[10], this.mat[11],
+			this.mat[13], this.mat[14], this.mat[15]) +
+
+		this.mat[8]*det3x3f(this.mat[1], this.mat[2], this.mat[3],
+			this.mat[5], this.mat[6], this.mat[7],
+			this.mat[13], this.mat[14], this.mat[15]) -
+
+		this.mat[12]*det3x3f(this.mat[1], this.mat[2], this.mat[3],
+			this.mat[5], this.mat[6], this.mat[7],
+			this.mat[9], this.mat[10], this.mat[11]))
+}
+
+// Returns a new matrix which is the Adjoint matrix of this.
+func (this Mat4f) Adjoint() Mat4f {
+	a1, a2, a3, a4 := this.mat[0], this.mat[1], this.mat[2], this.mat[3]
+	b1, b2, b3, b4 := this.mat[4], this.mat[5], this.mat[6], this.mat[7]
+	c1, c2, c3, c4 := this.mat[8], this.mat[9], this.mat[10], this.mat[11]
+	d1, d2, d3, d4 := this.mat[12], this.mat[13], this.mat[14], this.mat[15]
+
+	// 0 1 2 3 			a1 a2 a3 a4
+	// 4 5 6 7 			b1 b2 b3 b4
+	// 8 9 10 11 		c1 c2 c3 c4
+	// 12 13 14 15 		d1 d2 d3 d4
+	//m := Mat4f{}
+	this.mat[0] = det3x3f(b2, b3, b4, c2, c3, c4, d2, d3, d4)
+	this.mat[1] = -det3x3f(b1, b3, b4, c1, c3, c4, d1, d3, d4)
+	this.mat[2] = det3x3f(b1, b2, b4, c1, c2, c4, d1, d2, d4)
+	this.mat[3] = -det3x3f(b1, b2, b3, c1, c2, c3, d1, d2, d3)
+
+	this.mat[4] = -det3x3f(a2, a3, a4, c2, c3, c4, d2, d3, d4)
+	this.mat[5] = det3x3f(a1, a3, a4, c1, c3, c4, d1, d3, d4)
+	this.mat[6] = -det3x3f(a1, a2, a4, c1, c2, c4, d1, d2, d4)
+	this.mat[7] = det3x3f(a1, a2, a3, c1, c2, c3, d1, d2, d3)
+
+	this.mat[8] = det3x3f(a2, a3, a4, b2, b3, b4, d2, d3, d4)
+	this.mat[9] = -det3x3f(a1, a3, a4, b1, b3, b4, d1, d3, d4)
+	this.mat[10] = det3x3f(a1, a2, a4, b1, b2, b4, d1, d2, d4)
+	this.mat[11] = -det3x3f(a1, a2, a3, b1, b2, b3, d1, d2, d3)
+
+	this.mat[12] = -det3x3f(a2, a3, a4, b2, b3, b4, c2, c3, c4)
+	this.mat[13] = det3x3f(a1, a3, a4, b1, b3, b4, c1, c3, c4)
+	this.mat[14] = -det3x3f(a1, a2, a4, b1, b2, b4, c1, c2, c4)
+	this.mat[15] = det3x3f(a1, a2, a3, b1, b2, b3, c1, c2, c3)
+
+	this.TransposeIn()
+	return this
+}
+
+// Returns a new matrix which is the inverse matrix of this.
+// The bool flag is false if an inverse does not exist.
+func (this Mat4f) Inverse() Mat4f {
+	// TODO: Needs further testing
+	// Try out with rotation matrices.
+	// The inverse of a valid rotation matrix should just be the transpose
+	det := this.Determinant()
+	return this.Adjoint().DivScalar(det)
+}
+
+// Returns true if the inverse of this matrix exists false otherwise.
+// Internally it checks to see if the determinant is zero.
+func (this Mat4f) HasInverse() bool {
+	return !closeEq32(this.Determinant(), 0, epsilon32)
+}
+
+// Return the inverse of the matrix and true, or the zero matrix and false when
+// the matrix is singular or the inverse is not finite. Unlike HasInverse the
+// singular check is relative to the size of the entries, see Mat4.LU.
+func (this Mat4f) TryInverse() (Mat4f, bool) {
+	a := this.Mat4().mat
+	if _, ok := luDecompose(&a, mat4Dim, epsilon32); !ok {
+		return Mat4f{}, false
+	}
+	out := this.Inverse()
+	if !out.IsFinite() {
+		return Mat4f{}, false
+	}
+	return out, true
+}
+
+// Sets the matrix to the identity matrix.
+func (this *Mat4f) ToIdentity() *Mat4f {
+	this.mat = [16]float32{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	}
+	return this
+}
+
+// Return true if no element is NaN or infinite.
+func (this Mat4f) IsFinite() bool {
+	for _, v := range this.mat {
+		if !isFinite(float64(v)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if any element is NaN.
+func (this Mat4f) HasNaN() bool {
+	for _, v := range this.mat {
+		if math.IsNaN(float64(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Implement the Stringer interface
+// Prints out each row of the matrix on its own line
+func (this *Mat4f) String() string {
+	return fmt.Sprintf("%f %f %f %f\n%f %f %f %f\n%f %f %f %f\n%f %f %f %f",
+		this.mat[0], this.mat[1], this.mat[2], this.mat[3],
+		this.mat[4], this.mat[5], this.mat[6], this.mat[7],
+		this.mat[8], this.mat[9], this.mat[10], this.mat[11],
+		this.mat[12], this.mat[13], this.mat[14], this.mat[15])
+}
+
+// Create a translation matrix for Mat4f. Overwrites all values in the matrix.
+func (this *Mat4f) ToTranslate(x, y, z float32) *Mat4f {
+	this.ToIdentity()
+	this.Set(0, 3, x)
+	this.Set(1, 3, y)
+	this.Set(2, 3, z)
+	return this
+}
+
+// Create a scaling matrix for Mat4f. Overwrites all values in the matrix.
+func (this *Mat4f) ToScale(x, y, z float32) *Mat4f {
+	this.ToIdentity()
+	this.Set(0, 0, x)
+	this.Set(1, 1, y)
+	this.Set(2, 2, z)
+	return this
+}
+
+// Create a shearing matrix for Mat4f. Overwrites all values in the matrix.
+func (this *Mat4f) ToShear(x, y, z float32) *Mat4f {
+	// 0   -z     y    0
+	// z    0    -x    0
+	// -y   x     0    0
+	// 0    0     0    1
+
+	this.ToIdentity()
+	this.Set(0, 0, 0)
+	this.Set(1, 1, 0)
+	this.Set(2, 2, 0)
+
+	this.Set(1, 2, -x)
+	this.Set(2, 1, x)
+
+	this.Set(0, 2, y)
+	this.Set(2, 0, -y)
+
+	this.Set(0, 1, -z)
+	this.Set(1, 0, z)
+
+	return this
+}
+
+// Create a 3D rotation matrix about the x-axis with angles (radians)
+func (this *Mat4f) ToRotateX(angle float32) *Mat4f {
+	this.ToIdentity()
+	this.Set(1, 1, cos32(angle))
+	this.Set(1, 2, -sin32(angle))
+	this.Set(2, 1, sin32(angle))
+	this.Set(2, 2, cos32(angle))
+	return this
+}
+
+// Create a 3D rotation matrix about the y-axis with angles (radians)
+func (this *Mat4f) ToRotateY(angle float32) *Mat4f {
+	this.ToIdentity()
+	this.Set(0, 0, cos32(angle))
+	this.Set(0, 2, sin32(angle))
+	this.Set(2, 0, -sin32(angle))
+	this.Set(2, 2, cos32(angle))
+	return this
+}
+
+// Create a 3D rotation matrix about the z-axis with angles (radians)
+func (this *Mat4f) ToRotateZ(angle float32) *Mat4f {
+	this.ToIdentity()
+	this.Set(0, 0, cos32(angle))
+	this.Set(0, 1, -sin32(angle))
+	this.Set(1, 0, sin32(angle))
+	this.Set(1, 1, cos32(angle))
+	return this
+}
+
+// Create a view matrix positioned at eye looking towards center.
+// Overwrites all values in the matrix.
+// up is the approximate up direction and must not be parallel to the view
+// direction. Equivalent to gluLookAt; the camera looks down its -Z axis.
+func (this *Mat4f) ToLookAt(eye, center, up Vec3f) *Mat4f {
+	// s is the camera right vector, u the camera up vector and f the
+	// direction the camera is looking.
+	f := center.Sub(eye).Normalize()
+	s := f.Cross(up).Normalize()
+	u := s.Cross(f)
+
+	this.Load([16]float32{
+		s.X, s.Y, s.Z, -s.Dot(eye),
+		u.X, u.Y, u.Z, -u.Dot(eye),
+		-f.X, -f.Y, -f.Z, f.Dot(eye),
+		0, 0, 0, 1,
+	})
+	return this
+}
+
+// Return the upper 3x3 matrix as a Mat3f
+func (this Mat4f) UpperMat3() (out Mat3f) {
+	out.Load([9]float32{
+		this.Get(0, 0), this.Get(0, 1), this.Get(0, 2),
+		this.Get(1, 0), this.Get(1, 1), this.Get(1, 2),
+		this.Get(2, 0), this.Get(2, 1), this.Get(2, 2),
+	})
+	return out
+}
+
+// Return the upper 3x3 matrix to the provide Mat3f
+func (this *Mat4f) SetUpperMat3(m Mat3f) *Mat4f {
+	this.Set(0, 0, m.Get(0, 0))
+	this.Set(0, 1, m.Get(0, 1))
+	this.Set(0, 2, m.Get(0, 2))
+
+	this.Set(1, 0, m.Get(1, 0))
+	this.Set(1, 1, m.Get(1, 1))
+	this.Set(1, 2, m.Get(1, 2))
+
+	this.Set(2, 0, m.Get(2, 0))
+	this.Set(2, 1, m.Get(2, 1))
+	this.Set(2, 2, m.Get(2, 2))
+
+	return this
+}
+
+// Multiplies the Vec3f against the matrix ( ie. result = Matrix * Vec).
+// Returns a new vector with the result.
+// The bottom row is ignored so this is only correct for affine matrices, use
+// TransformPoint for projections.
+func (this Mat4f) MultVec3(v Vec3f) (out Vec3f) {
+	// 0   1   2   3
+	// 4   5   6   7
+	// 8   9   10  11
+	// 12  13  14  15
+	out.Set(
+		this.mat[0]*v.X+this.mat[1]*v.Y+this.mat[2]*v.Z+this.mat[3],
+		this.mat[4]*v.X+this.mat[5]*v.Y+this.mat[6]*v.Z+this.mat[7],
+		this.mat[8]*v.X+this.mat[9]*v.Y+this.mat[10]*v.Z+this.mat[11],
+	)
+	return
+}
+
+// Multiplies the Vec4f against the matrix ( ie. result = Matrix * Vec).
+// Returns a new vector with the result.
+func (this Mat4f) MultVec4(v Vec4f) (out Vec4f) {
+	// 0   1   2   3
+	// 4   5   6   7
+	// 8   9   10  11
+	// 12  13  14  15
+	out.Set(
+		this.mat[0]*v.X+this.mat[1]*v.Y+this.mat[2]*v.Z+this.mat[3]*v.W,
+		this.mat[4]*v.X+this.mat[5]*v.Y+this.mat[6]*v.Z+this.mat[7]*v.W,
+		this.mat[8]*v.X+this.mat[9]*v.Y+this.mat[10]*v.Z+this.mat[11]*v.W,
+		this.mat[12]*v.X+this.mat[13]*v.Y+this.mat[14]*v.Z+this.mat[15]*v.W,
+	)
+	return
+}
+
+// Transform the point by the matrix, treating it as [x,y,z,1].
+// Translation is applied and the result is divided by w, so this is correct
+// for projection matrices as well as affine ones.
+// Returns a new vector with the result.
+func (this Mat4f) TransformPoint(p Vec3f) Vec3f {
+	v := this.MultVec4(Vec4f{p.X, p.Y, p.Z, 1})
+	if v.W != 1 {
+		return Vec3f{v.X / v.W, v.Y / v.W, v.Z / v.W}
+	}
+	return Vec3f{v.X, v.Y, v.Z}
+}
+
+// Transform the direction by the matrix, treating it as [x,y,z,0].
+// Only the upper 3x3 is applied, translation is ignored.
+// Returns a new vector with the result.
+func (this Mat4f) TransformDirection(d Vec3f) Vec3f {
+	return this.UpperMat3().MultVec3(d)
+}
+
+// Transform the surface normal by the inverse-transpose of the upper 3x3, so
+// that it stays perpendicular to the surface under non-uniform scaling.
+// The upper 3x3 must be invertible.
+// Returns a new unit length vector with the result.
+func (this Mat4f) TransformNormal(n Vec3f) Vec3f {
+	return this.UpperMat3().Inverse().Transpose().MultVec3(n).Normalize()
+}
+
+// Creates a rotation matrix from the given quaternion. Return this
+func (this *Mat4f) FromQuat(q Quatf) *Mat4f {
+	*this = q.Mat4f()
+	return this
+}
+
+// Returns the quaternion represented by this rotation matrix.
+func (this Mat4f) Quatf() Quatf {
+	q := Quatf{}
+	q.FromMat4(this)
+	return q
+}
+
+// Set the matrix to the composition of a translation, rotation and scale
+// (ie. T * R * S), so the scale is applied first. r must be unit length.
+// Return this
+func (this *Mat4f) FromTRS(t Vec3f, r Quatf, s Vec3f) *Mat4f {
+	*this = r.Mat4f()
+	for row := 0; row < 3; row++ {
+		this.mat[row*4+0] *= s.X
+		this.mat[row*4+1] *= s.Y
+		this.mat[row*4+2] *= s.Z
+	}
+	this.mat[3] = t.X
+	this.mat[7] = t.Y
+	this.mat[11] = t.Z
+	return this
+}
+
+// Set this as the projection matrix. See Mat4.ToPerspective
+func (this *Mat4f) ToPerspective(fovy, aspect, near, far float32) *Mat4f {
+	m := Mat4{}
+	m.ToPerspective(float64(fovy), float64(aspect), float64(near), float64(far))
+	*this = m.Mat4f()
+	return this
+}
+
+// Set this as the projection matrix for the clip space. See Mat4.ToPerspectiveClip
+func (this *Mat4f) ToPerspectiveClip(fovy, aspect, near, far float32, clip ClipSpace) *Mat4f {
+	m := Mat4{}
+	m.ToPerspectiveClip(float64(fovy), float64(aspect), float64(near), float64(far), clip)
+	*this = m.Mat4f()
+	return this
+}
+
+// Set this as the projection matrix. See Mat4.ToFrustum
+func (this *Mat4f) ToFrustum(left, right, bottom, top, near, far float32) *Mat4f {
+	m := Mat4{}
+	m.ToFrustum(float64(left), float64(right), float64(bottom), float64(top), float64(near), float64(far))
+	*this = m.Mat4f()
+	return this
+}
+
+// Set this as the projection matrix for the clip space. See Mat4.ToFrustumClip
+func (this *Mat4f) ToFrustumClip(left, right, bottom, top, near, far float32, clip ClipSpace) *Mat4f {
+	m := Mat4{}
+	m.ToFrustumClip(float64(left), float64(right), float64(bottom), float64(top), float64(near), float64(far), clip)
+	*this = m.Mat4f()
+	return this
+}
+
+// Set this as the projection matrix. See Mat4.ToOrtho
+func (this *Mat4f) ToOrtho(left, right, bottom, top, near, far float32) *Mat4f {
+	m := Mat4{}
+	m.ToOrtho(float64(left), float64(right), float64(bottom), float64(top), float64(near), float64(far))
+	*this = m.Mat4f()
+	return this
+}
+
+// Set this as the projection matrix for the clip space. See Mat4.ToOrthoClip
+func (this *Mat4f) ToOrthoClip(left, right, bottom, top, near, far float32, clip ClipSpace) *Mat4f {
+	m := Mat4{}
+	m.ToOrthoClip(float64(left), float64(right), float64(bottom), float64(top), float64(near), float64(far), clip)
+	*this = m.Mat4f()
+	return this
+}
+
+// Set this as a rotation matrix about the axis [x,y,z] with the given angle
+// (radians). See Mat4.FromAxisAngle
+func (this *Mat4f) FromAxisAngle(angle, x, y, z float32) *Mat4f {
+	m := Mat4{}
+	m.FromAxisAngle(float64(angle), float64(x), float64(y), float64(z))
+	*this = m.Mat4f()
+	return this
+}
+
+// Return the angle (radians) and axis of this rotation matrix.
+// See Mat4.AxisAngle
+func (this Mat4f) AxisAngle() (angle, x, y, z float32) {
+	a, x64, y64, z64 := mat3AxisAngle(this.UpperMat3().Mat3(), epsilon32)
+	return float32(a), float32(x64), float32(y64), float32(z64)
+}
+
+// Set this as a rotation matrix using the specified pitch, yaw and roll
+// (radians). See Mat4.FromEuler
+func (this *Mat4f) FromEuler(pitch, yaw, roll float32) *Mat4f {
+	m := Mat4{}
+	m.FromEuler(float64(pitch), float64(yaw), float64(roll))
+	*this = m.Mat4f()
+	return this
+}
+
+// Return the pitch, yaw and roll euler angles (radians) of the rotation.
+// See Mat4.Euler
+func (this Mat4f) Euler() (pitch, yaw, roll float32) {
+	p, y, r := this.Mat4().Euler()
+	return float32(p), float32(y), float32(r)
+}
+
+// Set this as the rotation from the euler angles (radians) applied in the
+// given order. See Mat4.FromEulerOrder
+func (this *Mat4f) FromEulerOrder(order EulerOrder, a, b, c float32) *Mat4f {
+	m := Mat4{}
+	m.FromEulerOrder(order, float64(a), float64(b), float64(c))
+	*this = m.Mat4f()
+	return this
+}
+
+// Return the euler angles (radians) of the rotation in the given order.
+// See Mat4.EulerOrder
+func (this Mat4f) EulerOrder(order EulerOrder) (a, b, c float32) {
+	a64, b64, c64 := this.Mat4().EulerOrder(order)
+	return float32(a64), float32(b64), float32(c64)
+}
+
+// Return true if the matrix is the identity matrix. See Mat4.IsIdentity
+func (this Mat4f) IsIdentity() bool {
+	return this.Mat4().IsIdentity()
+}
+
+// Check to see if the matrix is a valid rotation matrix. See Mat4.IsRotation
+func (this Mat4f) IsRotation() bool {
+	return this.Mat4().IsRotation()
+}
+
+// Decompose the matrix into a translation, rotation and scale.
+// See Mat4.Decompose
+func (this Mat4f) Decompose() (t Vec3f, r Quatf, s Vec3f, ok bool) {
+	t64, r64, s64, ok := this.Mat4().Decompose()
+	return t64.Vec3f(), r64.Quatf(), s64.Vec3f(), ok
+}
+
+// Load the matrix with 16 float64 values.
+// Specified in Row-Major order.
+func (this *Mat4f) Load64(m [16]float64) *Mat4f {
+	for k, v := range m {
+		this.mat[k] = float32(v)
+	}
+	return this
+}
+
+// convert to the float64 Mat4
+func (this Mat4f) Mat4() (out Mat4) {
+	for k, v := range this.mat {
+		out.mat[k] = float64(v)
+	}
+	return
+}
+
+// convert to the float32 Mat4f
+func (this Mat4) Mat4f() (out Mat4f) {
+	return *out.Load64(this.mat)
+}
@@ -0,0 +1,1662 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+// Test creation with 16 values
+// Test Equals with matrices
+func TestNewMat4f(t *testing.T) {
+	m := NewMat4f(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16)
+	m2 := NewMat4f(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16)
+	if m.Eq(*m2) == false {
+		t.Errorf("TestNewMat4f ")
+	}
+}
+
+// Test Get
+// Test Set
+func TestGetterSetterMat4f(t *testing.T) {
+	cases := []struct {
+		orig          *Mat4f
+		x, y          int
+		wantBeforeSet float32
+		wantAfterSet  float32
+	}{
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 0, 0, 1, 10},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 1, 0, 2, 20},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 2, 0, 3, 30},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 3, 0, 4, 40},
+
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 0, 1, 5, 50},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 1, 1, 6, 60},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 2, 1, 7, 70},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 3, 1, 8, 80},
+
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 0, 2, 9, 90},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 1, 2, 10, 100},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 2, 2, 11, 110},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 3, 2, 12, 120},
+
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 0, 3, 13, 130},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, 1, 3, 14, 130},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, -15, 16}}, 2, 3, -15, -150},
+		{&Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, -16}}, 3, 3, -16, -160},
+	}
+
+	for testIndex, c := range cases {
+		get := c.orig.Get(c.y, c.x)
+		if get != c.wantBeforeSet {
+			t.Errorf("TestGetterSetterMat4f wantBeforeSet %d %v", testIndex, get)
+		}
+
+		get = c.orig.Set(c.y, c.x, c.wantAfterSet).Get(c.y, c.x)
+		if get != c.wantAfterSet {
+			t.Errorf("TestGetterSetterMat4f wantAfterSet %d %v", testIndex, get)
+		}
+	}
+
+	orig := &Mat4f{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}
+	for k, _ := range orig.Dump() {
+		get := orig.At(k)
+		if get != float32(k+1) {
+			t.Errorf("TestGetterSetterMat4f At %d %v", k, get)
+		}
+
+		orig.SetAt(k, float32((k+1)*10))
+		get = orig.At(k)
+		if get != float32((k+1)*10) {
+			t.Errorf("TestGetterSetterMat4f SetAt %d", k)
+		}
+	}
+}
+
+// Test Load Array
+// Test Dump
+// Test Dump
+func TestLoadDumpMat4f(t *testing.T) {
+	cases := []struct {
+		loadArray [16]float32
+	}{
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{[16]float32{-1, -2, -3, -4, -5, -6, -7, -8, -9, -10, -11, -12, -13, -14, -15, -16}},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.Load(c.loadArray)
+		get := m.Dump()
+
+		for k, _ := range get {
+			if get[k] != c.loadArray[k] {
+				t.Errorf("TestLoadDumpMat4f %d", testIndex)
+				break
+			}
+		}
+	}
+
+	m.Load([16]float32{1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15, 4, 8, 12, 16})
+	get := m.DumpOpenGL()
+	for k, _ := range get {
+		if get[k] != cases[0].loadArray[k] {
+			t.Errorf("TestDumpOpenGLMat4f")
+			break
+		}
+	}
+
+}
+
+func TestRowMat4f(t *testing.T) {
+	cases := []struct {
+		orig     [16]float32
+		rowIndex int
+		want     [4]float32
+	}{
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 0, [4]float32{1, 2, 3, 4}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 1, [4]float32{5, 6, 7, 8}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 2, [4]float32{9, 10, 11, 12}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 3, [4]float32{13, 14, 15, 16}},
+	}
+
+	m := &Mat4f{}
+	var x, y, z, w float32
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		x, y, z, w = m.Row(c.rowIndex)
+		if x != c.want[0] || y != c.want[1] || z != c.want[2] || w != c.want[3] {
+			t.Errorf("TestRowMat4f %d", testIndex)
+		}
+	}
+}
+
+func TestSetRowMat4f(t *testing.T) {
+	cases := []struct {
+		orig       [16]float32
+		rowIndex   int
+		x, y, z, w float32
+	}{
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 0, -1, -2, -3, -4},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 1, -5, -6, -7, -8},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 2, -9, -10, -11, -12},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 3, -13, -14, -15, -16},
+	}
+
+	m := &Mat4f{}
+	var x, y, z, w float32
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		m.SetRow(c.rowIndex, c.x, c.y, c.z, c.w)
+		x, y, z, w = m.Row(c.rowIndex)
+		if x != c.x || y != c.y || z != c.z || w != c.w {
+			t.Errorf("TestSetRowMat4f %d", testIndex)
+		}
+	}
+}
+
+func TestColMat4f(t *testing.T) {
+	cases := []struct {
+		orig     [16]float32
+		colIndex int
+		want     [4]float32
+	}{
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 0, [4]float32{1, 5, 9, 13}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 1, [4]float32{2, 6, 10, 14}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 2, [4]float32{3, 7, 11, 15}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 3, [4]float32{4, 8, 12, 16}},
+	}
+
+	m := &Mat4f{}
+	var x, y, z, w float32
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		x, y, z, w = m.Col(c.colIndex)
+		if x != c.want[0] || y != c.want[1] || z != c.want[2] || w != c.want[3] {
+			t.Errorf("TestColMat4f %d", testIndex)
+		}
+	}
+}
+
+func TestSetColMat4f(t *testing.T) {
+	cases := []struct {
+		orig       [16]float32
+		colIndex   int
+		x, y, z, w float32
+	}{
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 0, -1, -2, -3, -4},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 1, -5, -6, -7, -8},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 2, -9, -10, -11, -12},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 3, -13, -14, -15, -16},
+	}
+
+	m := &Mat4f{}
+	var x, y, z, w float32
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		m.SetCol(c.colIndex, c.x, c.y, c.z, c.w)
+		x, y, z, w = m.Col(c.colIndex)
+		if x != c.x || y != c.y || z != c.z || w != c.w {
+			t.Errorf("TestSetColMat4f %d", testIndex)
+		}
+	}
+}
+
+func TestAddScalarMat4f(t *testing.T) {
+	cases := []struct {
+		orig  [16]float32
+		value float32
+	}{
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 0},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 1},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, -1},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 0},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 1},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, -1},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		ret_mat := m.AddScalar(c.value)
+
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]+c.value {
+				t.Errorf("TestAddScalarMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.AddInScalar(c.value)
+		if ret_mat2 != m {
+			t.Errorf("TestAddInScalarMat4f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]+c.value {
+				t.Errorf("TestAddInScalarMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestSubScalarMat4f(t *testing.T) {
+	cases := []struct {
+		orig  [16]float32
+		value float32
+	}{
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 0},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 1},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, -1},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 0},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 1},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, -1},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		ret_mat := m.SubScalar(c.value)
+
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]-c.value {
+				t.Errorf("TestSubScalarMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.SubInScalar(c.value)
+		if ret_mat2 != m {
+			t.Errorf("TestSubInScalarMat4f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]-c.value {
+				t.Errorf("TestSubInScalarMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestMultScalarMat4f(t *testing.T) {
+	cases := []struct {
+		orig  [16]float32
+		value float32
+	}{
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 0},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 1},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, -1},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 0},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 1},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, -1},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		ret_mat := m.MultScalar(c.value)
+
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]*c.value {
+				t.Errorf("TestMultScalarMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.MultInScalar(c.value)
+		if ret_mat2 != m {
+			t.Errorf("TestMultInScalarMat4f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]*c.value {
+				t.Errorf("TestMultInScalarMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestDivScalarMat4f(t *testing.T) {
+	cases := []struct {
+		orig  [16]float32
+		value float32
+	}{
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 1},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, -1},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 1},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, -1},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 2},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, -2},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		ret_mat := m.DivScalar(c.value)
+
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]/c.value {
+				t.Errorf("TestDivScalarMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.DivInScalar(c.value)
+		if ret_mat2 != m {
+			t.Errorf("TestDivInScalarMat4f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if get[k] != c.orig[k]/c.value {
+				t.Errorf("TestDivInScalarMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestAddMat4f(t *testing.T) {
+	cases := []struct {
+		orig, other [16]float32
+	}{
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{-1, -2, -3, -4, -5, -6, -7, -8, -9, -10, -11, -12, -13, -14, -15, -16}},
+	}
+
+	m := Mat4f{}
+	m2 := Mat4f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		m2.Load(c.other)
+
+		ret_mat := m.Add(m2)
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if closeEq32(get[k], c.orig[k]+c.other[k], epsilon32) == false {
+				t.Errorf("TestAddMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.AddIn(m2)
+		if ret_mat2 != &m {
+			t.Errorf("TestAddInMat4f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if closeEq32(get[k], c.orig[k]+c.other[k], epsilon32) == false {
+				t.Errorf("TestAddInMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestSubMat4f(t *testing.T) {
+	cases := []struct {
+		orig, other [16]float32
+	}{
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{-1, -2, -3, -4, -5, -6, -7, -8, -9, -10, -11, -12, -13, -14, -15, -16}},
+	}
+
+	m := Mat4f{}
+	m2 := Mat4f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		m2.Load(c.other)
+
+		ret_mat := m.Sub(m2)
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if closeEq32(get[k], c.orig[k]-c.other[k], epsilon32) == false {
+				t.Errorf("TestSubMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := m.SubIn(m2)
+		if ret_mat2 != &m {
+			t.Errorf("TestSubInMat4f %d", testIndex)
+		}
+
+		get = ret_mat2.Dump()
+		for k, _ := range c.orig {
+			if closeEq32(get[k], c.orig[k]-c.other[k], epsilon32) == false {
+				t.Errorf("TestSubInMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestMultMat4f(t *testing.T) {
+	cases := []struct {
+		orig, other, want [16]float32
+	}{
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+
+		{[16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1},
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}},
+
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1},
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}},
+
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{90, 100, 110, 120, 202, 228, 254, 280, 314, 356, 398, 440, 426, 484, 542, 600}},
+
+		{[16]float32{5, 6, 7, 8, 1, 2, 3, 4, 13, 14, 15, 16, 9, 10, 11, 12},
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{202, 228, 254, 280, 90, 100, 110, 120, 426, 484, 542, 600, 314, 356, 398, 440}},
+
+		{
+			[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{5, 6, 7, 8, 1, 2, 3, 4, 13, 14, 15, 16, 9, 10, 11, 12},
+			[16]float32{82, 92, 102, 112, 194, 220, 246, 272, 306, 348, 390, 432, 418, 476, 534, 592}},
+	}
+
+	orig := Mat4f{}
+	other := Mat4f{}
+	for testIndex, c := range cases {
+		orig.Load(c.orig)
+		other.Load(c.other)
+
+		ret_mat := orig.Mult(other)
+		get := ret_mat.Dump()
+		for k, _ := range c.orig {
+			if closeEq32(get[k], c.want[k], epsilon32) == false {
+				t.Errorf("TestMultMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		ret_mat2 := orig.MultIn(other)
+		if ret_mat2 != &orig {
+			t.Errorf("TestMultInMat4f %d", testIndex)
+		}
+	}
+}
+
+func TestIdentityMat4f(t *testing.T) {
+	m := &Mat4f{}
+	m.Load([16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	m.ToIdentity()
+
+	get := m.Dump()
+	want := [16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}
+	for k, _ := range get {
+		if want[k] != get[k] {
+			t.Errorf("TestIdentity %d", k)
+			break
+		}
+	}
+}
+
+func TestTransposeMat4f(t *testing.T) {
+	cases := []struct {
+		orig, want [16]float32
+	}{
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+
+		{[16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1},
+			[16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}},
+
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			[16]float32{1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15, 4, 8, 12, 16}},
+	}
+
+	orig := &Mat4f{}
+	for testIndex, c := range cases {
+		orig.Load(c.orig)
+		get := orig.Transpose().Dump()
+
+		for k, _ := range c.want {
+			if closeEq32(get[k], c.want[k], epsilon32) == false {
+				t.Errorf("TestTransposeMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+
+		orig.Load(c.orig)
+		get = orig.TransposeIn().Dump()
+		for k, _ := range c.want {
+			if closeEq32(get[k], c.want[k], epsilon32) == false {
+				t.Errorf("TestTransposeInMat4f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestDeterminantMat4f(t *testing.T) {
+	want := float32(0.554020973727016224)
+	m := &Mat4f{}
+	m.Load([16]float32{
+		0.5 * 0.5,
+		-0.5 * 0.866,
+		0.866,
+		0,
+
+		0.866*0.866*0.866 + 0.5*0.5,
+		-0.866*0.866*0.866 + 0.5*0.5,
+		0.866 * 0.5,
+		0,
+
+		-0.5*0.866*0.5 + 0.866*0.866,
+		0.5*0.866*0.866 + 0.866*0.5,
+		-0.5 * 0.5,
+		0,
+
+		0, 0, 0, 1})
+
+	get := m.Determinant()
+	if closeEq32(get, want, epsilon32) == false {
+		t.Errorf("TestDeterminantMat4f %v", get)
+	}
+}
+
+func TestAdjointMat4f(t *testing.T) {
+	m := &Mat4f{}
+	m.Load([16]float32{
+		0.5 * 0.5,
+		-0.5 * 0.866,
+		0.866,
+		0,
+
+		0.866*0.866*0.866 + 0.5*0.5,
+		-0.866*0.866*0.866 + 0.5*0.5,
+		0.866 * 0.5,
+		0,
+
+		-0.5*0.866*0.5 + 0.866*0.866,
+		0.5*0.866*0.866 + 0.866*0.5,
+		-0.5 * 0.5,
+		0,
+
+		0, 0, 0, 1})
+
+	m2 := m.Adjoint()
+	get := m2.Dump()
+	want := [16]float32{
+		-0.249989, 0.591459, 0.158445, 0,
+		0.455852, -0.524473, 0.670684, 0,
+		0.939841, -0.432981, 0.289602, 0,
+		0, 0, 0, 0.554021}
+
+	for k, _ := range want {
+		// NOTE: the check uses lower precision because
+		// the adjoint values I got from wolframalpha
+		// only went up to 6 places
+		if closeEq32(get[k], want[k], 0.0001) == false {
+			t.Errorf("TestAdjointMat4f %d %v %v", k, get[k], want[k])
+			break
+		}
+	}
+}
+
+func TestInverseMat4f(t *testing.T) {
+	m := &Mat4f{}
+	cases := []struct {
+		orig, want        [16]float32
+		want_inverse_flag bool
+	}{
+		{[16]float32{
+			0.5 * 0.5,
+			-0.5 * 0.866,
+			0.866,
+			0,
+
+			0.866*0.866*0.866 + 0.5*0.5,
+			-0.866*0.866*0.866 + 0.5*0.5,
+			0.866 * 0.5,
+			0,
+
+			-0.5*0.866*0.5 + 0.866*0.866,
+			0.5*0.866*0.866 + 0.866*0.5,
+			-0.5 * 0.5,
+			0,
+
+			0, 0, 0, 1}, [16]float32{
+			-0.451227, 1.06758, 0.285991, 0,
+			0.822806, -0.946666, 1.21058, 0,
+			1.6964, -0.781524, 0.522727, 0,
+			0, 0, 0, 1}, true},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, false},
+		{[16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1},
+			[16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}, true},
+		{[16]float32{
+			cos32(math.Pi / 2), -sin32(math.Pi / 2), 0, 0,
+			sin32(math.Pi / 2), cos32(math.Pi / 2), 0, 0,
+			0, 0, 1, 0,
+			0, 0, 0, 1},
+			[16]float32{
+				cos32(math.Pi / 2), sin32(math.Pi / 2), 0, 0,
+				-sin32(math.Pi / 2), cos32(math.Pi / 2), 0, 0,
+				0, 0, 1, 0,
+				0, 0, 0, 1}, true},
+	}
+
+	for testIndex, c := range cases {
+		m.Load(c.orig)
+		get_inverse_flag := m.HasInverse()
+		if get_inverse_flag != c.want_inverse_flag {
+			t.Errorf("TestInverseMat4f %d %v", testIndex, get_inverse_flag)
+			continue
+		}
+		if get_inverse_flag == false {
+			continue
+		}
+
+		m2 := m.Inverse()
+		get := m2.Dump()
+		for k, _ := range c.want {
+			if closeEq32(get[k], c.want[k], 0.0001) == false {
+				t.Errorf("TestInverseMat4f %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+	}
+}
+
+func TestToTranslateMat4f(t *testing.T) {
+	cases := []struct {
+		x, y, z float32
+		want    [16]float32
+	}{
+		{0, 0, 0, [16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}},
+		{1, 2, 3, [16]float32{1, 0, 0, 1, 0, 1, 0, 2, 0, 0, 1, 3, 0, 0, 0, 1}},
+		{-1, -2, -3, [16]float32{1, 0, 0, -1, 0, 1, 0, -2, 0, 0, 1, -3, 0, 0, 0, 1}},
+	}
+
+	orig := &Mat4f{}
+	for testIndex, c := range cases {
+
+		orig.ToTranslate(c.x, c.y, c.z)
+		get := orig.Dump()
+		for k, _ := range c.want {
+			if c.want[k] != get[k] {
+				t.Errorf("TestToTranslateMat4f %d", testIndex)
+				break
+			}
+		}
+	}
+}
+
+func TestToScaleMat4f(t *testing.T) {
+	cases := []struct {
+		x, y, z float32
+		want    [16]float32
+	}{
+		{0, 0, 0, [16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}},
+		{1, 2, 3, [16]float32{1, 0, 0, 0, 0, 2, 0, 0, 0, 0, 3, 0, 0, 0, 0, 1}},
+		{-1, -2, -3, [16]float32{-1, 0, 0, 0, 0, -2, 0, 0, 0, 0, -3, 0, 0, 0, 0, 1}},
+	}
+
+	orig := &Mat4f{}
+	for testIndex, c := range cases {
+
+		orig.ToScale(c.x, c.y, c.z)
+		get := orig.Dump()
+		for k, _ := range c.want {
+			if c.want[k] != get[k] {
+				t.Errorf("TestToScaleMat4f %d", testIndex)
+				break
+			}
+		}
+	}
+}
+
+func TestToShearMat4f(t *testing.T) {
+	cases := []struct {
+		x, y, z float32
+		want    [16]float32
+	}{
+		{0, 0, 0, [16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}},
+		{1, 2, 3, [16]float32{
+			0, -3, 2, 0,
+			3, 0, -1, 0,
+			-2, 1, 0, 0,
+			0, 0, 0, 1,
+		}},
+		{-1, -2, -3, [16]float32{
+			0, 3, -2, 0,
+			-3, 0, 1, 0,
+			2, -1, 0, 0,
+			0, 0, 0, 1,
+		}},
+	}
+
+	orig := &Mat4f{}
+	for testIndex, c := range cases {
+
+		orig.ToShear(c.x, c.y, c.z)
+		get := orig.Dump()
+		for k, _ := range c.want {
+			if c.want[k] != get[k] {
+				t.Errorf("TestToScaleMat4f %d", testIndex)
+				break
+			}
+		}
+	}
+}
+
+func TestToLookAtMat4f(t *testing.T) {
+	cases := []struct {
+		eye, center, up Vec3f
+		point           Vec3f
+		want            Vec3f
+	}{
+		{Vec3f{0, 0, 5}, Vec3f{0, 0, 0}, Vec3f{0, 1, 0}, Vec3f{0, 0, 0}, Vec3f{0, 0, -5}},
+		{Vec3f{0, 0, 5}, Vec3f{0, 0, 0}, Vec3f{0, 1, 0}, Vec3f{1, 2, 5}, Vec3f{1, 2, 0}},
+		{Vec3f{1, 2, 3}, Vec3f{1, 2, 0}, Vec3f{0, 1, 0}, Vec3f{0, 0, 0}, Vec3f{-1, -2, -3}},
+		{Vec3f{0, 0, 0}, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}, Vec3f{5, 0, 0}, Vec3f{0, 0, -5}},
+		{Vec3f{0, 0, 0}, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}},
+		{Vec3f{0, 0, 0}, Vec3f{0, -1, 0}, Vec3f{0, 0, -1}, Vec3f{0, -2, 0}, Vec3f{0, 0, -2}},
+		{Vec3f{0, 0, 0}, Vec3f{0, -1, 0}, Vec3f{0, 0, -1}, Vec3f{0, 0, -1}, Vec3f{0, 1, 0}},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.ToLookAt(c.eye, c.center, c.up)
+		get := m.MultVec3(c.point)
+		if !get.Eq(c.want) {
+			t.Errorf("TestToLookAtMat4f %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+		r := m.UpperMat3()
+		if !r.Mult(r.Transpose()).Eq(Mat3fIdentity) || !closeEq32(r.Determinant(), 1, epsilon32) {
+			t.Errorf("TestToLookAtMat4f rotation %d \n%v\n\n", testIndex, m)
+		}
+	}
+}
+
+func TestUpperMat3Mat4f(t *testing.T) {
+	common_cases := []struct {
+		mat4_vals [16]float32
+		mat3_vals [9]float32
+	}{
+		{[16]float32{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, [9]float32{0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{[16]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, [9]float32{1, 1, 1, 1, 1, 1, 1, 1, 1}},
+		{[16]float32{-1, -2, -3, -4, -5, -6, -7, -8, -9, -10, -11, -12, -13, -14, -15}, [9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+	}
+
+	m3 := &Mat3f{}
+	m4 := Mat4f{}
+	for testIndex, c := range common_cases {
+		m4.Load(c.mat4_vals)
+		m3.Load(c.mat3_vals)
+
+		get_m4 := m4.SetUpperMat3(*m3)
+		if get_m4 != &m4 {
+			t.Errorf("TestUpperMat3Mat4f %d", testIndex)
+		}
+
+		mm := m4.UpperMat3()
+		get_m3 := mm.Dump()
+		for k, _ := range c.mat3_vals {
+			if !closeEq32(c.mat3_vals[k], get_m3[k], epsilon32) {
+				t.Errorf("TestUpperMat3Mat4f %d %d", testIndex, k)
+				break
+			}
+		}
+	}
+}
+
+func TestMultVec3Mat4f(t *testing.T) {
+	cases := []struct {
+		orig_mat     [16]float32
+		orig_v, want Vec3f
+	}{
+		{[16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{[16]float32{2, 0, 0, 0, 0, 2, 0, 0, 0, 0, 2, 0, 0, 0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{2, 0, 0}},
+		{[16]float32{2, 0, 0, 0, 0, 2, 0, 0, 0, 0, 2, 0, 0, 0, 0, 1}, Vec3f{1, 1, 1}, Vec3f{2, 2, 2}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, Vec3f{1, 0, 0}, Vec3f{5, 13, 21}},
+		{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, Vec3f{1, 2, 3}, Vec3f{18, 46, 74}},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.Load(c.orig_mat)
+		get := m.MultVec3(c.orig_v)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestMultVec3Mat4f %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+	}
+}
+
+func TestConvertMat4f(t *testing.T) {
+	m := Mat4{}
+	m.ToPerspective(math.Pi/2, 1, 1, 10)
+	mf := m.Mat4f()
+
+	want := m.DumpOpenGLf32()
+	if get := mf.DumpOpenGL(); get != want {
+		t.Errorf("TestConvertMat4f DumpOpenGL\n%v\n%v", get, want)
+	}
+	back := mf.Mat4()
+	for k := 0; k < 16; k++ {
+		if !closeEq(back.At(k), m.At(k), 1e-6) {
+			t.Errorf("TestConvertMat4f\n%v\n%v", &back, &m)
+			break
+		}
+	}
+
+	// the near and far planes map to -1 and 1 after the divide
+	if get := mf.TransformPoint(Vec3f{5, -5, -10}); !get.Eq(Vec3f{0.5, -0.5, 1}) {
+		t.Errorf("TestConvertMat4f TransformPoint %v", get)
+	}
+
+	m3 := Mat3{}
+	m3.ToRotateZ(1)
+	m3f := m3.Mat3f()
+	get := m3f.Mat3()
+	for k := 0; k < 9; k++ {
+		if !closeEq(get.At(k), m3.At(k), 1e-6) {
+			t.Errorf("TestConvertMat4f mat3\n%v\n%v", get, m3)
+			break
+		}
+	}
+}
+
+func TestFromTRSMat4f(t *testing.T) {
+	q := Quatf{}
+	q.FromAxisAngle(math.Pi/2, 0, 0, 1)
+	m := Mat4f{}
+	m.FromTRS(Vec3f{1, 2, 3}, q, Vec3f{2, 2, 2})
+
+	cases := []struct {
+		orig, want Vec3f
+	}{
+		{Vec3f{0, 0, 0}, Vec3f{1, 2, 3}},
+		{Vec3f{1, 0, 0}, Vec3f{1, 4, 3}},
+		{Vec3f{0, 1, 1}, Vec3f{-1, 2, 5}},
+	}
+	for testIndex, c := range cases {
+		if get := m.TransformPoint(c.orig); !get.Eq(c.want) {
+			t.Errorf("TestFromTRSMat4f %d %v %v", testIndex, get, c.want)
+		}
+	}
+}
+
+func TestTryInverseMat4f(t *testing.T) {
+	cases := []struct {
+		m, want Mat4f
+		ok      bool
+	}{
+		{Mat4fIdentity, Mat4fIdentity, true},
+		{Mat4f{[16]float32{2, 0, 0, 0, 0, 3, 0, 0, 0, 0, 4, 0, 0, 0, 0, 5}}, Mat4f{[16]float32{0.5, 0, 0, 0, 0, 0.3333333333333333, 0, 0, 0, 0, 0.25, 0, 0, 0, 0, 0.2}}, true},
+		{Mat4f{[16]float32{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}}, Mat4f{}, false},
+		{Mat4f{}, Mat4f{}, false},
+		// singular is judged relative to the entries, not by the determinant
+		{Mat4f{[16]float32{1e-2, 0, 0, 0, 0, 1e-2, 0, 0, 0, 0, 1e-2, 0, 0, 0, 0, 1e-2}}, Mat4f{[16]float32{1e2, 0, 0, 0, 0, 1e2, 0, 0, 0, 0, 1e2, 0, 0, 0, 0, 1e2}}, true},
+		{Mat4f{[16]float32{1e10, 2e10, 3e10, 4e10, 5e10, 6e10, 7e10, 8e10, 9e10, 10e10, 11e10, 12e10, 13e10, 14e10, 15e10, 16e10}}, Mat4f{}, false},
+	}
+
+	for testIndex, c := range cases {
+		got, ok := c.m.TryInverse()
+		if ok != c.ok {
+			t.Errorf("TestTryInverseMat4f %d ok %v", testIndex, ok)
+		}
+		for k := 0; k < 16; k++ {
+			if !closeEq32(got.At(k), c.want.At(k), 1e-6) {
+				t.Errorf("TestTryInverseMat4f %d\n%v\n%v", testIndex, &got, &c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestToPerspectiveMat4f(t *testing.T) {
+	cases := []struct {
+		fovy, aspect, near, far float32
+		want                    [16]float32
+	}{
+		{90, 1, 1, 3, [16]float32{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -2, -3,
+			0, 0, -1, 0,
+		}},
+		{90, 2, 1, 3, [16]float32{
+			0.5, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -2, -3,
+			0, 0, -1, 0,
+		}},
+		{60, 1, 0.1, 100, [16]float32{
+			sqrt32(3), 0, 0, 0,
+			0, sqrt32(3), 0, 0,
+			0, 0, -100.1 / 99.9, -20 / 99.9,
+			0, 0, -1, 0,
+		}},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.ToPerspective(float32(Radians(float64(c.fovy))), c.aspect, c.near, c.far)
+		get := m.Dump()
+		for k, _ := range c.want {
+			if !closeEq32(get[k], c.want[k], epsilon32) {
+				t.Errorf("TestToPerspectiveMat4f %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+
+		// The near and far planes must map onto the OpenGL depth range [-1,1]
+		near := m.MultVec4(Vec4f{0, 0, -c.near, 1})
+		far := m.MultVec4(Vec4f{0, 0, -c.far, 1})
+		if !closeEq32(near.Z/near.W, -1, epsilon32) || !closeEq32(far.Z/far.W, 1, epsilon32) {
+			t.Errorf("TestToPerspectiveMat4f depth %d %v %v", testIndex, near, far)
+		}
+	}
+}
+
+func TestToFrustumMat4f(t *testing.T) {
+	cases := []struct {
+		left, right, bottom, top, near, far float32
+		want                                [16]float32
+	}{
+		{-1, 1, -1, 1, 1, 3, [16]float32{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -2, -3,
+			0, 0, -1, 0,
+		}},
+		{0, 2, 0, 1, 1, 3, [16]float32{
+			1, 0, 1, 0,
+			0, 2, 1, 0,
+			0, 0, -2, -3,
+			0, 0, -1, 0,
+		}},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.ToFrustum(c.left, c.right, c.bottom, c.top, c.near, c.far)
+		get := m.Dump()
+		for k, _ := range c.want {
+			if !closeEq32(get[k], c.want[k], epsilon32) {
+				t.Errorf("TestToFrustumMat4f %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+
+		// The corners of the near plane must map onto the corners of the NDC cube
+		v := m.MultVec4(Vec4f{c.left, c.bottom, -c.near, 1})
+		v.DivInScalar(v.W)
+		if !v.Eq(Vec4f{-1, -1, -1, 1}) {
+			t.Errorf("TestToFrustumMat4f bottom-left %d %v", testIndex, v)
+		}
+		v = m.MultVec4(Vec4f{c.right, c.top, -c.near, 1})
+		v.DivInScalar(v.W)
+		if !v.Eq(Vec4f{1, 1, -1, 1}) {
+			t.Errorf("TestToFrustumMat4f top-right %d %v", testIndex, v)
+		}
+	}
+
+	// A symmetric frustum is the same as a perspective matrix
+	p := &Mat4f{}
+	p.ToPerspective(float32(Radians(float64(90))), 1, 1, 3)
+	if !p.Eq(*m.ToFrustum(-1, 1, -1, 1, 1, 3)) {
+		t.Errorf("TestToFrustumMat4f perspective")
+	}
+}
+
+func TestToOrthoMat4f(t *testing.T) {
+	cases := []struct {
+		left, right, bottom, top, near, far float32
+		want                                [16]float32
+	}{
+		{-1, 1, -1, 1, 1, 3, [16]float32{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -1, -2,
+			0, 0, 0, 1,
+		}},
+		{0, 2, 0, 4, -1, 1, [16]float32{
+			1, 0, 0, -1,
+			0, 0.5, 0, -1,
+			0, 0, -1, 0,
+			0, 0, 0, 1,
+		}},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.ToOrtho(c.left, c.right, c.bottom, c.top, c.near, c.far)
+		get := m.Dump()
+		for k, _ := range c.want {
+			if !closeEq32(get[k], c.want[k], epsilon32) {
+				t.Errorf("TestToOrthoMat4f %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+
+		near := m.MultVec3(Vec3f{c.left, c.bottom, -c.near})
+		far := m.MultVec3(Vec3f{c.right, c.top, -c.far})
+		if !near.Eq(Vec3f{-1, -1, -1}) || !far.Eq(Vec3f{1, 1, 1}) {
+			t.Errorf("TestToOrthoMat4f %d %v %v", testIndex, near, far)
+		}
+	}
+}
+
+func TestProjectionClipSpaceMat4f(t *testing.T) {
+	cases := []struct {
+		clip     ClipSpace
+		wantNear float32
+		wantFar  float32
+		wantTopY float32
+	}{
+		{ClipSpaceOpenGL, -1, 1, 1},
+		{ClipSpaceD3D, 0, 1, 1},
+		{ClipSpaceVulkan, 0, 1, -1},
+		{ClipSpace{ReverseZ: true}, 1, -1, 1},
+		{ClipSpace{DepthZeroToOne: true, ReverseZ: true}, 1, 0, 1},
+		{ClipSpace{DepthZeroToOne: true, FlipY: true, ReverseZ: true}, 1, 0, -1},
+	}
+
+	var near, far float32 = 0.5, 20.0
+	var left, right, bottom, top float32 = -2.0, 1.0, -1.0, 3.0
+	ndc := func(m *Mat4f, v Vec3f) Vec3f {
+		p := m.MultVec4(Vec4f{v.X, v.Y, v.Z, 1})
+		return Vec3f{p.X / p.W, p.Y / p.W, p.Z / p.W}
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		// Perspective
+		m.ToPerspectiveClip(float32(Radians(float64(90))), 1, near, far, c.clip)
+		if get := ndc(m, Vec3f{0, near, -near}); !get.Eq(Vec3f{0, c.wantTopY, c.wantNear}) {
+			t.Errorf("TestProjectionClipSpaceMat4f perspective near %d %v", testIndex, get)
+		}
+		if get := ndc(m, Vec3f{0, far, -far}); !get.Eq(Vec3f{0, c.wantTopY, c.wantFar}) {
+			t.Errorf("TestProjectionClipSpaceMat4f perspective far %d %v", testIndex, get)
+		}
+
+		// Frustum
+		m.ToFrustumClip(left, right, bottom, top, near, far, c.clip)
+		if get := ndc(m, Vec3f{left, top, -near}); !get.Eq(Vec3f{-1, c.wantTopY, c.wantNear}) {
+			t.Errorf("TestProjectionClipSpaceMat4f frustum near %d %v", testIndex, get)
+		}
+		s := far / near
+		if get := ndc(m, Vec3f{right * s, bottom * s, -far}); !get.Eq(Vec3f{1, -c.wantTopY, c.wantFar}) {
+			t.Errorf("TestProjectionClipSpaceMat4f frustum far %d %v", testIndex, get)
+		}
+
+		// Ortho
+		m.ToOrthoClip(left, right, bottom, top, near, far, c.clip)
+		if get := ndc(m, Vec3f{left, top, -near}); !get.Eq(Vec3f{-1, c.wantTopY, c.wantNear}) {
+			t.Errorf("TestProjectionClipSpaceMat4f ortho near %d %v", testIndex, get)
+		}
+		if get := ndc(m, Vec3f{right, bottom, -far}); !get.Eq(Vec3f{1, -c.wantTopY, c.wantFar}) {
+			t.Errorf("TestProjectionClipSpaceMat4f ortho far %d %v", testIndex, get)
+		}
+
+		// Infinite far plane. The near plane is unchanged and points far away
+		// approach the far end of the depth range.
+		inf := c.clip
+		inf.InfiniteFar = true
+		m.ToPerspectiveClip(float32(Radians(float64(90))), 1, near, far, inf)
+		if get := ndc(m, Vec3f{0, 0, -near}); !get.Eq(Vec3f{0, 0, c.wantNear}) {
+			t.Errorf("TestProjectionClipSpaceMat4f infinite near %d %v", testIndex, get)
+		}
+		if get := ndc(m, Vec3f{0, 0, -1e12}); !closeEq32(get.Z, c.wantFar, 1e-9) {
+			t.Errorf("TestProjectionClipSpaceMat4f infinite far %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestToPerspectiveClipMat4f(t *testing.T) {
+	cases := []struct {
+		clip ClipSpace
+		want [16]float32
+	}{
+		{ClipSpaceOpenGL, [16]float32{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -2, -3,
+			0, 0, -1, 0,
+		}},
+		{ClipSpaceVulkan, [16]float32{
+			1, 0, 0, 0,
+			0, -1, 0, 0,
+			0, 0, -1.5, -1.5,
+			0, 0, -1, 0,
+		}},
+		{ClipSpace{DepthZeroToOne: true, ReverseZ: true}, [16]float32{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, 0.5, 1.5,
+			0, 0, -1, 0,
+		}},
+		{ClipSpace{InfiniteFar: true}, [16]float32{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -1, -2,
+			0, 0, -1, 0,
+		}},
+		{ClipSpace{DepthZeroToOne: true, ReverseZ: true, InfiniteFar: true}, [16]float32{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, 0, 1,
+			0, 0, -1, 0,
+		}},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		m.ToPerspectiveClip(float32(Radians(float64(90))), 1, 1, 3, c.clip)
+		get := m.Dump()
+		for k, _ := range c.want {
+			if !closeEq32(get[k], c.want[k], epsilon32) {
+				t.Errorf("TestToPerspectiveClipMat4f %d %d %v %v", testIndex, k, get[k], c.want[k])
+				break
+			}
+		}
+	}
+}
+
+func TestProjectionDumpOpenGLMat4f(t *testing.T) {
+	// OpenGL expects the matrices in column-major order
+	m := &Mat4f{}
+	m.ToPerspective(float32(Radians(float64(90))), 1, 1, 3)
+	want := [16]float32{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, -2, -1,
+		0, 0, -3, 0,
+	}
+	get := m.DumpOpenGL()
+	for k, _ := range want {
+		if !closeEq32(get[k], want[k], epsilon32) {
+			t.Errorf("TestProjectionDumpOpenGLMat4f perspective %d %v", k, get)
+			break
+		}
+	}
+
+	m.ToLookAt(Vec3f{1, 2, 3}, Vec3f{1, 2, 0}, Vec3f{0, 1, 0})
+	want = [16]float32{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		-1, -2, -3, 1,
+	}
+	get = m.DumpOpenGL()
+	for k, _ := range want {
+		if !closeEq32(get[k], want[k], epsilon32) {
+			t.Errorf("TestProjectionDumpOpenGLMat4f lookAt %d %v", k, get)
+			break
+		}
+	}
+}
+
+func TestFromAxisAngleMat4f(t *testing.T) {
+	cases := []struct {
+		angle     float32
+		axis      Vec3f
+		start_vec Vec3f
+		want      Vec3f
+	}{
+
+		//test basic rotations using a [1,0,0] vector
+		{90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{90, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{-90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{-90, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{360, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{180, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //7
+
+		//test basic rotations using a [0,1,0] vector
+		{90, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{90, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}},
+		{-90, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{-90, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{360, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{180, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}}, //15
+
+		// test negative axes
+		{90, Vec3f{0, -1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{90, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{90, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{-90, Vec3f{0, -1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{-90, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{-90, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{360, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{180, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //23
+
+		// test arbitraty axis
+		{360, Vec3f{1, 1, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{90, Vec3f{1, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0.5, 0.5, -0.7071067811}},
+		{45, Vec3f{1, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0.85355339059, 0.1464466094067, -0.5}}, //26
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		c.axis.NormalizeIn()
+		m.FromAxisAngle(float32(Radians(float64(c.angle))), c.axis.X, c.axis.Y, c.axis.Z)
+
+		get := m.MultVec3(c.start_vec)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestFromAxisAngleMat4f %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+	}
+}
+
+func TestAxisAngleMat4f(t *testing.T) {
+	cases := []struct {
+		angle, x, y, z float32
+	}{
+		//test basic rotations using a [1,0,0] vector
+		{90, 1, 0, 0},
+		{90, 0, 1, 0},
+		{90, 0, 0, 1},
+		{45, 1, 0, 0},
+		{45, 0, 1, 0},
+		{45, 0, 0, 1}, //5
+		{180, 1, 0, 0},
+		{180, 0, 1, 0},
+		{180, 0, 0, 1},
+		{90, 1, 1, 0},
+		{90, 1, 1, 0}, //10
+		{90, 0, -1, 1},
+		{45, 1, 0, 1},
+		{45, 0, 1, 0},
+		{45, 1, 0, 1},
+		{180, 1, -2, 0}, //15
+		{180, 0, 1, 20},
+		{180, 0, 20, 1},
+		{180, -4, 4, 1},
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range cases {
+		v := Vec3f{c.x, c.y, c.z}
+		v.NormalizeIn()
+		m.FromAxisAngle(float32(Radians(float64(c.angle))), v.X, v.Y, v.Z)
+		get_angle, get_x, get_y, get_z := m.AxisAngle()
+
+		if !closeEq32(float32(Degrees(float64(get_angle))), c.angle, epsilon32) ||
+			!closeEq32(get_x, v.X, epsilon32) ||
+			!closeEq32(get_y, v.Y, epsilon32) ||
+			!closeEq32(get_z, v.Z, epsilon32) {
+
+			if closeEq32(get_angle, math.Pi, epsilon32) &&
+				closeEq32(float32(math.Abs(float64(get_x))-math.Abs(float64(v.X))), 0, epsilon32) &&
+				closeEq32(float32(math.Abs(float64(get_y))-math.Abs(float64(v.Y))), 0, epsilon32) &&
+				closeEq32(float32(math.Abs(float64(get_z))-math.Abs(float64(v.Z))), 0, epsilon32) {
+				continue
+			} else {
+				t.Errorf("TestAxisAngleMat4f %d %v \n%f %f %f %f\n%f %f %f %f\n",
+					testIndex, v, float32(Degrees(float64(get_angle))), get_x, get_y, get_z, c.angle, v.X, v.Y, v.Z)
+			}
+		}
+	}
+}
+
+func TestFromEulerMat4f(t *testing.T) {
+	common_cases := []struct {
+		pitch, yaw, roll float32
+		start_vec        Vec3f
+		want             Vec3f
+	}{
+		{180, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 180, 0, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //2
+		{180, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{0, 0, 180, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}}, //5
+		{180, 0, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, -1}},
+		{0, 0, 180, Vec3f{0, 0, 1}, Vec3f{0, 0, 1}}, //8
+
+		{180, 0, 0, Vec3f{-1, 0, 0}, Vec3f{-1, 0, 0}},
+		{0, 180, 0, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}}, //11
+		{180, 0, 0, Vec3f{0, -1, 0}, Vec3f{0, 1, 0}},
+		{0, 180, 0, Vec3f{0, -1, 0}, Vec3f{0, -1, 0}},
+		{0, 0, 180, Vec3f{0, -1, 0}, Vec3f{0, 1, 0}}, //14
+		{180, 0, 0, Vec3f{0, 0, -1}, Vec3f{0, 0, 1}},
+		{0, 180, 0, Vec3f{0, 0, -1}, Vec3f{0, 0, 1}},
+		{0, 0, 180, Vec3f{0, 0, -1}, Vec3f{0, 0, -1}}, //17
+
+		{0, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{0, 0, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, 1}}, //2
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}},
+
+		//test basic rotations using a [0,1,0] vector
+		// pitch,yaw,roll
+		{0, 0, 90, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}},
+		{0, 90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{0, 0, -90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{0, -90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}}, //6
+
+		// test basic rotation using a [1,0,0] vector
+		{0, 0, 90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{0, 90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, -90, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{0, -90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //13
+
+		// basic rotation using a non major axis vector
+		{0, 0, 90, Vec3f{1, 1, 0}, Vec3f{-1, 1, 0}},
+		{0, 90, 0, Vec3f{1, -1, 0}, Vec3f{0, -1, -1}},
+		{90, 0, 0, Vec3f{-1, -1, 0}, Vec3f{-1, 0, -1}}, //16
+
+		// two rotations
+		{90, 0, 45, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{90, 45, 0, Vec3f{0, 0, 1}, Vec3f{0, -1, 0}},
+		{45, 90, 0, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}}, //20
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range common_cases {
+		// m = EulerToMat4(float32(Radians(float64(c.yaw))), float32(Radians(float64(c.pitch))), float32(Radians(float64(c.roll))))
+		m.FromEuler(float32(Radians(float64(c.pitch))), float32(Radians(float64(c.yaw))), float32(Radians(float64(c.roll))))
+		get := m.MultVec3(c.start_vec)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestFromEulerMat4f %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+	}
+}
+
+func TestEulerMat4f(t *testing.T) {
+	common_cases := []struct {
+		pitch, yaw, roll float32
+		start_vec        Vec3f
+		want             Vec3f
+	}{
+		{180, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 180, 0, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //2
+		{180, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{0, 0, 180, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}}, //5
+		{180, 0, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, -1}},
+		{0, 0, 180, Vec3f{0, 0, 1}, Vec3f{0, 0, 1}}, //8
+
+		{180, 0, 0, Vec3f{-1, 0, 0}, Vec3f{-1, 0, 0}},
+		{0, 180, 0, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}}, //11
+		{180, 0, 0, Vec3f{0, -1, 0}, Vec3f{0, 1, 0}},
+		{0, 180, 0, Vec3f{0, -1, 0}, Vec3f{0, -1, 0}},
+		{0, 0, 180, Vec3f{0, -1, 0}, Vec3f{0, 1, 0}}, //14
+		{180, 0, 0, Vec3f{0, 0, -1}, Vec3f{0, 0, 1}},
+		{0, 180, 0, Vec3f{0, 0, -1}, Vec3f{0, 0, 1}},
+		{0, 0, 180, Vec3f{0, 0, -1}, Vec3f{0, 0, -1}}, //17
+
+		{0, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{0, 0, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, 1}}, //20
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}},
+
+		//test basic rotations using a [0,1,0] vector
+		// pitch,yaw,roll
+		{0, 0, 90, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}}, //22
+		{0, 90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{0, 0, -90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{0, -90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}}, //28
+
+		// test basic rotation using a [1,0,0] vector
+		{0, 0, 90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}}, //29
+		{0, 90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, -90, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{0, -90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //35
+
+		// basic rotation using a non major axis vector
+		{0, 0, 90, Vec3f{1, 1, 0}, Vec3f{-1, 1, 0}},
+		{0, 90, 0, Vec3f{1, -1, 0}, Vec3f{0, -1, -1}},
+		{90, 0, 0, Vec3f{-1, -1, 0}, Vec3f{-1, 0, -1}}, //38
+
+		// two rotations
+		{90, 0, 45, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}}, //39
+		{90, 45, 0, Vec3f{0, 0, 1}, Vec3f{0, -1, 0}},
+		{45, 90, 0, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}}, //42
+	}
+
+	m := &Mat4f{}
+	for testIndex, c := range common_cases {
+		m.FromEuler(float32(Radians(float64(c.pitch))), float32(Radians(float64(c.yaw))), float32(Radians(float64(c.roll))))
+		x, y, z := m.Euler()
+
+		if closeEq32(float32(Degrees(float64(x))), c.pitch, epsilon32) && closeEq32(float32(Degrees(float64(y))), c.yaw, epsilon32) && closeEq32(float32(Degrees(float64(z))), c.roll, epsilon32) {
+			continue
+		}
+
+		// The euler angles we got back didn't match, but lets see if the rotation
+		// matrix it makes is still equivalent
+		m.FromEuler(x, y, z)
+		get := m.MultVec3(c.start_vec)
+		if get.Eq(c.want) {
+			continue
+		}
+
+		t.Errorf("TestEulerMat4f %d %f %f %f", testIndex, x, y, z)
+	}
+}
+
+func TestTransformPointMat4f(t *testing.T) {
+	persp := Mat4f{}
+	persp.ToPerspective(math.Pi/2, 1, 1, 10)
+	trans := Mat4f{}
+	trans.ToTranslate(1, 2, 3)
+
+	cases := []struct {
+		m            Mat4f
+		orig_v, want Vec3f
+	}{
+		{trans, Vec3f{1, 1, 1}, Vec3f{2, 3, 4}},
+		// the near and far planes map to -1 and 1 after the divide
+		{persp, Vec3f{0, 0, -1}, Vec3f{0, 0, -1}},
+		{persp, Vec3f{0, 0, -10}, Vec3f{0, 0, 1}},
+		{persp, Vec3f{2, 2, -2}, Vec3f{1, 1, 1.0 / 9}},
+		{persp, Vec3f{5, -5, -10}, Vec3f{0.5, -0.5, 1}},
+	}
+
+	for testIndex, c := range cases {
+		get := c.m.TransformPoint(c.orig_v)
+		if get.Sub(c.want).Length() > epsilon32 {
+			t.Errorf("TestTransformPointMat4f %d %v %v", testIndex, get, c.want)
+		}
+	}
+}
+
+func TestTransformDirectionMat4f(t *testing.T) {
+	trans := Mat4f{}
+	trans.ToTranslate(1, 2, 3)
+	scale := Mat4f{}
+	scale.ToScale(2, 3, 4)
+	rot := Mat4f{}
+	rot.FromAxisAngle(math.Pi/2, 0, 0, 1)
+
+	cases := []struct {
+		m            Mat4f
+		orig_v, want Vec3f
+	}{
+		{trans, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{scale, Vec3f{1, 1, 1}, Vec3f{2, 3, 4}},
+		{trans.Mult(rot), Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+	}
+
+	for testIndex, c := range cases {
+		get := c.m.TransformDirection(c.orig_v)
+		if get.Sub(c.want).Length() > epsilon32 {
+			t.Errorf("TestTransformDirectionMat4f %d %v %v", testIndex, get, c.want)
+		}
+	}
+}
+
+func TestTransformNormalMat4f(t *testing.T) {
+	scale := Mat4f{}
+	scale.ToScale(1, 4, 1)
+	rot := Mat4f{}
+	rot.FromAxisAngle(math.Pi/3, 1, 1, 0)
+	trans := Mat4f{}
+	trans.ToTranslate(5, -2, 1)
+	m := trans.Mult(rot.Mult(scale))
+
+	cases := []struct {
+		normal, tangent1, tangent2 Vec3f
+	}{
+		{Vec3f{1, 1, 0}, Vec3f{1, -1, 0}, Vec3f{0, 0, 1}},
+		{Vec3f{0, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{Vec3f{1, 2, 3}, Vec3f{2, -1, 0}, Vec3f{3, 0, -1}},
+	}
+
+	for testIndex, c := range cases {
+		get := m.TransformNormal(c.normal)
+		t1 := m.TransformDirection(c.tangent1)
+		t2 := m.TransformDirection(c.tangent2)
+		if !closeEq32(get.Length(), 1, epsilon32) ||
+			!closeEq32(get.Dot(t1), 0, epsilon32) ||
+			!closeEq32(get.Dot(t2), 0, epsilon32) {
+			t.Errorf("TestTransformNormalMat4f %d %v %v %v", testIndex, get, t1, t2)
+		}
+	}
+
+	// the normal must face the same way as a naively transformed one
+	n := m.TransformNormal(Vec3f{0, 1, 0})
+	if n.Dot(m.TransformDirection(Vec3f{0, 1, 0})) <= 0 {
+		t.Errorf("TestTransformNormalMat4f flipped %v", n)
+	}
+}
+
+func TestDecomposeMat4f(t *testing.T) {
+	axis := Vec3f{1, 2, 3}.Normalize()
+	q := Quatf{}
+	q.FromAxisAngle(1.2, axis.X, axis.Y, axis.Z)
+	qz := Quatf{}
+	qz.FromAxisAngle(math.Pi/2, 0, 0, 1)
+
+	cases := []struct {
+		trans Vec3f
+		rot   Quatf
+		scale Vec3f
+	}{
+		{Vec3f{0, 0, 0}, QuatfIdentity, Vec3f{1, 1, 1}},
+		{Vec3f{1, 2, 3}, QuatfIdentity, Vec3f{2, 3, 4}},
+		{Vec3f{-5, 0, 2}, qz, Vec3f{1, 1, 1}},
+		{Vec3f{1, -2, 3}, q, Vec3f{0.5, 2, 7}},
+		{Vec3f{1, -2, 3}, q, Vec3f{-2, 1, 3}},
+		{Vec3f{4, 4, 4}, qz, Vec3f{-1, 2, 1}},
+	}
+
+	for testIndex, c := range cases {
+		m := Mat4f{}
+		m.FromTRS(c.trans, c.rot, c.scale)
+
+		trans, rot, scale, ok := m.Decompose()
+		if !ok {
+			t.Errorf("TestDecomposeMat4f %d not ok", testIndex)
+			continue
+		}
+		if trans.Sub(c.trans).Length() > 1e-9 {
+			t.Errorf("TestDecomposeMat4f %d translation %v %v", testIndex, trans, c.trans)
+		}
+		// a reflection is always moved into the X scale
+		if scale.Sub(c.scale).Length() > 1e-9 {
+			rebuilt := Mat4f{}
+			rebuilt.FromTRS(trans, rot, scale)
+			for k := 0; k < 16; k++ {
+				if !closeEq32(rebuilt.At(k), m.At(k), 1e-9) {
+					t.Errorf("TestDecomposeMat4f %d scale %v %v\n%v\n%v", testIndex, scale, c.scale, &rebuilt, &m)
+					break
+				}
+			}
+			continue
+		}
+		if math.Abs(float64(rot.Dot(c.rot))) < 1-1e-5 {
+			t.Errorf("TestDecomposeMat4f %d rotation %v %v", testIndex, rot, c.rot)
+		}
+	}
+}
+
+func TestDecomposeReflectionMat4f(t *testing.T) {
+	// mirror in Y, the reflection is reported through the X scale
+	m := Mat4f{}
+	m.ToScale(1, -1, 1)
+	_, rot, scale, ok := m.Decompose()
+	if !ok || scale.X > 0 || scale.Y < 0 || scale.Z < 0 {
+		t.Errorf("TestDecomposeReflectionMat4f %v %v %v", rot, scale, ok)
+	}
+	rebuilt := Mat4f{}
+	rebuilt.FromTRS(Vec3f{0, 0, 0}, rot, scale)
+	for k := 0; k < 16; k++ {
+		if !closeEq32(rebuilt.At(k), m.At(k), 1e-9) {
+			t.Errorf("TestDecomposeReflectionMat4f \n%v\n%v", &rebuilt, &m)
+			break
+		}
+	}
+}
+
+func TestDecomposeFailMat4f(t *testing.T) {
+	shear := Mat4f{}
+	shear.Load([16]float32{1, 0.5, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1})
+	zero := Mat4f{}
+	zero.ToScale(1, 0, 1)
+	persp := Mat4f{}
+	persp.ToPerspective(math.Pi/2, 1, 1, 10)
+
+	cases := []Mat4f{shear, zero, persp}
+	for testIndex, c := range cases {
+		if _, _, _, ok := c.Decompose(); ok {
+			t.Errorf("TestDecomposeFailMat4f %d\n%v", testIndex, &c)
+		}
+	}
+}
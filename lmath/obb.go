@@ -0,0 +1,143 @@
+package lmath
+
+import (
+	"math"
+)
+
+// An oriented bounding box.
+// The box spans HalfExtents along each of its local axes, which are the world
+// axes rotated by Rotation. Rotation must be a unit quaternion.
+type OBB struct {
+	Center      Vec3
+	HalfExtents Vec3
+	Rotation    Quat
+}
+
+// Return the oriented box which matches the axis aligned box.
+func OBBFromAABB(box AABB) OBB {
+	return OBB{box.Center(), box.Extents(), Quat{1, 0, 0, 0}}
+}
+
+// Return the three local (unit length) axes of the box in world space.
+func (this OBB) Axes() (out [3]Vec3) {
+	m := this.Rotation.Mat3()
+	for k := range out {
+		out[k].Set(m.Col(k))
+	}
+	return
+}
+
+// Return the 8 corners of the box.
+// Bit 0 of the index picks the positive X extent, bit 1 Y and bit 2 Z.
+func (this OBB) Corners() (out [8]Vec3) {
+	local := AABB{this.HalfExtents.MultScalar(-1), this.HalfExtents}.Corners()
+	for k := range out {
+		out[k] = this.Rotation.RotateVec3(local[k]).Add(this.Center)
+	}
+	return
+}
+
+// Return the point p in the local space of the box.
+// The box spans [-HalfExtents,HalfExtents] in this space.
+func (this OBB) toLocal(p Vec3) Vec3 {
+	return this.Rotation.Conjugate().RotateVec3(p.Sub(this.Center))
+}
+
+// Return true if the point is inside or on the box.
+func (this OBB) Contains(p Vec3) bool {
+	l := this.toLocal(p)
+	h := this.HalfExtents
+	return math.Abs(l.X) <= h.X+epsilon &&
+		math.Abs(l.Y) <= h.Y+epsilon &&
+		math.Abs(l.Z) <= h.Z+epsilon
+}
+
+// Return the point in the box which is closest to p.
+// Points inside the box are returned unchanged.
+func (this OBB) ClosestPoint(p Vec3) Vec3 {
+	h := this.HalfExtents
+	l := AABB{h.MultScalar(-1), h}.ClosestPoint(this.toLocal(p))
+	return this.Rotation.RotateVec3(l).Add(this.Center)
+}
+
+// Return the distance from p to the box. Zero when p is inside.
+func (this OBB) Distance(p Vec3) float64 {
+	h := this.HalfExtents
+	return AABB{h.MultScalar(-1), h}.Distance(this.toLocal(p))
+}
+
+// Return the axis aligned box which bounds this box.
+func (this OBB) AABB() AABB {
+	corners := this.Corners()
+	return AABBFromPoints(corners[:]...)
+}
+
+// Return an oriented box containing both boxes.
+// The orientation is the average of the two orientations, the result is
+// not guaranteed to be the smallest such box.
+func (this OBB) Merge(other OBB) OBB {
+	out := OBB{Rotation: Nlerp(this.Rotation, other.Rotation, 0.5)}
+
+	// Bound all the corners in the local space of the new orientation
+	inv := out.Rotation.Conjugate()
+	a, b := this.Corners(), other.Corners()
+	var local [16]Vec3
+	for k := range a {
+		local[k] = inv.RotateVec3(a[k])
+		local[k+8] = inv.RotateVec3(b[k])
+	}
+	box := AABBFromPoints(local[:]...)
+	out.Center = out.Rotation.RotateVec3(box.Center())
+	out.HalfExtents = box.Extents()
+	return out
+}
+
+// Return a new box transformed by the matrix.
+// Scaling along the local axes of the box changes the extents. The matrix
+// should not shear the box, otherwise the result is only approximate.
+func (this OBB) Transform(m Mat4) OBB {
+	upper := m.UpperMat3()
+	axes := this.Axes()
+	h := [3]float64{this.HalfExtents.X, this.HalfExtents.Y, this.HalfExtents.Z}
+
+	var rot Mat3
+	for k := range axes {
+		v := upper.MultVec3(axes[k])
+		l := v.Length()
+		h[k] *= l
+		v.DivInScalar(l)
+		rot.SetCol(k, v.X, v.Y, v.Z)
+	}
+	if rot.Determinant() < 0 {
+		// A reflection, the box is symmetric so flip one of the axes.
+		x, y, z := rot.Col(2)
+		rot.SetCol(2, -x, -y, -z)
+	}
+
+	var q Quat
+	q.FromMat3(rot)
+	return OBB{m.MultVec3(this.Center), Vec3{h[0], h[1], h[2]}, *q.ToUnit()}
+}
+
+// Return a new box rotated about the origin by the quaternion.
+func (this OBB) Rotate(q Quat) OBB {
+	return OBB{q.RotateVec3(this.Center), this.HalfExtents, q.Mult(this.Rotation)}
+}
+
+// Return true if no element is NaN or infinite.
+func (this OBB) IsFinite() bool {
+	return this.Center.IsFinite() && this.HalfExtents.IsFinite() && this.Rotation.IsFinite()
+}
+
+// Return true if any element is NaN.
+func (this OBB) HasNaN() bool {
+	return this.Center.HasNaN() || this.HalfExtents.HasNaN() || this.Rotation.HasNaN()
+}
+
+// Equal if all the components are equal within the tolerance.
+// The rotations are compared with ApproxEqRotation.
+func (this OBB) ApproxEq(other OBB, tol Tolerance) bool {
+	return this.Center.ApproxEq(other.Center, tol) &&
+		this.HalfExtents.ApproxEq(other.HalfExtents, tol) &&
+		this.Rotation.ApproxEqRotation(other.Rotation, tol)
+}
@@ -0,0 +1,124 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestContainsOBB(t *testing.T) {
+	var q Quat
+	q.FromAxisAngle(math.Pi/4, 0, 0, 1)
+	box := OBB{Vec3{1, 0, 0}, Vec3{1, 2, 3}, q}
+	s := math.Sqrt(2) / 2
+
+	cases := []struct {
+		p       Vec3
+		inside  bool
+		dist    float64
+		closest Vec3
+	}{
+		{Vec3{1, 0, 0}, true, 0, Vec3{1, 0, 0}},
+		// along the local x axis
+		{Vec3{1 + s, s, 0}, true, 0, Vec3{1 + s, s, 0}},
+		{Vec3{1 + 3*s, 3 * s, 0}, false, 2, Vec3{1 + s, s, 0}},
+		// along the local y axis
+		{Vec3{1 - 2*s, 2 * s, 2}, true, 0, Vec3{1 - 2*s, 2 * s, 2}},
+		{Vec3{1 - 3*s, 3 * s, 0}, false, 1, Vec3{1 - 2*s, 2 * s, 0}},
+		{Vec3{1, 0, -7}, false, 4, Vec3{1, 0, -3}},
+	}
+
+	for testIndex, c := range cases {
+		if get := box.Contains(c.p); get != c.inside {
+			t.Errorf("TestContainsOBB %d %v", testIndex, get)
+		}
+		if get := box.Distance(c.p); !closeEq(get, c.dist, epsilon) {
+			t.Errorf("TestContainsOBB dist %d %v", testIndex, get)
+		}
+		if get := box.ClosestPoint(c.p); !get.Eq(c.closest) {
+			t.Errorf("TestContainsOBB closest %d %v", testIndex, get)
+		}
+	}
+
+	axes := box.Axes()
+	if !axes[0].Eq(Vec3{s, s, 0}) || !axes[1].Eq(Vec3{-s, s, 0}) || !axes[2].Eq(Vec3{0, 0, 1}) {
+		t.Errorf("TestContainsOBB axes %v", axes)
+	}
+	for k, c := range box.Corners() {
+		if !box.Contains(c) || !box.AABB().Contains(c) {
+			t.Errorf("TestContainsOBB corner %d %v", k, c)
+		}
+	}
+	aabb := box.AABB()
+	if !aabb.Min.Eq(Vec3{1 - 3*s, -3 * s, -3}) || !aabb.Max.Eq(Vec3{1 + 3*s, 3 * s, 3}) {
+		t.Errorf("TestContainsOBB aabb %v", aabb)
+	}
+}
+
+func TestMergeOBB(t *testing.T) {
+	var qa, qb Quat
+	qa.FromAxisAngle(0.3, 0, 1, 0)
+	qb.FromAxisAngle(-0.5, 1, 0, 0)
+	a := OBB{Vec3{0, 0, 0}, Vec3{1, 1, 1}, qa}
+	b := OBB{Vec3{3, 1, 0}, Vec3{0.5, 2, 1}, qb}
+
+	get := a.Merge(b)
+	for k, c := range a.Corners() {
+		if !get.Contains(c) {
+			t.Errorf("TestMergeOBB a %d %v", k, c)
+		}
+	}
+	for k, c := range b.Corners() {
+		if !get.Contains(c) {
+			t.Errorf("TestMergeOBB b %d %v", k, c)
+		}
+	}
+
+	// boxes with the same orientation merge exactly
+	a = OBBFromAABB(AABB{Vec3{0, 0, 0}, Vec3{1, 1, 1}})
+	b = OBBFromAABB(AABB{Vec3{2, 0, 0}, Vec3{3, 1, 1}})
+	get = a.Merge(b)
+	if !get.Center.Eq(Vec3{1.5, 0.5, 0.5}) || !get.HalfExtents.Eq(Vec3{1.5, 0.5, 0.5}) {
+		t.Errorf("TestMergeOBB aligned %v", get)
+	}
+}
+
+func TestTransformOBB(t *testing.T) {
+	var q Quat
+	q.FromAxisAngle(math.Pi/2, 0, 0, 1)
+	box := OBB{Vec3{1, 0, 0}, Vec3{1, 2, 3}, q}
+
+	var tr, s, r, flip Mat4
+	tr.ToTranslate(0, 0, 4)
+	s.ToScale(3, 1, 2)
+	r.FromAxisAngle(0.4, 1, 0, 0)
+	flip.ToScale(-1, 1, 1)
+	for testIndex, m := range []Mat4{tr, tr.Mult(s), r.Mult(s), tr.Mult(flip)} {
+		get := box.Transform(m)
+		if !closeEq(get.Rotation.Norm(), 1, epsilon) {
+			t.Errorf("TestTransformOBB norm %d %v", testIndex, get)
+		}
+		// every transformed corner must be a corner of the result
+		for _, c := range box.Corners() {
+			p := m.MultVec3(c)
+			l := get.toLocal(p)
+			h := get.HalfExtents
+			if !closeEq(math.Abs(l.X), h.X, 1e-7) || !closeEq(math.Abs(l.Y), h.Y, 1e-7) ||
+				!closeEq(math.Abs(l.Z), h.Z, 1e-7) {
+				t.Errorf("TestTransformOBB %d %v %v", testIndex, p, get)
+			}
+		}
+	}
+
+	// scaling along world x stretches the local y axis of the box
+	get := box.Transform(s)
+	if !get.HalfExtents.Eq(Vec3{1, 6, 6}) {
+		t.Errorf("TestTransformOBB extents %v", get)
+	}
+
+	q.FromAxisAngle(math.Pi/2, 0, 1, 0)
+	get = box.Rotate(q)
+	if !get.Center.Eq(Vec3{0, 0, -1}) || !get.HalfExtents.Eq(box.HalfExtents) ||
+		!get.Rotation.Eq(q.Mult(box.Rotation)) {
+		t.Errorf("TestTransformOBB rotate %v", get)
+	}
+}
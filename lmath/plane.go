@@ -0,0 +1,97 @@
+package lmath
+
+import (
+	"math"
+)
+
+// A plane holding all the points p where Normal.Dot(p) == D.
+// When Normal is unit length D is the signed distance of the plane from the
+// origin along the normal. Points on the side the normal points to are in
+// front of the plane.
+type Plane struct {
+	Normal Vec3
+	D      float64
+}
+
+// Return the plane with the given normal which passes through the point.
+// The normal is normalized.
+func PlaneFromNormalPoint(normal, point Vec3) Plane {
+	n := normal.Normalize()
+	return Plane{n, n.Dot(point)}
+}
+
+// Return the plane passing through the three points.
+// The normal follows the counter-clockwise winding of a, b, c.
+func PlaneFromPoints(a, b, c Vec3) Plane {
+	return PlaneFromNormalPoint(b.Sub(a).Cross(c.Sub(a)), a)
+}
+
+// Return a new plane with a unit length normal.
+func (this Plane) Normalize() Plane {
+	this.NormalizeIn()
+	return this
+}
+
+// Scale the plane so the normal is unit length.
+// Return a pointer to 'this'
+func (this *Plane) NormalizeIn() *Plane {
+	n := this.Normal.Length()
+	this.Normal.DivInScalar(n)
+	this.D /= n
+	return this
+}
+
+// Return the signed distance from the plane to p.
+// Positive in front of the plane, negative behind.
+// Assumes the normal is unit length.
+func (this Plane) SignedDistance(p Vec3) float64 {
+	return this.Normal.Dot(p) - this.D
+}
+
+// Return the distance from p to the plane.
+// Assumes the normal is unit length.
+func (this Plane) Distance(p Vec3) float64 {
+	return math.Abs(this.SignedDistance(p))
+}
+
+// Return the point on the plane which is closest to p.
+// Assumes the normal is unit length.
+func (this Plane) ClosestPoint(p Vec3) Vec3 {
+	return p.Sub(this.Normal.MultScalar(this.SignedDistance(p)))
+}
+
+// Return true if the point lies on the plane.
+// Equality is measured using an epsilon (< 0.0000001).
+func (this Plane) Contains(p Vec3) bool {
+	return this.Distance(p) < epsilon
+}
+
+// Return a new plane transformed by the matrix.
+// The normal is transformed by the inverse-transpose of the upper 3x3 so
+// the plane stays correct under non-uniform scaling. The matrix must be
+// invertible. The result has a unit length normal.
+func (this Plane) Transform(m Mat4) Plane {
+	point := m.MultVec3(this.Normal.MultScalar(this.D / this.Normal.LengthSq()))
+	return PlaneFromNormalPoint(m.TransformNormal(this.Normal), point)
+}
+
+// Return a new plane rotated about the origin by the quaternion.
+func (this Plane) Rotate(q Quat) Plane {
+	return Plane{q.RotateVec3(this.Normal), this.D}
+}
+
+// Return true if no element is NaN or infinite.
+func (this Plane) IsFinite() bool {
+	return this.Normal.IsFinite() && isFinite(this.D)
+}
+
+// Return true if any element is NaN.
+func (this Plane) HasNaN() bool {
+	return this.Normal.HasNaN() || math.IsNaN(this.D)
+}
+
+// Equal if the normal and D are equal within the tolerance. The planes
+// are compared as stored, so (n, d) and (-n, -d) are not equal.
+func (this Plane) ApproxEq(other Plane, tol Tolerance) bool {
+	return this.Normal.ApproxEq(other.Normal, tol) && tol.Eq(this.D, other.D)
+}
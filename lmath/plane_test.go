@@ -0,0 +1,84 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFromPointsPlane(t *testing.T) {
+	cases := []struct {
+		a, b, c Vec3
+		want    Plane
+	}{
+		{Vec3{0, 0, 0}, Vec3{1, 0, 0}, Vec3{0, 1, 0}, Plane{Vec3{0, 0, 1}, 0}},
+		{Vec3{0, 0, 2}, Vec3{0, 1, 2}, Vec3{1, 0, 2}, Plane{Vec3{0, 0, -1}, -2}},
+		{Vec3{3, 0, 0}, Vec3{3, 1, 0}, Vec3{3, 0, 1}, Plane{Vec3{1, 0, 0}, 3}},
+		{Vec3{1, 0, 0}, Vec3{0, 1, 0}, Vec3{0, 0, 1}, Plane{Vec3{1, 1, 1}.Normalize(), 1 / math.Sqrt(3)}},
+	}
+
+	for testIndex, c := range cases {
+		get := PlaneFromPoints(c.a, c.b, c.c)
+		if !get.Normal.Eq(c.want.Normal) || !closeEq(get.D, c.want.D, epsilon) {
+			t.Errorf("TestFromPointsPlane %d %v", testIndex, get)
+		}
+		if !get.Contains(c.a) || !get.Contains(c.b) || !get.Contains(c.c) {
+			t.Errorf("TestFromPointsPlane contains %d", testIndex)
+		}
+	}
+
+	get := Plane{Vec3{0, 2, 0}, 4}.Normalize()
+	if !get.Normal.Eq(Vec3{0, 1, 0}) || get.D != 2 {
+		t.Errorf("TestFromPointsPlane normalize %v", get)
+	}
+}
+
+func TestDistancePlane(t *testing.T) {
+	p := PlaneFromNormalPoint(Vec3{0, 1, 0}, Vec3{5, 2, 5})
+	cases := []struct {
+		p       Vec3
+		signed  float64
+		closest Vec3
+	}{
+		{Vec3{0, 2, 0}, 0, Vec3{0, 2, 0}},
+		{Vec3{1, 5, 1}, 3, Vec3{1, 2, 1}},
+		{Vec3{-1, -1, 7}, -3, Vec3{-1, 2, 7}},
+	}
+
+	for testIndex, c := range cases {
+		if get := p.SignedDistance(c.p); !closeEq(get, c.signed, epsilon) {
+			t.Errorf("TestDistancePlane signed %d %v", testIndex, get)
+		}
+		if get := p.Distance(c.p); !closeEq(get, math.Abs(c.signed), epsilon) {
+			t.Errorf("TestDistancePlane %d %v", testIndex, get)
+		}
+		if get := p.ClosestPoint(c.p); !get.Eq(c.closest) {
+			t.Errorf("TestDistancePlane closest %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestTransformPlane(t *testing.T) {
+	points := []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	p := PlaneFromPoints(points[0], points[1], points[2])
+
+	var tr, s, r Mat4
+	tr.ToTranslate(1, -2, 3)
+	s.ToScale(2, 0.5, 3)
+	r.FromAxisAngle(0.7, 0, 1, 0)
+	m := tr.Mult(r).Mult(s)
+
+	// The transformed plane must hold the transformed points
+	get := p.Transform(m)
+	want := PlaneFromPoints(m.MultVec3(points[0]), m.MultVec3(points[1]), m.MultVec3(points[2]))
+	if !get.Normal.Eq(want.Normal) || !closeEq(get.D, want.D, epsilon) {
+		t.Errorf("TestTransformPlane %v %v", get, want)
+	}
+
+	var q Quat
+	q.FromAxisAngle(math.Pi/2, 1, 0, 0)
+	floor := Plane{Vec3{0, 1, 0}, 2}
+	get = floor.Rotate(q)
+	if !get.Normal.Eq(Vec3{0, 0, 1}) || get.D != 2 || !get.Contains(q.RotateVec3(Vec3{3, 2, 1})) {
+		t.Errorf("TestTransformPlane rotate %v", get)
+	}
+}
@@ -31,6 +31,36 @@ func (this Quat) Eq(other Quat) bool {
 		closeEq(this.W, other.W, epsilon)
 }
 
+// Equal if all elements are equal within the tolerance
+func (this Quat) ApproxEq(other Quat, tol Tolerance) bool {
+	return tol.Eq(this.W, other.W) &&
+		tol.Eq(this.X, other.X) &&
+		tol.Eq(this.Y, other.Y) &&
+		tol.Eq(this.Z, other.Z)
+}
+
+// Return true if the quaternions represent the same rotation within the
+// tolerance. q and -q are the same rotation so either sign is accepted.
+func (this Quat) ApproxEqRotation(other Quat, tol Tolerance) bool {
+	return this.ApproxEq(other, tol) || this.ApproxEq(other.MultScalar(-1), tol)
+}
+
+// Return true if no element is NaN or infinite.
+func (this Quat) IsFinite() bool {
+	return isFinite(this.W) &&
+		isFinite(this.X) &&
+		isFinite(this.Y) &&
+		isFinite(this.Z)
+}
+
+// Return true if any element is NaN.
+func (this Quat) HasNaN() bool {
+	return math.IsNaN(this.W) ||
+		math.IsNaN(this.X) ||
+		math.IsNaN(this.Y) ||
+		math.IsNaN(this.Z)
+}
+
 // Set the component of the quaternion. Return this
 func (this *Quat) Set(w, x, y, z float64) *Quat {
 	this.W = w
@@ -166,7 +196,7 @@ func (this *Quat) DivInScalar(val float64) *Quat {
 // Returns a pointer to this.
 func (this *Quat) ToUnit() *Quat {
 	n := this.Norm()
-	if closeEq(n, 0, epsilon) {
+	if n == 0 {
 		return this
 	}
 	return this.DivInScalar(n)
@@ -214,17 +244,198 @@ func (this Quat) Inverse() Quat {
 func (this *Quat) InverseIn() *Quat {
 	this.ConjugateIn()
 	n := this.NormSq()
-	if closeEq(n, 0, epsilon) {
+	if n == 0 {
 		return this
 	}
 	return this.DivInScalar(n)
 }
 
+// Return the inverse of the quaternion and true, or the zero quaternion and
+// false when the quaternion is zero or not finite. Any other quaternion has
+// an exact inverse, however small it is.
+func (this Quat) TryInverse() (Quat, bool) {
+	if this.NormSq() == 0 || !this.IsFinite() {
+		return Quat{}, false
+	}
+	out := this.Inverse()
+	if !out.IsFinite() {
+		return Quat{}, false
+	}
+	return out, true
+}
+
 // Create a copy of this Quaternion and return the result.
 func (this Quat) Copy() Quat {
 	return this
 }
 
+// Returns the 4D dot product between 'this' and the other quaternion.
+// For unit quaternions this is the cosine of half the angle between them.
+func (this Quat) Dot(other Quat) float64 {
+	return this.W*other.W + this.X*other.X + this.Y*other.Y + this.Z*other.Z
+}
+
+// Returns the quaternion natural logarithm.
+// For a unit quaternion [cos(a), sin(a)*v] this is [0, a*v]
+// Stable for small angles. The identity maps to the zero quaternion.
+func (this Quat) Log() Quat {
+	this.LogIn()
+	return this
+}
+
+// Set this quaternion to its natural logarithm. Returns this.
+func (this *Quat) LogIn() *Quat {
+	n := this.Norm()
+	vlen := math.Sqrt(this.X*this.X + this.Y*this.Y + this.Z*this.Z)
+
+	var scale float64
+	switch {
+	case this.W > 0 && vlen < smallAngle*this.W:
+		// atan(r)/vlen == (1 - r^2/3 + ...)/W with r = vlen/W
+		r := vlen / this.W
+		scale = (1 - r*r/3) / this.W
+	case vlen == 0:
+		// A rotation of 2pi about an undefined axis, pick the X axis.
+		this.Set(math.Log(n), math.Pi, 0, 0)
+		return this
+	default:
+		scale = math.Atan2(vlen, this.W) / vlen
+	}
+	return this.Set(math.Log(n), this.X*scale, this.Y*scale, this.Z*scale)
+}
+
+// Returns the quaternion exponential.
+// For a pure quaternion [0, a*v] this is [cos(a), sin(a)*v]
+// Stable for small angles. The zero quaternion maps to the identity.
+func (this Quat) Exp() Quat {
+	this.ExpIn()
+	return this
+}
+
+// Set this quaternion to its exponential. Returns this.
+func (this *Quat) ExpIn() *Quat {
+	vlen := math.Sqrt(this.X*this.X + this.Y*this.Y + this.Z*this.Z)
+	e := math.Exp(this.W)
+
+	var scale float64
+	if vlen < smallAngle {
+		// sin(vlen)/vlen == 1 - vlen^2/6 + ...
+		scale = e * (1 - vlen*vlen/6)
+	} else {
+		scale = e * math.Sin(vlen) / vlen
+	}
+	return this.Set(e*math.Cos(vlen), this.X*scale, this.Y*scale, this.Z*scale)
+}
+
+// Raise the quaternion to the power t. exp(t * log(q))
+// For a unit quaternion this scales the angle of the rotation by t, so
+// q.Pow(0.5) is half of the rotation and q.Pow(-1) is the inverse.
+// Returns a new quaternion with the result.
+func (this Quat) Pow(t float64) Quat {
+	this.PowIn(t)
+	return this
+}
+
+// Raise this quaternion to the power t. Returns this.
+func (this *Quat) PowIn(t float64) *Quat {
+	return this.LogIn().MultInScalar(t).ExpIn()
+}
+
+// Return the rotation vector (axis * angle) of this unit quaternion.
+// The rotation taking the shortest path is used, so the length of the
+// vector (the angle in radians) is in the range [0,pi].
+// Stable for small angles, the identity returns the zero vector.
+func (this Quat) RotationVector() Vec3 {
+	if this.W < 0 {
+		this.MultInScalar(-1)
+	}
+	l := this.LogIn()
+	return Vec3{2 * l.X, 2 * l.Y, 2 * l.Z}
+}
+
+// Set this quaternion as the rotation described by the rotation vector
+// (axis * angle). The length of the vector is the angle in radians.
+// Stable for small angles, the zero vector gives the identity.
+// Return this
+func (this *Quat) FromRotationVector(v Vec3) *Quat {
+	return this.Set(0, v.X/2, v.Y/2, v.Z/2).ExpIn()
+}
+
+// Split the unit quaternion into a twist about the axis and the remaining
+// swing such that this == swing * twist. The twist is applied first.
+// The axis doesn't need to be normalized. When the swing is 180 degrees the
+// twist is undefined and the identity is returned for it.
+func (this Quat) SwingTwist(axis Vec3) (swing, twist Quat) {
+	a := axis.Normalize()
+	d := a.Dot(Vec3{this.X, this.Y, this.Z})
+	twist = Quat{this.W, a.X * d, a.Y * d, a.Z * d}
+	// only a swing of exactly 180 degrees leaves nothing of the twist, the
+	// bound just absorbs rounding in the dot product
+	if twist.NormSq() <= 1e-30*this.NormSq() {
+		twist = QuatIdentity
+	} else {
+		twist.ToUnit()
+	}
+	if twist.W < 0 {
+		twist.MultInScalar(-1)
+	}
+	swing = this.Mult(twist.Conjugate())
+	return
+}
+
+// Return the angle (radians) of the twist about the axis in the range
+// [-pi,pi], positive angles being counter-clockwise about the axis.
+func (this Quat) TwistAngle(axis Vec3) float64 {
+	_, twist := this.SwingTwist(axis)
+	a := axis.Normalize()
+	return 2 * math.Atan2(a.Dot(Vec3{twist.X, twist.Y, twist.Z}), twist.W)
+}
+
+// Return the rotation with the twist about the axis clamped to the range
+// [minAngle,maxAngle] (radians), keeping the swing.
+func (this Quat) ClampTwist(axis Vec3, minAngle, maxAngle float64) Quat {
+	swing, _ := this.SwingTwist(axis)
+	angle := math.Max(minAngle, math.Min(maxAngle, this.TwistAngle(axis)))
+	a := axis.Normalize()
+	twist := Quat{}
+	twist.FromAxisAngle(angle, a.X, a.Y, a.Z)
+	return swing.Mult(twist)
+}
+
+// Return the rotation with the swing away from the axis clamped to the cone
+// with the half angle limit (radians), keeping the twist.
+func (this Quat) ClampSwingCone(axis Vec3, limit float64) Quat {
+	swing, twist := this.SwingTwist(axis)
+	v := swing.RotationVector()
+	angle := v.Length()
+	if angle <= limit {
+		return this
+	}
+	swing.FromRotationVector(v.MultScalar(limit / angle))
+	return swing.Mult(twist)
+}
+
+// Return the rotation with the swing away from the axis clamped to an
+// elliptical cone, keeping the twist. limitA is the largest swing (radians)
+// about swingAxis and limitB the largest about axis.Cross(swingAxis).
+// swingAxis should be perpendicular to axis and both limits greater than zero.
+// Swings outside the ellipse are scaled back towards the axis until they touch it.
+func (this Quat) ClampSwingEllipse(axis, swingAxis Vec3, limitA, limitB float64) Quat {
+	swing, twist := this.SwingTwist(axis)
+	a := axis.Normalize()
+	b := swingAxis.Sub(a.MultScalar(a.Dot(swingAxis))).Normalize()
+	c := a.Cross(b)
+
+	v := swing.RotationVector()
+	x, y := v.Dot(b)/limitA, v.Dot(c)/limitB
+	e := x*x + y*y
+	if e <= 1 {
+		return this
+	}
+	swing.FromRotationVector(v.MultScalar(1 / math.Sqrt(e)))
+	return swing.Mult(twist)
+}
+
 //==============================================================================
 
 // Return a new vector holding the axis component of the quaternion
@@ -280,35 +491,46 @@ func (this *Quat) FromAxisAngle(angle float64, x, y, z float64) *Quat {
 	return this
 }
 
+// Return the shortest rotation which turns the direction a onto the
+// direction b. The vectors don't need to be normalized.
+// Antiparallel vectors are rotated by 180 degrees about an axis perpendicular
+// to a, and the identity is returned if either vector is zero or not finite.
+func QuatFromTo(a, b Vec3) Quat {
+	la, lb := a.Length(), b.Length()
+	if la == 0 || lb == 0 || !isFinite(la) || !isFinite(lb) {
+		return QuatIdentity
+	}
+	a = a.DivScalar(la)
+	b = b.DivScalar(lb)
+
+	d := a.Dot(b)
+	if d < -1+epsilon {
+		axis := perpendicular(a)
+		return Quat{0, axis.X, axis.Y, axis.Z}
+	}
+	// the half angle rotation is the normalized [1+cos, sin*axis]
+	c := a.Cross(b)
+	q := Quat{1 + d, c.X, c.Y, c.Z}
+	q.ToUnit()
+	return q
+}
+
+// Return the rotation which turns Vec3Forward onto forward and Vec3Up
+// towards up. When up is zero or parallel to forward the result is
+// QuatFromTo(Vec3Forward, forward).
+func QuatLookRotation(forward, up Vec3) Quat {
+	q := Quat{}
+	q.FromMat3(Mat3LookRotation(forward, up))
+	return q
+}
+
 // Extract out the euler angles from the quaternion
 // Extract out the angles assuming the quaterion is encoded
 // as pitch -> yaw -> roll
 // The returned euler angle may not match the same angles passed in using the
 // FromEuler(),but the angles are guaranteed to form an equivalent rotation quaternion.
 func (this Quat) Euler() (pitch, yaw, roll float64) {
-	// Reference http://www.euclideanspace.com/maths/geometry/rotations/conversions/quaternionToEuler/
-
-	test := this.X*this.Y + this.Z*this.W
-	if test > 0.499 { // singularity at north pole
-		yaw = 2 * math.Atan2(this.X, this.W)
-		roll = math.Pi / 2
-		pitch = 0
-		return
-	}
-	if test < -0.499 { // singularity at south pole
-		yaw = -2 * math.Atan2(this.X, this.W)
-		roll = -math.Pi / 2
-		pitch = 0
-		return
-	}
-
-	sqx := this.X * this.X
-	sqy := this.Y * this.Y
-	sqz := this.Z * this.Z
-	yaw = math.Atan2(2*this.Y*this.W-2*this.X*this.Z, 1-2*sqy-2*sqz)
-	roll = math.Asin(2 * test)
-	pitch = math.Atan2(2*this.X*this.W-2*this.Y*this.Z, 1-2*sqx-2*sqz)
-	return
+	return this.EulerOrder(ExtrinsicXYZ)
 }
 
 // Return the axis and angle of this rotation quaternion
@@ -331,7 +553,8 @@ func (this *Quat) fromMat(m [16]float64) *Quat {
 	// trace := m.Get(0, 0) + m.Get(1, 1) + m.Get(2, 2) + 1
 	trace := m[0] + m[5] + m[10] + 1
 
-	if trace > 0 {
+	// Only use the trace when it is large enough to divide by safely
+	if trace > 1 {
 		s := 0.5 / math.Sqrt(trace)
 		this.Set(
 			0.25/s,
@@ -355,27 +578,27 @@ func (this *Quat) fromMat(m [16]float64) *Quat {
 		}
 	}
 
-	// TODO : UNTESTED!!!
+	// s is 4 times the largest of x,y or z
 	var w, x, y, z, s float64
 	switch max_col {
 	case 0:
 		s = 2 * math.Sqrt(1.0+m[0]-m[5]-m[10])
-		x = 0.5 / 2
+		x = 0.25 * s
 		y = (m[4] + m[1]) / s
 		z = (m[8] + m[2]) / s
-		w = (m[9] + m[6]) / s
+		w = (m[9] - m[6]) / s
 	case 1:
 		s = 2 * math.Sqrt(1.0+m[5]-m[0]-m[10])
 		x = (m[4] + m[1]) / s
-		y = 0.5 / 2
+		y = 0.25 * s
 		z = (m[9] + m[6]) / s
-		w = (m[8] + m[2]) / s
+		w = (m[2] - m[8]) / s
 	case 2:
 		s = 2 * math.Sqrt(1.0+m[10]-m[0]-m[5])
 		x = (m[8] + m[2]) / s
 		y = (m[9] + m[6]) / s
-		z = 0.5 / 2
-		w = (m[4] + m[1]) / s
+		z = 0.25 * s
+		w = (m[4] - m[1]) / s
 	}
 
 	this.Set(w, x, y, z)
@@ -398,7 +621,7 @@ func (this Quat) mat() (m [16]float64) {
 	// 8 9 10 11
 	// 12 13 14 15
 	m[0] = 1 - 2*y*y - 2*z*z
-	m[1] = 2*y*z - 2*w*z
+	m[1] = 2*x*y - 2*w*z
 	m[2] = 2*x*z + 2*w*y
 	m[3] = 0
 
@@ -456,3 +679,82 @@ func (this Quat) Mat3() Mat3 {
 	})
 	return m
 }
+
+//==============================================================================
+
+// Spherically interpolate between the two unit quaternions a and b.
+// The rotation follows the shortest path between the two orientations,
+// so b may be negated internally. inc is specified between the range 0 - 1,
+// values outside of the range extrapolate along the same great arc.
+//
+//	Slerp(a,b,0) ==> a
+//	Slerp(a,b,1) ==> b (or -b)
+func Slerp(a, b Quat, inc float64) Quat {
+	if a.Dot(b) < 0 {
+		b.MultInScalar(-1)
+	}
+	return slerp(a, b, inc)
+}
+
+// Spherically interpolate between a and b without correcting for the
+// shortest path.
+func slerp(a, b Quat, inc float64) Quat {
+	cosTheta := a.Dot(b)
+	if math.Abs(cosTheta) > 1-slerpEpsilon {
+		// The quaternions are nearly parallel, sin(theta) tends to zero so fall
+		// back to a normalized linear interpolation.
+		return nlerp(a, b, inc)
+	}
+
+	theta := math.Acos(cosTheta)
+	sinTheta := math.Sin(theta)
+	wa := math.Sin((1-inc)*theta) / sinTheta
+	wb := math.Sin(inc*theta) / sinTheta
+	return a.MultScalar(wa).Add(b.MultScalar(wb))
+}
+
+// Linearly interpolate between the two unit quaternions a and b and
+// normalize the result. Much cheaper than Slerp but the angular velocity is
+// not constant. The shortest path is taken, so b may be negated internally.
+func Nlerp(a, b Quat, inc float64) Quat {
+	if a.Dot(b) < 0 {
+		b.MultInScalar(-1)
+	}
+	return nlerp(a, b, inc)
+}
+
+func nlerp(a, b Quat, inc float64) Quat {
+	out := a.MultScalar(1 - inc).Add(b.MultScalar(inc))
+	out.ToUnit()
+	return out
+}
+
+// Spherical quadrangle interpolation between q1 and q2 using the control
+// points s1 and s2. Gives a C1 continuous curve through a sequence of
+// keyframes when the control points are made by SquadControl.
+//
+//	Squad(q1,q2,s1,s2,0) ==> q1
+//	Squad(q1,q2,s1,s2,1) ==> q2
+func Squad(q1, q2, s1, s2 Quat, inc float64) Quat {
+	return slerp(slerp(q1, q2, inc), slerp(s1, s2, inc), 2*inc*(1-inc))
+}
+
+// Return the Squad control point for the keyframe q, given the keyframe
+// before it (prev) and after it (next). For the first and last keyframe pass
+// q itself as the missing neighbour.
+// Neighbours are flipped into the same hemisphere as q so that the curve
+// takes the shortest path. Keyframes passed to Squad should be flipped the
+// same way.
+func SquadControl(prev, q, next Quat) Quat {
+	if q.Dot(prev) < 0 {
+		prev.MultInScalar(-1)
+	}
+	if q.Dot(next) < 0 {
+		next.MultInScalar(-1)
+	}
+
+	inv := q.Inverse()
+	a := inv.Mult(next).Log()
+	b := inv.Mult(prev).Log()
+	return q.Mult(a.Add(b).MultScalar(-0.25).Exp())
+}
@@ -569,6 +569,44 @@ func TestMat4Quat(t *testing.T) {
 	}
 }
 
+// Regression cases for the matrix conversions. mat() used y*z instead of x*y
+// for m[1], and fromMat only handled the trace branch correctly.
+func TestMatConversionQuat(t *testing.T) {
+	cases := []struct {
+		angle   float64
+		x, y, z float64
+	}{
+		{Radians(60), 1, 1, 0},
+		{Radians(100), 1, -2, 3},
+		{Radians(130), 1, 0, 0},
+		{Radians(150), 0, 1, 0},
+		{Radians(170), 0, 0, 1},
+		{Radians(180), 1, 0, 0},
+		{Radians(180), 0, 1, 0},
+		{Radians(180), 0, 0, 1},
+		{Radians(160), -4, 4, 1},
+		{Radians(179), 2, 1, -1},
+	}
+
+	for testIndex, c := range cases {
+		v := Vec3{c.x, c.y, c.z}.Normalize()
+		q := Quat{}
+		q.FromAxisAngle(c.angle, v.X, v.Y, v.Z)
+		want := Mat3{}
+		want.FromAxisAngle(c.angle, v.X, v.Y, v.Z)
+
+		if m := q.Mat3(); !m.Eq(want) {
+			t.Errorf("TestMatConversionQuat %d mat\n%v\n%v", testIndex, m, want)
+		}
+		// q and -q are the same rotation
+		get := Quat{}
+		get.FromMat3(want)
+		if !closeEq(math.Abs(get.Dot(q)), 1, epsilon) {
+			t.Errorf("TestMatConversionQuat %d fromMat %v %v", testIndex, get, q)
+		}
+	}
+}
+
 func TestEulerQuat(t *testing.T) {
 	common_cases2 := []struct {
 		pitch, yaw, roll float64
@@ -658,3 +696,527 @@ func TestEulerQuat(t *testing.T) {
 		// }
 	}
 }
+
+func TestDotQuat(t *testing.T) {
+	cases := []struct {
+		a, b Quat
+		want float64
+	}{
+		{Quat{1, 0, 0, 0}, Quat{1, 0, 0, 0}, 1},
+		{Quat{1, 0, 0, 0}, Quat{0, 1, 0, 0}, 0},
+		{Quat{1, 2, 3, 4}, Quat{-1, 2, -3, 4}, 10},
+	}
+
+	for testIndex, c := range cases {
+		if get := c.a.Dot(c.b); get != c.want {
+			t.Errorf("TestDotQuat %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestSlerpQuat(t *testing.T) {
+	cases := []struct {
+		angleA, angleB float64
+		axis           Vec3
+		inc            float64
+		wantAngle      float64
+	}{
+		{0, 90, Vec3{0, 0, 1}, 0, 0},
+		{0, 90, Vec3{0, 0, 1}, 1, 90},
+		{0, 90, Vec3{0, 0, 1}, 0.5, 45},
+		{0, 90, Vec3{0, 0, 1}, 0.25, 22.5},
+		{30, 90, Vec3{1, 0, 0}, 0.5, 60},
+		{-60, 60, Vec3{0, 1, 0}, 0.75, 30},
+		{0, 180, Vec3{0, 1, 0}, 0.5, 90},
+
+		// Shortest path, 350 degrees is the same as -10 degrees
+		{0, 350, Vec3{0, 0, 1}, 0.5, -5},
+		{10, 340, Vec3{0, 0, 1}, 0.5, -5},
+
+		// Extrapolation outside of [0,1]
+		{0, 45, Vec3{0, 0, 1}, 2, 90},
+		{0, 45, Vec3{0, 0, 1}, -1, -45},
+		{20, 40, Vec3{1, 0, 0}, 1.5, 50},
+
+		// Nearly parallel quaternions
+		{10, 10 + 1e-7, Vec3{0, 1, 0}, 0.5, 10 + 0.5e-7},
+		{10, 10, Vec3{0, 1, 0}, 0.5, 10},
+		{10, 10, Vec3{0, 1, 0}, 3, 10},
+	}
+
+	var a, b, want Quat
+	for testIndex, c := range cases {
+		a.FromAxisAngle(Radians(c.angleA), c.axis.X, c.axis.Y, c.axis.Z)
+		b.FromAxisAngle(Radians(c.angleB), c.axis.X, c.axis.Y, c.axis.Z)
+		want.FromAxisAngle(Radians(c.wantAngle), c.axis.X, c.axis.Y, c.axis.Z)
+
+		get := Slerp(a, b, c.inc)
+		if !closeEq(get.Norm(), 1, epsilon) {
+			t.Errorf("TestSlerpQuat norm %d %v", testIndex, get)
+		}
+		if !get.Eq(want) && !get.Eq(want.MultScalar(-1)) {
+			t.Errorf("TestSlerpQuat %d %v %v", testIndex, get, want)
+		}
+
+		// Quaternions in the opposite hemisphere represent the same rotation
+		// and must give the same result.
+		get2 := Slerp(a, b.MultScalar(-1), c.inc)
+		if !get2.Eq(get) {
+			t.Errorf("TestSlerpQuat hemisphere %d %v %v", testIndex, get2, get)
+		}
+	}
+}
+
+func TestSlerpRotateQuat(t *testing.T) {
+	// Interpolating between two arbitrary orientations must rotate with a
+	// constant angular velocity.
+	var a, b Quat
+	a.FromEuler(Radians(10), Radians(20), Radians(30))
+	b.FromEuler(Radians(-40), Radians(70), Radians(-15))
+	v := Vec3{0.2, 0.5, -0.8}
+
+	total := b.Mult(a.Inverse()).Angle()
+	for _, inc := range []float64{0, 0.1, 0.3, 0.5, 0.9, 1} {
+		q := Slerp(a, b, inc)
+		get := q.Mult(a.Inverse()).Angle()
+		if !closeEq(get, total*inc, 1e-7) {
+			t.Errorf("TestSlerpRotateQuat %v %v %v", inc, get, total*inc)
+		}
+	}
+	if !Slerp(a, b, 0).RotateVec3(v).Eq(a.RotateVec3(v)) ||
+		!Slerp(a, b, 1).RotateVec3(v).Eq(b.RotateVec3(v)) {
+		t.Errorf("TestSlerpRotateQuat end points")
+	}
+}
+
+func TestNlerpQuat(t *testing.T) {
+	cases := []struct {
+		angleA, angleB float64
+		axis           Vec3
+		inc            float64
+		wantAngle      float64
+	}{
+		{0, 90, Vec3{0, 0, 1}, 0, 0},
+		{0, 90, Vec3{0, 0, 1}, 1, 90},
+		{0, 90, Vec3{0, 0, 1}, 0.5, 45},
+		{-60, 60, Vec3{0, 1, 0}, 0.5, 0},
+		{0, 350, Vec3{0, 0, 1}, 0.5, -5},
+		{10, 10, Vec3{0, 1, 0}, 0.5, 10},
+	}
+
+	var a, b, want Quat
+	for testIndex, c := range cases {
+		a.FromAxisAngle(Radians(c.angleA), c.axis.X, c.axis.Y, c.axis.Z)
+		b.FromAxisAngle(Radians(c.angleB), c.axis.X, c.axis.Y, c.axis.Z)
+		want.FromAxisAngle(Radians(c.wantAngle), c.axis.X, c.axis.Y, c.axis.Z)
+
+		get := Nlerp(a, b, c.inc)
+		if !closeEq(get.Norm(), 1, epsilon) {
+			t.Errorf("TestNlerpQuat norm %d %v", testIndex, get)
+		}
+		if !get.Eq(want) && !get.Eq(want.MultScalar(-1)) {
+			t.Errorf("TestNlerpQuat %d %v %v", testIndex, get, want)
+		}
+		if !Nlerp(a, b.MultScalar(-1), c.inc).Eq(get) {
+			t.Errorf("TestNlerpQuat hemisphere %d", testIndex)
+		}
+	}
+}
+
+func TestSquadQuat(t *testing.T) {
+	var q0, q1, q2, q3 Quat
+	q0.FromEuler(Radians(0), Radians(10), Radians(0))
+	q1.FromEuler(Radians(30), Radians(60), Radians(10))
+	q2.FromEuler(Radians(-20), Radians(90), Radians(45))
+	q3.FromEuler(Radians(10), Radians(120), Radians(-30))
+	keys := []Quat{q0, q1, q2, q3}
+
+	controls := make([]Quat, len(keys))
+	for k, _ := range keys {
+		prev, next := keys[k], keys[k]
+		if k > 0 {
+			prev = keys[k-1]
+		}
+		if k < len(keys)-1 {
+			next = keys[k+1]
+		}
+		controls[k] = SquadControl(prev, keys[k], next)
+	}
+	segment := func(k int, inc float64) Quat {
+		return Squad(keys[k], keys[k+1], controls[k], controls[k+1], inc)
+	}
+
+	for k := 0; k < len(keys)-1; k++ {
+		// The curve passes through the keyframes
+		if !segment(k, 0).Eq(keys[k]) || !segment(k, 1).Eq(keys[k+1]) {
+			t.Errorf("TestSquadQuat end points %d", k)
+		}
+		if get := segment(k, 0.5); !closeEq(get.Norm(), 1, 1e-7) {
+			t.Errorf("TestSquadQuat norm %d %v", k, get.Norm())
+		}
+	}
+
+	// The curve is C1 continuous across the keyframes
+	h := 1e-5
+	for k := 0; k < len(keys)-2; k++ {
+		before := segment(k, 1).Sub(segment(k, 1-h)).DivScalar(h)
+		after := segment(k+1, h).Sub(segment(k+1, 0)).DivScalar(h)
+		if !closeEq(before.Sub(after).Norm(), 0, 1e-3) {
+			t.Errorf("TestSquadQuat C1 %d %v %v", k, before, after)
+		}
+	}
+
+	// Keyframes evenly spaced about a single axis reduce to slerp
+	var a, b, c, d Quat
+	a.FromAxisAngle(Radians(0), 0, 0, 1)
+	b.FromAxisAngle(Radians(20), 0, 0, 1)
+	c.FromAxisAngle(Radians(40), 0, 0, 1)
+	d.FromAxisAngle(Radians(60), 0, 0, 1)
+	sb := SquadControl(a, b, c)
+	sc := SquadControl(b, c, d)
+	if !sb.Eq(b) || !sc.Eq(c) {
+		t.Errorf("TestSquadQuat control %v %v", sb, sc)
+	}
+	for _, inc := range []float64{0.1, 0.5, 0.8} {
+		if get := Squad(b, c, sb, sc, inc); !get.Eq(Slerp(b, c, inc)) {
+			t.Errorf("TestSquadQuat slerp %v %v", inc, get)
+		}
+	}
+}
+
+func TestLogExpQuat(t *testing.T) {
+	cases := []struct {
+		q, log Quat
+	}{
+		{Quat{1, 0, 0, 0}, Quat{0, 0, 0, 0}},
+		{Quat{math.Cos(0.5), math.Sin(0.5), 0, 0}, Quat{0, 0.5, 0, 0}},
+		{Quat{math.Cos(1), 0, math.Sin(1), 0}, Quat{0, 0, 1, 0}},
+		{Quat{0, 0, 0, 1}, Quat{0, 0, 0, math.Pi / 2}},
+		{Quat{math.Cos(3), 0, 0, -math.Sin(3)}, Quat{0, 0, 0, -3}},
+		{Quat{2, 0, 0, 0}, Quat{math.Log(2), 0, 0, 0}},
+		{Quat{2 * math.Cos(0.3), 0, 2 * math.Sin(0.3), 0}, Quat{math.Log(2), 0, 0.3, 0}},
+
+		// small angles
+		{Quat{math.Cos(1e-9), 1e-9, 0, 0}, Quat{0, 1e-9, 0, 0}},
+		{Quat{math.Cos(1e-5), 0, math.Sin(1e-5), 0}, Quat{0, 0, 1e-5, 0}},
+	}
+
+	for testIndex, c := range cases {
+		if get := c.q.Log(); !get.Eq(c.log) {
+			t.Errorf("TestLogExpQuat log %d %v %v", testIndex, get, c.log)
+		}
+		if get := c.log.Exp(); !get.Eq(c.q) {
+			t.Errorf("TestLogExpQuat exp %d %v %v", testIndex, get, c.q)
+		}
+		if get := c.q.Log().Exp(); !get.Eq(c.q) {
+			t.Errorf("TestLogExpQuat round trip %d %v %v", testIndex, get, c.q)
+		}
+	}
+
+	// The small angle branch must agree with the exact formula at the switch
+	for _, a := range []float64{smallAngle * 0.999, smallAngle * 1.001} {
+		q := Quat{math.Cos(a), 0, 0, math.Sin(a)}
+		if get := q.Log(); !closeEq(get.Z, a, 1e-15) {
+			t.Errorf("TestLogExpQuat small log %v %v", a, get)
+		}
+		if get := (Quat{0, 0, 0, a}).Exp(); !closeEq(get.Z, math.Sin(a), 1e-15) {
+			t.Errorf("TestLogExpQuat small exp %v %v", a, get)
+		}
+	}
+}
+
+func TestPowQuat(t *testing.T) {
+	cases := []struct {
+		angle float64
+		axis  Vec3
+		pow   float64
+	}{
+		{90, Vec3{0, 0, 1}, 0.5},
+		{90, Vec3{0, 0, 1}, 2},
+		{60, Vec3{1, 0, 0}, -1},
+		{120, Vec3{0, 1, 0}, 0},
+		{45, Vec3{0, 1, 0}, 1},
+		{1e-6, Vec3{0, 1, 0}, 0.5},
+		{170, Vec3{0, 0.6, 0.8}, 1.0 / 3},
+	}
+
+	var q, want Quat
+	for testIndex, c := range cases {
+		q.FromAxisAngle(Radians(c.angle), c.axis.X, c.axis.Y, c.axis.Z)
+		want.FromAxisAngle(Radians(c.angle*c.pow), c.axis.X, c.axis.Y, c.axis.Z)
+		if get := q.Pow(c.pow); !get.Eq(want) {
+			t.Errorf("TestPowQuat %d %v %v", testIndex, get, want)
+		}
+	}
+
+	// Applying half a rotation twice is the full rotation
+	q.FromEuler(0.3, -1.2, 2.1)
+	half := q.Pow(0.5)
+	if get := half.Mult(half); !get.Eq(q) {
+		t.Errorf("TestPowQuat half %v %v", get, q)
+	}
+	if get := q.Pow(-1); !get.Eq(q.Inverse()) {
+		t.Errorf("TestPowQuat inverse %v %v", get, q.Inverse())
+	}
+
+	// Non unit quaternions scale the norm
+	if get := (Quat{4, 0, 0, 0}).Pow(0.5); !get.Eq(Quat{2, 0, 0, 0}) {
+		t.Errorf("TestPowQuat norm %v", get)
+	}
+}
+
+func TestRotationVectorQuat(t *testing.T) {
+	cases := []struct {
+		q Quat
+		v Vec3
+	}{
+		{Quat{1, 0, 0, 0}, Vec3{0, 0, 0}},
+		{Quat{math.Cos(math.Pi / 4), 0, 0, math.Sin(math.Pi / 4)}, Vec3{0, 0, math.Pi / 2}},
+		{Quat{0, 1, 0, 0}, Vec3{math.Pi, 0, 0}},
+		{Quat{math.Cos(0.25), 0, math.Sin(0.25) * 0.6, math.Sin(0.25) * 0.8}, Vec3{0, 0.3, 0.4}},
+		{Quat{math.Cos(1e-8), math.Sin(1e-8), 0, 0}, Vec3{2e-8, 0, 0}},
+	}
+
+	for testIndex, c := range cases {
+		if get := c.q.RotationVector(); !get.Eq(c.v) {
+			t.Errorf("TestRotationVectorQuat %d %v %v", testIndex, get, c.v)
+		}
+		// q and -q are the same rotation and give the same vector.
+		// At pi both directions about the axis are equally short.
+		if get := c.q.MultScalar(-1).RotationVector(); c.q.W != 0 && !get.Eq(c.v) {
+			t.Errorf("TestRotationVectorQuat negate %d %v %v", testIndex, get, c.v)
+		}
+		var q Quat
+		if q.FromRotationVector(c.v); !q.Eq(c.q) && !q.Eq(c.q.MultScalar(-1)) {
+			t.Errorf("TestRotationVectorQuat from %d %v %v", testIndex, q, c.q)
+		}
+	}
+
+	// Integrating a constant angular velocity matches a single rotation
+	omega := Vec3{0.4, -1.1, 0.7}
+	dt := 0.01
+	var step, q, want Quat
+	step.FromRotationVector(omega.MultScalar(dt))
+	q.Set(1, 0, 0, 0)
+	for i := 0; i < 100; i++ {
+		q = step.Mult(q)
+	}
+	want.FromRotationVector(omega)
+	if !q.Eq(want) {
+		t.Errorf("TestRotationVectorQuat integrate %v %v", q, want)
+	}
+}
+
+func TestTryInverseQuat(t *testing.T) {
+	cases := []struct {
+		q, want Quat
+		ok      bool
+	}{
+		{Quat{1, 0, 0, 0}, Quat{1, 0, 0, 0}, true},
+		{Quat{0, 2, 0, 0}, Quat{0, -0.5, 0, 0}, true},
+		// small quaternions have an exact inverse
+		{Quat{1.0 / 65536, 0, 0, 0}, Quat{65536, 0, 0, 0}, true},
+		{Quat{0, 0, 0, 0}, Quat{}, false},
+		{Quat{math.NaN(), 1, 0, 0}, Quat{}, false},
+	}
+
+	for testIndex, c := range cases {
+		got, ok := c.q.TryInverse()
+		if ok != c.ok || got != c.want {
+			t.Errorf("TestTryInverseQuat %d %v %v %v", testIndex, got, c.want, ok)
+		}
+	}
+}
+
+func TestQuatFromTo(t *testing.T) {
+	cases := []struct {
+		a, b Vec3
+	}{
+		{Vec3{1, 0, 0}, Vec3{0, 1, 0}},
+		{Vec3{1, 2, 3}, Vec3{-3, 0.5, 2}},
+		{Vec3{0, 0, 5}, Vec3{0, 0, 2}},
+		{Vec3{1, 0, 0}, Vec3{-1, 0, 0}},
+		{Vec3{0, 0, 1}, Vec3{0, 0, -3}},
+		{Vec3{1, 2, 3}, Vec3{-1, -2, -3}},
+		{Vec3{1, 0, 0}, Vec3{-1, 1e-9, 0}},
+		{Vec3{1e-10, 0, 0}, Vec3{0, 1e-10, 0}},
+	}
+
+	for testIndex, c := range cases {
+		q := QuatFromTo(c.a, c.b)
+		if !closeEq(q.Norm(), 1, 1e-9) {
+			t.Errorf("TestQuatFromTo %d not unit %v", testIndex, q)
+		}
+		got := q.RotateVec3(c.a.Normalize())
+		if got.Sub(c.b.Normalize()).Length() > 1e-6 {
+			t.Errorf("TestQuatFromTo %d %v %v", testIndex, got, c.b.Normalize())
+		}
+	}
+
+	// the shortest rotation keeps the axis perpendicular to both vectors
+	q := QuatFromTo(Vec3{1, 0, 0}, Vec3{0, 1, 0})
+	want := Quat{}
+	want.FromAxisAngle(math.Pi/2, 0, 0, 1)
+	if !q.ApproxEqRotation(want, DefaultTolerance) {
+		t.Errorf("TestQuatFromTo shortest %v %v", q, want)
+	}
+
+	if q := QuatFromTo(Vec3{}, Vec3{1, 0, 0}); q != QuatIdentity {
+		t.Errorf("TestQuatFromTo zero %v", q)
+	}
+}
+
+func TestQuatLookRotation(t *testing.T) {
+	cases := []struct {
+		forward, up Vec3
+	}{
+		{Vec3Forward, Vec3Up},
+		{Vec3{1, 0, 0}, Vec3Up},
+		{Vec3{0, 0, -1}, Vec3Up},
+		{Vec3{1, 2, 3}, Vec3{0, 1, 0.5}},
+		{Vec3{-2, 1, 0}, Vec3{0, 0, 1}},
+		// short vectors still have a direction
+		{Vec3{1, 0, 0}, Vec3{0, 1e-10, 1e-11}},
+		{Vec3{0, 0, 1e-10}, Vec3{1e-10, 0, 0}},
+	}
+
+	for testIndex, c := range cases {
+		q := QuatLookRotation(c.forward, c.up)
+		f := q.RotateVec3(Vec3Forward)
+		if f.Sub(c.forward.Normalize()).Length() > 1e-9 {
+			t.Errorf("TestQuatLookRotation %d forward %v %v", testIndex, f, c.forward)
+		}
+		// up stays in the plane of forward and up, on the same side as up
+		u := q.RotateVec3(Vec3Up)
+		side := c.forward.Cross(c.up).Normalize()
+		if math.Abs(u.Dot(f)) > 1e-9 || math.Abs(u.Dot(side)) > 1e-9 || u.Dot(c.up) <= 0 {
+			t.Errorf("TestQuatLookRotation %d up %v %v", testIndex, u, c.up)
+		}
+	}
+
+	// up parallel to forward falls back to the shortest rotation
+	q := QuatLookRotation(Vec3{0, 2, 0}, Vec3Up)
+	if !q.ApproxEqRotation(QuatFromTo(Vec3Forward, Vec3Up), DefaultTolerance) {
+		t.Errorf("TestQuatLookRotation parallel %v", q)
+	}
+}
+
+func TestSwingTwistQuat(t *testing.T) {
+	cases := []struct {
+		swingAngle float64
+		swingAxis  Vec3
+		twistAngle float64
+		axis       Vec3
+	}{
+		{0, Vec3{1, 0, 0}, 0, Vec3{0, 1, 0}},
+		{0.5, Vec3{1, 0, 0}, 0.3, Vec3{0, 1, 0}},
+		{1.2, Vec3{0, 0, 1}, -2, Vec3{0, 1, 0}},
+		{0.7, Vec3{0.6, 0, 0.8}, 3, Vec3{0, 2, 0}},
+		{0.4, Vec3{0, 0.6, -0.8}, -1, Vec3{1, 0, 0}},
+		// close to a 180 degree swing the twist is small but still defined
+		{math.Pi - 2e-5, Vec3{1, 0, 0}, 0.3, Vec3{0, 1, 0}},
+		{math.Pi - 1e-9, Vec3{0, 0, 1}, -2, Vec3{1, 0, 0}},
+	}
+
+	for testIndex, c := range cases {
+		a := c.axis.Normalize()
+		wantSwing, wantTwist := Quat{}, Quat{}
+		wantSwing.FromAxisAngle(c.swingAngle, c.swingAxis.X, c.swingAxis.Y, c.swingAxis.Z)
+		wantTwist.FromAxisAngle(c.twistAngle, a.X, a.Y, a.Z)
+		q := wantSwing.Mult(wantTwist)
+
+		swing, twist := q.SwingTwist(c.axis)
+		if !swing.Mult(twist).ApproxEqRotation(q, AbsTolerance(1e-9)) {
+			t.Errorf("TestSwingTwistQuat %d rebuilt %v %v", testIndex, swing.Mult(twist), q)
+		}
+		if !swing.ApproxEqRotation(wantSwing, AbsTolerance(1e-9)) || !twist.ApproxEqRotation(wantTwist, AbsTolerance(1e-9)) {
+			t.Errorf("TestSwingTwistQuat %d %v %v %v %v", testIndex, swing, wantSwing, twist, wantTwist)
+		}
+		// the swing axis is perpendicular to the twist axis
+		if d := a.Dot(Vec3{swing.X, swing.Y, swing.Z}); math.Abs(d) > 1e-9 {
+			t.Errorf("TestSwingTwistQuat %d swing axis %v %v", testIndex, swing, d)
+		}
+		if angle := q.TwistAngle(c.axis); !closeEq(angle, c.twistAngle, 1e-9) {
+			t.Errorf("TestSwingTwistQuat %d angle %v %v", testIndex, angle, c.twistAngle)
+		}
+	}
+
+	// a swing of 180 degrees leaves the twist undefined
+	q := Quat{0, 1, 0, 0}
+	swing, twist := q.SwingTwist(Vec3{0, 1, 0})
+	if twist != QuatIdentity || !swing.ApproxEqRotation(q, DefaultTolerance) {
+		t.Errorf("TestSwingTwistQuat 180 %v %v", swing, twist)
+	}
+}
+
+func TestClampTwistQuat(t *testing.T) {
+	axis := Vec3{0, 1, 0}
+	swing := Quat{}
+	swing.FromAxisAngle(0.5, 1, 0, 0)
+
+	cases := []struct {
+		angle, want float64
+	}{
+		{0.2, 0.2},
+		{0.8, 0.5},
+		{-1.5, -0.25},
+		{3, 0.5},
+	}
+
+	for testIndex, c := range cases {
+		twist := Quat{}
+		twist.FromAxisAngle(c.angle, 0, 1, 0)
+		got := swing.Mult(twist).ClampTwist(axis, -0.25, 0.5)
+		if angle := got.TwistAngle(axis); !closeEq(angle, c.want, 1e-9) {
+			t.Errorf("TestClampTwistQuat %d %v %v", testIndex, angle, c.want)
+		}
+		if s, _ := got.SwingTwist(axis); !s.ApproxEqRotation(swing, AbsTolerance(1e-9)) {
+			t.Errorf("TestClampTwistQuat %d swing %v %v", testIndex, s, swing)
+		}
+	}
+}
+
+func TestClampSwingQuat(t *testing.T) {
+	axis := Vec3{0, 1, 0}
+	twist := Quat{}
+	twist.FromAxisAngle(0.3, 0, 1, 0)
+
+	cases := []struct {
+		angle           float64
+		swingAxis       Vec3
+		wantCone        float64
+		wantX, wantNegZ float64
+	}{
+		{0.1, Vec3{1, 0, 0}, 0.1, 0.1, 0},
+		{0.4, Vec3{1, 0, 0}, 0.4, 0.4, 0},
+		{1, Vec3{1, 0, 0}, 0.6, 0.5, 0},
+		{0.4, Vec3{0, 0, -1}, 0.4, 0, 0.2},
+		{-1, Vec3{0, 0, -1}, 0.6, 0, -0.2},
+		{0.5, Vec3{0.6, 0, 0.8}, 0.5, 0.3 / math.Sqrt(0.36+4), -0.4 / math.Sqrt(0.36+4)},
+	}
+
+	for testIndex, c := range cases {
+		swing := Quat{}
+		swing.FromAxisAngle(c.angle, c.swingAxis.X, c.swingAxis.Y, c.swingAxis.Z)
+		q := swing.Mult(twist)
+
+		cone := q.ClampSwingCone(axis, 0.6)
+		s, tw := cone.SwingTwist(axis)
+		if angle := s.RotationVector().Length(); !closeEq(angle, c.wantCone, 1e-9) {
+			t.Errorf("TestClampSwingQuat %d cone %v %v", testIndex, angle, c.wantCone)
+		}
+		if !tw.ApproxEqRotation(twist, AbsTolerance(1e-9)) {
+			t.Errorf("TestClampSwingQuat %d cone twist %v", testIndex, tw)
+		}
+
+		ellipse := q.ClampSwingEllipse(axis, Vec3{1, 0, 0}, 0.5, 0.2)
+		s, tw = ellipse.SwingTwist(axis)
+		v := s.RotationVector()
+		if !closeEq(v.X, c.wantX, 1e-9) || !closeEq(-v.Z, c.wantNegZ, 1e-9) || !closeEq(v.Y, 0, 1e-9) {
+			t.Errorf("TestClampSwingQuat %d ellipse %v %v %v", testIndex, v, c.wantX, c.wantNegZ)
+		}
+		if !tw.ApproxEqRotation(twist, AbsTolerance(1e-9)) {
+			t.Errorf("TestClampSwingQuat %d ellipse twist %v", testIndex, tw)
+		}
+	}
+}
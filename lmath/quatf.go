@@ -0,0 +1,573 @@
+package lmath
+
+import (
+	"math"
+)
+
+// A float32 quaternion with the same semantics as Quat.
+// When using as a rotation quaternion, you must ensure that the quaternion is
+// unit length. Operations which need trigonometry are evaluated with float64
+// and rounded.
+type Quatf struct {
+	W, X, Y, Z float32
+}
+
+var (
+	QuatfIdentity = Quatf{1, 0, 0, 0}
+	QuatfZero     = Quatf{0, 0, 0, 0}
+)
+
+// Return true if all elements are equal between both quaternions.
+// Comparisons are done using an epsilon32 (< 0.00001)
+func (this Quatf) Eq(other Quatf) bool {
+	return closeEq32(this.X, other.X, epsilon32) &&
+		closeEq32(this.Y, other.Y, epsilon32) &&
+		closeEq32(this.Z, other.Z, epsilon32) &&
+		closeEq32(this.W, other.W, epsilon32)
+}
+
+// Equal if all elements are equal within the tolerance
+func (this Quatf) ApproxEq(other Quatf, tol Tolerance) bool {
+	return tol.Eq32(this.W, other.W) &&
+		tol.Eq32(this.X, other.X) &&
+		tol.Eq32(this.Y, other.Y) &&
+		tol.Eq32(this.Z, other.Z)
+}
+
+// Return true if the quaternions represent the same rotation within the
+// tolerance. q and -q are the same rotation so either sign is accepted.
+func (this Quatf) ApproxEqRotation(other Quatf, tol Tolerance) bool {
+	return this.ApproxEq(other, tol) || this.ApproxEq(other.MultScalar(-1), tol)
+}
+
+// Return true if no element is NaN or infinite.
+func (this Quatf) IsFinite() bool {
+	return isFinite(float64(this.W)) &&
+		isFinite(float64(this.X)) &&
+		isFinite(float64(this.Y)) &&
+		isFinite(float64(this.Z))
+}
+
+// Return true if any element is NaN.
+func (this Quatf) HasNaN() bool {
+	return math.IsNaN(float64(this.W)) ||
+		math.IsNaN(float64(this.X)) ||
+		math.IsNaN(float64(this.Y)) ||
+		math.IsNaN(float64(this.Z))
+}
+
+// Set the component of the quaternion. Return this
+func (this *Quatf) Set(w, x, y, z float32) *Quatf {
+	this.W = w
+	this.X = x
+	this.Y = y
+	this.Z = z
+	return this
+}
+
+// Add the two quaternions (q + other).
+// Returns a new quaternion with the result.
+func (this Quatf) Add(other Quatf) Quatf {
+	this.AddIn(other)
+	return this
+}
+
+// Add two quaternionns (q + other) storing the result in this. Returns this.
+func (this *Quatf) AddIn(other Quatf) *Quatf {
+	this.W += other.W
+	this.X += other.X
+	this.Y += other.Y
+	this.Z += other.Z
+	return this
+}
+
+// Subtract the two quaternions (q - other).
+// Returns a new quaternion with the result.
+func (this Quatf) Sub(other Quatf) Quatf {
+	this.SubIn(other)
+	return this
+}
+
+// Sub two quaternionns (q - other). Result is stored in this. Return this.
+func (this *Quatf) SubIn(other Quatf) *Quatf {
+	this.W -= other.W
+	this.X -= other.X
+	this.Y -= other.Y
+	this.Z -= other.Z
+	return this
+}
+
+// Multiply the two quaterions.
+// Return a new quaterion with the result.
+// Multiplying quaternions is NOT commutative (order matters!).
+func (this Quatf) Mult(q Quatf) Quatf {
+	this.MultIn(q)
+	return this
+}
+
+// Multiply 'this' with the other quaternion.
+// Store the result into 'this'.
+// Return a pointer to 'this'.
+// Multiplying quaternions is NOT commutative (order matters!).
+func (this *Quatf) MultIn(other Quatf) *Quatf {
+	w := this.W*other.W - this.X*other.X - this.Y*other.Y - this.Z*other.Z
+	x := this.W*other.X + this.X*other.W + this.Y*other.Z - this.Z*other.Y
+	y := this.W*other.Y + this.Y*other.W - this.X*other.Z + this.Z*other.X
+	z := this.W*other.Z + this.Z*other.W + this.X*other.Y - this.Y*other.X
+
+	this.X = x
+	this.Y = y
+	this.Z = z
+	this.W = w
+	return this
+}
+
+// Add a scalar quantity to the quaternion.
+// Returns a new quaternion with the result.
+func (this Quatf) AddScalar(val float32) Quatf {
+	this.AddInScalar(val)
+	return this
+}
+
+// Add a scalar quantity to the quaternion. Returns this.
+func (this *Quatf) AddInScalar(val float32) *Quatf {
+	this.W += val
+	this.X += val
+	this.Y += val
+	this.Z += val
+	return this
+}
+
+// Subtract a scalar quantity to the quaternion.
+// Returns a new quaternion with the result.
+func (this Quatf) SubScalar(val float32) Quatf {
+	this.SubInScalar(val)
+	return this
+}
+
+// Add a scalar quantity to the quaternion. Returns this.
+func (this *Quatf) SubInScalar(val float32) *Quatf {
+	this.W -= val
+	this.X -= val
+	this.Y -= val
+	this.Z -= val
+	return this
+}
+
+// Multiply a scalar quantity to the quaternion.
+// Returns a new quaternion with the result.
+func (this Quatf) MultScalar(val float32) Quatf {
+	this.MultInScalar(val)
+	return this
+}
+
+// Add a scalar quantity to the quaternion. Returns this.
+func (this *Quatf) MultInScalar(val float32) *Quatf {
+	this.W *= val
+	this.X *= val
+	this.Y *= val
+	this.Z *= val
+	return this
+}
+
+// Divide a scalar quantity to the quaternion.
+// Returns a new quaternion with the result.
+func (this Quatf) DivScalar(val float32) Quatf {
+	this.DivInScalar(val)
+	return this
+}
+
+// Add a scalar quantity to the quaternion.
+// Returns this.
+func (this *Quatf) DivInScalar(val float32) *Quatf {
+	this.W /= val
+	this.X /= val
+	this.Y /= val
+	this.Z /= val
+	return this
+}
+
+// Make this quaterion into a unit length quaternion.
+// Returns a pointer to this.
+func (this *Quatf) ToUnit() *Quatf {
+	n := this.Norm()
+	if n == 0 {
+		return this
+	}
+	return this.DivInScalar(n)
+}
+
+// Returns the norm of this quaternion.
+// sqrt(x^2 + y^2 + z^2 + w^2)
+func (this Quatf) Norm() float32 {
+	return sqrt32(this.NormSq())
+}
+
+// Returns the squared norm of this quaternion.
+// So that we can save a math.Sqrt.
+// x^2 + y^2 + z^2 + w^2
+func (this Quatf) NormSq() float32 {
+	return this.X*this.X +
+		this.Y*this.Y +
+		this.Z*this.Z +
+		this.W*this.W
+}
+
+// Return a new quaternion which is the conjugate of this
+// Conjugate is defined as [w,-x,-y,-z]
+func (this Quatf) Conjugate() Quatf {
+	this.ConjugateIn()
+	return this
+}
+
+// Set the quaternion to the conjugate
+// Conjugate is defined as [w,-x,-y,-z]
+func (this *Quatf) ConjugateIn() *Quatf {
+	this.X = -this.X
+	this.Y = -this.Y
+	this.Z = -this.Z
+	return this
+}
+
+// Return a new quaternion which is the inverse of this
+func (this Quatf) Inverse() Quatf {
+	this.InverseIn()
+	return this
+}
+
+// Set this quaternion as the inverse
+func (this *Quatf) InverseIn() *Quatf {
+	this.ConjugateIn()
+	n := this.NormSq()
+	if n == 0 {
+		return this
+	}
+	return this.DivInScalar(n)
+}
+
+// Return the inverse of the quaternion and true, or the zero quaternion and
+// false when the quaternion is zero or not finite. Any other quaternion has
+// an exact inverse, however small it is.
+func (this Quatf) TryInverse() (Quatf, bool) {
+	if this.NormSq() == 0 || !this.IsFinite() {
+		return Quatf{}, false
+	}
+	out := this.Inverse()
+	if !out.IsFinite() {
+		return Quatf{}, false
+	}
+	return out, true
+}
+
+// Create a copy of this Quaternion and return the result.
+func (this Quatf) Copy() Quatf {
+	return this
+}
+
+// Returns the 4D dot product between 'this' and the other quaternion.
+// For unit quaternions this is the cosine of half the angle between them.
+func (this Quatf) Dot(other Quatf) float32 {
+	return this.W*other.W + this.X*other.X + this.Y*other.Y + this.Z*other.Z
+}
+
+// Apply this quaternion as a rotation to the vec3.
+// Perform the operation q * v * q^-1
+// Return a new vector with result
+func (this Quatf) RotateVec3(v Vec3f) (out Vec3f) {
+	vq := Quatf{0.0, v.X, v.Y, v.Z}
+	rs := this.Mult(vq).Mult(this.Inverse())
+	out.Set(rs.X, rs.Y, rs.Z)
+	return
+}
+
+// Set the quaternion from the specified rotation matrix.
+// Assumption is that the matrix is a valid rotation matrix.
+// Matrix should be in right-hand coordinate system
+// Pitch-Yaw-Roll euler angle formation
+// Return this
+func (this *Quatf) fromMat(m [16]float32) *Quatf {
+	// Reference : http://www.flipcode.com/documents/matrfaq.html#Q55
+	// 0  1  2  3
+	// 4  5  6  7
+	// 8  9  10 11
+	// 12 13 14 15
+	// trace := m.Get(0, 0) + m.Get(1, 1) + m.Get(2, 2) + 1
+	trace := m[0] + m[5] + m[10] + 1
+
+	// Only use the trace when it is large enough to divide by safely
+	if trace > 1 {
+		s := 0.5 / sqrt32(trace)
+		this.Set(
+			0.25/s,
+			(m[9]-m[6])*s,
+			(m[2]-m[8])*s,
+			(m[4]-m[1])*s,
+		)
+		return this
+	}
+
+	// Find the column which has the maximum diagonal value
+	test_cols := [3]int{0, 5, 10}
+	max_col := 0
+	champ := m[test_cols[0]]
+	for col := 1; col < 3; col += 1 {
+		cand := m[test_cols[col]]
+		//cand := m.Get(col, col)
+		if cand > champ {
+			champ = cand
+			max_col = col
+		}
+	}
+
+	// s is 4 times the largest of x,y or z
+	var w, x, y, z, s float32
+	switch max_col {
+	case 0:
+		s = 2 * sqrt32(1.0+m[0]-m[5]-m[10])
+		x = 0.25 * s
+		y = (m[4] + m[1]) / s
+		z = (m[8] + m[2]) / s
+		w = (m[9] - m[6]) / s
+	case 1:
+		s = 2 * sqrt32(1.0+m[5]-m[0]-m[10])
+		x = (m[4] + m[1]) / s
+		y = 0.25 * s
+		z = (m[9] + m[6]) / s
+		w = (m[2] - m[8]) / s
+	case 2:
+		s = 2 * sqrt32(1.0+m[10]-m[0]-m[5])
+		x = (m[8] + m[2]) / s
+		y = (m[9] + m[6]) / s
+		z = 0.25 * s
+		w = (m[4] - m[1]) / s
+	}
+
+	this.Set(w, x, y, z)
+	return this
+}
+
+// Return a mat4 from the provided quaternion
+func (this Quatf) mat() (m [16]float32) {
+	// Reference
+	// Derivation of the below matrix can be found here
+	// http://www.euclideanspace.com/maths/geometry/rotations/conversions/quaternionToMatrix/index.htm
+	//     1 - 2y² - 2z²    2yx - 2wz        2xz + 2wy
+	// M=  2xy + 2wz        1 - 2x² - 2z²    2yz - 2wx
+	//     2xz - 2wy        2yz + 2wx        1 - 2x² - 2y²
+
+	w, x, y, z := this.W, this.X, this.Y, this.Z
+
+	// 0 1 2 3
+	// 4 5 6 7
+	// 8 9 10 11
+	// 12 13 14 15
+	m[0] = 1 - 2*y*y - 2*z*z
+	m[1] = 2*x*y - 2*w*z
+	m[2] = 2*x*z + 2*w*y
+	m[3] = 0
+
+	m[4] = 2*x*y + 2*w*z
+	m[5] = 1 - 2*x*x - 2*z*z
+	m[6] = 2*y*z - 2*w*x
+	m[7] = 0
+
+	m[8] = 2*x*z - 2*w*y
+	m[9] = 2*y*z + 2*w*x
+	m[10] = 1 - 2*x*x - 2*y*y
+	m[11] = 0
+
+	m[12] = 0
+	m[13] = 0
+	m[14] = 0
+	m[15] = 1
+	return m
+}
+
+func (this *Quatf) FromMat4(m Mat4f) *Quatf {
+	values := m.Dump()
+	return this.fromMat(values)
+}
+
+func (this *Quatf) FromMat3(m Mat3f) *Quatf {
+	values := [16]float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}
+	mxs := m.Dump()
+	values[0] = mxs[0]
+	values[1] = mxs[1]
+	values[2] = mxs[2]
+	values[4] = mxs[3]
+	values[5] = mxs[4]
+	values[6] = mxs[5]
+	values[8] = mxs[6]
+	values[9] = mxs[7]
+	values[10] = mxs[8]
+
+	return this.fromMat(values)
+}
+
+func (this Quatf) Mat4f() Mat4f {
+	values := this.mat()
+	m := Mat4f{}
+	m.Load(values)
+	return m
+}
+
+func (this Quatf) Mat3f() Mat3f {
+	v := this.mat()
+	m := Mat3f{}
+	m.Load([9]float32{
+		v[0], v[1], v[2],
+		v[4], v[5], v[6],
+		v[8], v[9], v[10],
+	})
+	return m
+}
+
+// Create a rotation quaternion of angle (radians) about the unit axis x,y,z.
+// Return this
+func (this *Quatf) FromAxisAngle(angle float32, x, y, z float32) *Quatf {
+	sin, cos := math.Sincos(float64(angle) / 2)
+	this.Set(float32(cos), float32(sin)*x, float32(sin)*y, float32(sin)*z)
+	return this
+}
+
+// Create a quaternion from the specified euler angles (radians).
+// See Quat.FromEuler
+// Return this
+func (this *Quatf) FromEuler(pitch, yaw, roll float32) *Quatf {
+	q := Quat{}
+	q.FromEuler(float64(pitch), float64(yaw), float64(roll))
+	*this = q.Quatf()
+	return this
+}
+
+// Return the pitch, yaw and roll euler angles (radians) of the rotation.
+// See Quat.Euler
+func (this Quatf) Euler() (pitch, yaw, roll float32) {
+	p, y, r := this.Quat().Euler()
+	return float32(p), float32(y), float32(r)
+}
+
+// Return the angle (radians) and the unit axis of rotation of the unit
+// quaternion.
+func (this Quatf) AxisAngle() (angle, x, y, z float32) {
+	a, x64, y64, z64 := this.Quat().AxisAngle()
+	return float32(a), float32(x64), float32(y64), float32(z64)
+}
+
+// Spherically interpolate between the two unit quaternions a and b.
+// See Slerp
+func Slerpf(a, b Quatf, inc float32) Quatf {
+	return Slerp(a.Quat(), b.Quat(), float64(inc)).Quatf()
+}
+
+// Linearly interpolate between the two unit quaternions a and b and
+// normalize the result. See Nlerp
+func Nlerpf(a, b Quatf, inc float32) Quatf {
+	if a.Dot(b) < 0 {
+		b.MultInScalar(-1)
+	}
+	out := a.MultScalar(1 - inc).Add(b.MultScalar(inc))
+	out.ToUnit()
+	return out
+}
+
+// Return a new vector holding the axis component of the quaternion.
+// See Quat.Axis
+func (this Quatf) Axis() Vec3f {
+	return this.Quat().Axis().Vec3f()
+}
+
+// Return the angle (radians) component of the quaternion
+func (this Quatf) Angle() float32 {
+	return float32(this.Quat().Angle())
+}
+
+// Returns the quaternion natural logarithm. See Quat.Log
+func (this Quatf) Log() Quatf {
+	this.LogIn()
+	return this
+}
+
+// Set this quaternion to its natural logarithm. Returns this.
+func (this *Quatf) LogIn() *Quatf {
+	*this = this.Quat().Log().Quatf()
+	return this
+}
+
+// Returns the quaternion exponential. See Quat.Exp
+func (this Quatf) Exp() Quatf {
+	this.ExpIn()
+	return this
+}
+
+// Set this quaternion to its exponential. Returns this.
+func (this *Quatf) ExpIn() *Quatf {
+	*this = this.Quat().Exp().Quatf()
+	return this
+}
+
+// Raise the quaternion to the power t. See Quat.Pow
+// Returns a new quaternion with the result.
+func (this Quatf) Pow(t float32) Quatf {
+	this.PowIn(t)
+	return this
+}
+
+// Raise this quaternion to the power t. Returns this.
+func (this *Quatf) PowIn(t float32) *Quatf {
+	*this = this.Quat().Pow(float64(t)).Quatf()
+	return this
+}
+
+// Return the rotation vector (axis * angle) of this unit quaternion.
+// See Quat.RotationVector
+func (this Quatf) RotationVector() Vec3f {
+	return this.Quat().RotationVector().Vec3f()
+}
+
+// Set this quaternion as the rotation described by the rotation vector
+// (axis * angle). Return this
+func (this *Quatf) FromRotationVector(v Vec3f) *Quatf {
+	q := Quat{}
+	q.FromRotationVector(v.Vec3())
+	*this = q.Quatf()
+	return this
+}
+
+// Set this as the rotation from the euler angles (radians) applied in the
+// given order. See Quat.FromEulerOrder
+func (this *Quatf) FromEulerOrder(order EulerOrder, a, b, c float32) *Quatf {
+	q := Quat{}
+	q.FromEulerOrder(order, float64(a), float64(b), float64(c))
+	*this = q.Quatf()
+	return this
+}
+
+// Return the euler angles (radians) of the rotation in the given order.
+// See Quat.EulerOrder
+func (this Quatf) EulerOrder(order EulerOrder) (a, b, c float32) {
+	a64, b64, c64 := this.Quat().EulerOrder(order)
+	return float32(a64), float32(b64), float32(c64)
+}
+
+// Spherical quadrangle interpolation between q1 and q2 using the control
+// points s1 and s2. See Squad
+func Squadf(q1, q2, s1, s2 Quatf, inc float32) Quatf {
+	return Squad(q1.Quat(), q2.Quat(), s1.Quat(), s2.Quat(), float64(inc)).Quatf()
+}
+
+// Return the Squad control point for q given its neighbours.
+// See SquadControl
+func SquadControlf(prev, q, next Quatf) Quatf {
+	return SquadControl(prev.Quat(), q.Quat(), next.Quat()).Quatf()
+}
+
+// convert to the float64 Quat
+func (this Quatf) Quat() Quat {
+	return Quat{float64(this.W), float64(this.X), float64(this.Y), float64(this.Z)}
+}
+
+// convert to the float32 Quatf
+func (this Quat) Quatf() Quatf {
+	return Quatf{float32(this.W), float32(this.X), float32(this.Y), float32(this.Z)}
+}
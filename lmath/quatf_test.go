@@ -0,0 +1,1009 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqQuatf(t *testing.T) {
+	cases := []struct {
+		orig, other Quatf
+		want        bool
+	}{
+		{Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}, true},
+		{Quatf{1, 0, 0, 0}, Quatf{0, 0, 0, 0}, false},
+		{Quatf{1, 2, 3, 4}, Quatf{1.000, 2.000, 3.00, 4.0}, true},
+		{Quatf{-1, -2, 3, 4}, Quatf{-1, -2, 3, 4}, true},
+		{Quatf{-1, 2, 3, 4}, Quatf{-1, -2, 3, 4}, false},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Eq(test.other)
+		if get != test.want {
+			t.Errorf("TestEqQuatf %d", testIndex)
+		}
+	}
+}
+
+func TestSetQuatf(t *testing.T) {
+	cases := []struct {
+		x, y, z, w float32
+		want       Quatf
+	}{
+		{0, 0, 0, 0, Quatf{0, 0, 0, 0}},
+		{1, 2, 3, 4, Quatf{1, 2, 3, 4}},
+		{0.1, 0.2, 0.3, 0.4, Quatf{0.1, 0.2, 0.3, 0.4}},
+		{-0.1, 0.2, -0.3, 0.4, Quatf{-0.1, 0.2, -0.3, 0.4}},
+	}
+
+	for testIndex, test := range cases {
+		orig := Quatf{-1, -1, -1, -1}
+		get := orig.Set(test.x, test.y, test.z, test.w)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSetQuatf %d", testIndex)
+		}
+	}
+}
+
+func TestAddQuatf(t *testing.T) {
+	cases := []struct {
+		orig, other, want Quatf
+	}{
+		{Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}},
+		{Quatf{0, 0, 0, 0}, Quatf{1, 2, 3, 4}, Quatf{1, 2, 3, 4}},
+		{Quatf{1, 0, 0, 0}, Quatf{0, 1, 0, 0}, Quatf{1, 1, 0, 0}},
+		{Quatf{0, 1, 0, 0}, Quatf{1, 0, 0, 0}, Quatf{1, 1, 0, 0}},
+		{Quatf{1, 2, 3, 4}, Quatf{5, 6, 7, 8}, Quatf{6, 8, 10, 12}},
+		{Quatf{1, -2, 3, -4}, Quatf{1, -2, 3, -4}, Quatf{2, -4, 6, -8}},
+		{Quatf{1, 2, 3, 4}, Quatf{1, -2, 3, -4}, Quatf{2, 0, 6, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Add(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestAddQuatf %d", testIndex)
+		}
+
+		get2 := test.orig.AddIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestAddInQuatf %d", testIndex)
+		}
+	}
+}
+
+func TestSubQuatf(t *testing.T) {
+	cases := []struct {
+		orig, other, want Quatf
+	}{
+		{Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}},
+		{Quatf{0, 0, 0, 0}, Quatf{1, 2, 3, 4}, Quatf{-1, -2, -3, -4}},
+		{Quatf{1, 0, 0, 0}, Quatf{0, 1, 0, 0}, Quatf{1, -1, 0, 0}},
+		{Quatf{0, 1, 0, 0}, Quatf{1, 0, 0, 0}, Quatf{-1, 1, 0, 0}},
+		{Quatf{1, 2, 3, 4}, Quatf{5, 6, 7, 8}, Quatf{-4, -4, -4, -4}},
+		{Quatf{1, -2, 3, -4}, Quatf{1, -2, 3, -4}, Quatf{0, 0, 0, 0}},
+		{Quatf{1, 2, 3, 4}, Quatf{1, -2, 3, -4}, Quatf{0, 4, 0, 8}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Sub(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSubQuatf %d", testIndex)
+		}
+
+		get2 := test.orig.SubIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestSubInQuatf %d", testIndex)
+		}
+	}
+}
+
+func TestMultQuatf(t *testing.T) {
+	cases := []struct {
+		orig, other, want Quatf
+	}{
+		{Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}},
+		{Quatf{0, 0, 0, 0}, Quatf{1, 2, 3, 4}, Quatf{0, 0, 0, 0}},
+		{Quatf{1, 0, 0, 0}, Quatf{0, 1, 0, 0}, Quatf{0, 1, 0, 0}},
+		{Quatf{0, 1, 0, 0}, Quatf{1, 0, 0, 0}, Quatf{0, 1, 0, 0}},
+		{Quatf{1, 2, 3, 4}, Quatf{5, 6, 7, 8}, Quatf{-60, 12, 30, 24}},
+		{Quatf{1, -2, 3, -4}, Quatf{1, -2, 3, -4}, Quatf{-28, -4, 6, -8}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Mult(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestMultQuatf %d %v", testIndex, get)
+		}
+
+		get2 := test.orig.MultIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestMultInQuatf %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestAddScalarQuatf(t *testing.T) {
+	cases := []struct {
+		orig, want Quatf
+		scale      float32
+	}{
+		{Quatf{0, 0, 0, 0}, Quatf{2, 2, 2, 2}, 2},
+		{Quatf{0, 0, 0, 0}, Quatf{-1, -1, -1, -1}, -1},
+		{Quatf{1, 2, 3, 4}, Quatf{0, 1, 2, 3}, -1},
+		{Quatf{1, 2, 3, 4}, Quatf{3, 4, 5, 6}, 2},
+		{Quatf{1, 2, 3, 4}, Quatf{1, 2, 3, 4}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.AddScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestAddScalarQuatf %d", testIndex)
+		}
+
+		get2 := test.orig.AddInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestAddInScalarQuatf %d", testIndex)
+		}
+	}
+}
+
+func TestSubScalarQuatf(t *testing.T) {
+	cases := []struct {
+		orig, want Quatf
+		scale      float32
+	}{
+		{Quatf{0, 0, 0, 0}, Quatf{-2, -2, -2, -2}, 2},
+		{Quatf{0, 0, 0, 0}, Quatf{1, 1, 1, 1}, -1},
+		{Quatf{1, 2, 3, 4}, Quatf{2, 3, 4, 5}, -1},
+		{Quatf{1, 2, 3, 4}, Quatf{-1, 0, 1, 2}, 2},
+		{Quatf{1, 2, 3, 4}, Quatf{1, 2, 3, 4}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.SubScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSubScalarQuatf %d", testIndex)
+		}
+
+		get2 := test.orig.SubInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestSubInScalarQuatf %d", testIndex)
+		}
+	}
+}
+
+func TestMultScalarQuatf(t *testing.T) {
+	cases := []struct {
+		orig, want Quatf
+		scale      float32
+	}{
+		{Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}, 2},
+		{Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}, -1},
+		{Quatf{1, 2, 3, 4}, Quatf{-1, -2, -3, -4}, -1},
+		{Quatf{1, 2, 3, 4}, Quatf{2, 4, 6, 8}, 2},
+		{Quatf{1, 2, 3, 4}, Quatf{0, 0, 0, 0}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.MultScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestMultScalarQuatf %d", testIndex)
+		}
+
+		get2 := test.orig.MultInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestMultInScalarQuatf %d", testIndex)
+		}
+	}
+}
+
+func TestDivScalarQuatf(t *testing.T) {
+	cases := []struct {
+		orig, want Quatf
+		scale      float32
+	}{
+		{Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}, 2},
+		{Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}, -1},
+		{Quatf{1, 2, 3, 4}, Quatf{-1, -2, -3, -4}, -1},
+		{Quatf{1, 2, 3, 4}, Quatf{0.5, 1, 1.5, 2}, 2},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.DivScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestDivScalarQuatf %d", testIndex)
+		}
+
+		get2 := test.orig.DivInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestDivInScalarQuatf %d", testIndex)
+		}
+	}
+}
+
+func TestToUnitQuatf(t *testing.T) {
+	mag := sqrt32(30)
+	cases := []struct {
+		orig, want Quatf
+	}{
+		{Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}},
+		{Quatf{1, 0, 0, 0}, Quatf{1, 0, 0, 0}},
+		{Quatf{0, 1, 0, 0}, Quatf{0, 1, 0, 0}},
+		{Quatf{0, -1, 0, 0}, Quatf{0, -1, 0, 0}},
+		{Quatf{1, 2, 3, 4}, Quatf{1 / mag, 2 / mag, 3 / mag, 4 / mag}},
+		{Quatf{1 / mag, 2 / mag, 3 / mag, 4 / mag}, Quatf{1 / mag, 2 / mag, 3 / mag, 4 / mag}},
+		{Quatf{1, -2, 3, -4}, Quatf{1 / mag, -2 / mag, 3 / mag, -4 / mag}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.ToUnit()
+		if get.Eq(test.want) == false {
+			t.Errorf("TestToUnitQuatf %d", testIndex)
+		}
+	}
+}
+
+func TestNormQuatf(t *testing.T) {
+	mag := sqrt32(30)
+
+	cases := []struct {
+		orig Quatf
+		want float32
+	}{
+		{Quatf{0, 0, 0, 0}, 0},
+		{Quatf{1, 0, 0, 0}, 1},
+		{Quatf{0, 1, 0, 0}, 1},
+		{Quatf{0, -1, 0, 0}, 1},
+		{Quatf{1, 2, 3, 4}, mag},
+		{Quatf{1 / mag, 2 / mag, 3 / mag, 4 / mag}, 1},
+		{Quatf{1, -2, 3, -4}, mag},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Norm()
+		if closeEq32(get, test.want, epsilon32) == false {
+			t.Errorf("TestNormQuatf %d", testIndex)
+		}
+
+		get = test.orig.NormSq()
+		if closeEq32(get, test.want*test.want, epsilon32) == false {
+			t.Errorf("TestNormSqQuatf %d", testIndex)
+		}
+	}
+}
+
+func TestConjugateQuatf(t *testing.T) {
+	mag := sqrt32(30)
+	cases := []struct {
+		orig, want Quatf
+	}{
+		{Quatf{0, 0, 0, 0}, Quatf{0, 0, 0, 0}},
+		{Quatf{1, 0, 0, 0}, Quatf{1, 0, 0, 0}},
+		{Quatf{1, 1, 0, 0}, Quatf{1, -1, 0, 0}},
+		{Quatf{1, -1, 0, 0}, Quatf{1, 1, 0, 0}},
+		{Quatf{1, 2, 3, 4}, Quatf{1, -2, -3, -4}},
+		{Quatf{1 / mag, 2 / mag, 3 / mag, 4 / mag}, Quatf{1 / mag, -2 / mag, -3 / mag, -4 / mag}},
+		{Quatf{1, -2, 3, -4}, Quatf{1, 2, -3, 4}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Conjugate()
+		if get.Eq(test.want) == false {
+			t.Errorf("TestConjugateQuatf %d", testIndex)
+		}
+
+		get2 := test.orig.ConjugateIn()
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestConjugateInQuatf %d", testIndex)
+		}
+
+	}
+}
+
+func TestInverseQuatf(t *testing.T) {
+	cases := []struct {
+		orig Quatf
+	}{
+		{Quatf{1, 0, 0, 0}},
+		{Quatf{1, 1, 0, 0}},
+		{Quatf{1, -1, 0, 0}},
+		{Quatf{1, 2, 3, 4}},
+		{Quatf{1, -2, 3, -4}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Inverse().Mult(test.orig)
+		if get.Eq(QuatfIdentity) == false {
+			t.Errorf("TestInverse %d", testIndex)
+		}
+
+		orig := test.orig
+		get2 := test.orig.InverseIn().MultIn(orig)
+		if get2.Eq(QuatfIdentity) == false {
+			t.Errorf("TestInverseIn %d", testIndex)
+		}
+	}
+}
+
+func TestFromAxisAngleQuatf(t *testing.T) {
+	cases := []struct {
+		angle     float32
+		axis      Vec3f
+		start_vec Vec3f
+		want      Vec3f
+	}{
+		//test basic rotations using a [1,0,0] vector
+		{90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{90, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{-90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{-90, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{360, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{180, Vec3f{0, 0, 1}, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}},
+
+		//test basic rotations using a [0,1,0] vector
+		{90, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{90, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}},
+		{-90, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{-90, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{360, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{180, Vec3f{0, 0, 1}, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}},
+
+		// test negative axes
+		{90, Vec3f{0, -1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{90, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{90, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{-90, Vec3f{0, -1, 0}, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{-90, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{-90, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{360, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{180, Vec3f{0, 0, -1}, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}},
+
+		// test arbitraty axis
+		{360, Vec3f{1, 1, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{90, Vec3f{1, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0.5, 0.5, -0.7071067811}},
+		{45, Vec3f{1, 1, 0}, Vec3f{1, 0, 0}, Vec3f{0.85355339059, 0.1464466094067, -0.5}},
+	}
+
+	q := Quatf{}
+	for testIndex, c := range cases {
+		c.axis.NormalizeIn()
+		q.FromAxisAngle(float32(Radians(float64(c.angle))), c.axis.X, c.axis.Y, c.axis.Z)
+		get := q.RotateVec3(c.start_vec)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestFromAxisAngle %d \n %v\n%v\n\n", testIndex, q, get)
+		}
+	}
+}
+
+func TestFromMat4Quatf(t *testing.T) {
+	common_cases := []struct {
+		pitch, yaw, roll float32
+		start_vec        Vec3f
+		want             Vec3f
+	}{
+		//test basic rotations using a [0,1,0] vector
+		// pitch,yaw,roll
+		{0, 0, 90, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}},
+		{0, 90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{0, 0, -90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{0, -90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}}, //6
+
+		// test basic rotation using a [1,0,0] vector
+		{0, 0, 90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{0, 90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, -90, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{0, -90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //13
+
+		// basic rotation using a non major axis vector
+		{0, 0, 90, Vec3f{1, 1, 0}, Vec3f{-1, 1, 0}},
+		{0, 90, 0, Vec3f{1, -1, 0}, Vec3f{0, -1, -1}},
+		{90, 0, 0, Vec3f{-1, -1, 0}, Vec3f{-1, 0, -1}}, //16
+
+		// two rotations
+		{90, 0, 45, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{90, 45, 0, Vec3f{0, 0, 1}, Vec3f{0, -1, 0}},
+		{45, 90, 0, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}}, //20
+	}
+
+	m := &Mat4f{}
+	q := Quatf{}
+	for testIndex, c := range common_cases {
+		m64 := Mat4{}
+		m64.FromEuler(Radians(float64(c.pitch)), Radians(float64(c.yaw)), Radians(float64(c.roll)))
+		*m = m64.Mat4f()
+		q.FromMat4(*m)
+		get := q.RotateVec3(c.start_vec)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestFromMat4f %d \n %v\n%v\n\n", testIndex, q, get)
+		}
+	}
+}
+
+func TestAxisAngleQuatf(t *testing.T) {
+	cases := []struct {
+		angle, x, y, z float32
+	}{
+		//test basic rotations using a [1,0,0] vector
+		{90, 1, 0, 0},
+		{90, 0, 1, 0},
+		{90, 0, 0, 1},
+		{45, 1, 0, 0},
+		{45, 0, 1, 0},
+		{45, 0, 0, 1},
+		{180, 1, 0, 0},
+		{180, 0, 1, 0},
+		{180, 0, 0, 1},
+		{90, 1, 1, 0},
+		{90, 1, 1, 0},
+		{90, 0, -1, 1},
+		{45, 1, 0, 1},
+		{45, 0, 1, 0},
+		{45, 1, 0, 1},
+		{180, 1, -2, 0},
+		{180, 0, 1, 20},
+		{180, -4, 4, 1},
+	}
+
+	//var q Quatf
+	q := Quatf{}
+	for testIndex, c := range cases {
+		v := Vec3f{c.x, c.y, c.z}
+		v.NormalizeIn()
+		q.FromAxisAngle(float32(Radians(float64(c.angle))), v.X, v.Y, v.Z)
+		get_angle, get_x, get_y, get_z := q.AxisAngle()
+		if !closeEq32(float32(Degrees(float64(get_angle))), c.angle, epsilon32) ||
+			!closeEq32(get_x, v.X, epsilon32) ||
+			!closeEq32(get_y, v.Y, epsilon32) ||
+			!closeEq32(get_z, v.Z, epsilon32) {
+			t.Errorf("TestQuatToAxisAngle %d %v %f %f %f %f\n%f %f %f %f\n",
+				testIndex, v, float32(Degrees(float64(get_angle))), get_x, get_y, get_z, c.angle, v.X, v.Y, v.Z)
+		}
+	}
+}
+
+func TestMat4Quatf(t *testing.T) {
+	common_cases := []struct {
+		pitch, yaw, roll float32
+		start_vec        Vec3f
+		want             Vec3f
+	}{
+		//test basic rotations using a [0,1,0] vector
+		// pitch,yaw,roll
+		{0, 0, 90, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}},
+		{0, 90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{0, 0, -90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{0, -90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}}, //6
+
+		// test basic rotation using a [1,0,0] vector
+		{0, 0, 90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{0, 90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, -90, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{0, -90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //13
+
+		// basic rotation using a non major axis vector
+		{0, 0, 90, Vec3f{1, 1, 0}, Vec3f{-1, 1, 0}},
+		{0, 90, 0, Vec3f{1, -1, 0}, Vec3f{0, -1, -1}},
+		{90, 0, 0, Vec3f{-1, -1, 0}, Vec3f{-1, 0, -1}}, //16
+
+		// two rotations
+		{90, 0, 45, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{90, 45, 0, Vec3f{0, 0, 1}, Vec3f{0, -1, 0}},
+		{45, 90, 0, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}}, //20
+	}
+	var m Mat4f
+	q := Quatf{}
+	for testIndex, c := range common_cases {
+		q.FromEuler(float32(Radians(float64(c.pitch))), float32(Radians(float64(c.yaw))), float32(Radians(float64(c.roll))))
+		m = q.Mat4f()
+
+		get := m.MultVec3(c.start_vec)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestMat4f %d \n%v\n%v\n\n", testIndex, m, get)
+		}
+	}
+}
+
+func TestDotQuatf(t *testing.T) {
+	cases := []struct {
+		a, b Quatf
+		want float32
+	}{
+		{Quatf{1, 0, 0, 0}, Quatf{1, 0, 0, 0}, 1},
+		{Quatf{1, 0, 0, 0}, Quatf{0, 1, 0, 0}, 0},
+		{Quatf{1, 2, 3, 4}, Quatf{-1, 2, -3, 4}, 10},
+	}
+
+	for testIndex, c := range cases {
+		if get := c.a.Dot(c.b); get != c.want {
+			t.Errorf("TestDotQuatf %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestSlerpQuatf(t *testing.T) {
+	cases := []struct {
+		angleA, angleB float32
+		axis           Vec3f
+		inc            float32
+		wantAngle      float32
+	}{
+		{0, 90, Vec3f{0, 0, 1}, 0, 0},
+		{0, 90, Vec3f{0, 0, 1}, 1, 90},
+		{0, 90, Vec3f{0, 0, 1}, 0.5, 45},
+		{0, 90, Vec3f{0, 0, 1}, 0.25, 22.5},
+		{30, 90, Vec3f{1, 0, 0}, 0.5, 60},
+		{-60, 60, Vec3f{0, 1, 0}, 0.75, 30},
+
+		// Shortest path, 350 degrees is the same as -10 degrees
+		{0, 350, Vec3f{0, 0, 1}, 0.5, -5},
+		{10, 340, Vec3f{0, 0, 1}, 0.5, -5},
+
+		// Extrapolation outside of [0,1]
+		{0, 45, Vec3f{0, 0, 1}, 2, 90},
+		{0, 45, Vec3f{0, 0, 1}, -1, -45},
+		{20, 40, Vec3f{1, 0, 0}, 1.5, 50},
+
+		// Nearly parallel quaternions
+		{10, 10 + 1e-7, Vec3f{0, 1, 0}, 0.5, 10 + 0.5e-7},
+		{10, 10, Vec3f{0, 1, 0}, 0.5, 10},
+		{10, 10, Vec3f{0, 1, 0}, 3, 10},
+	}
+
+	var a, b, want Quatf
+	for testIndex, c := range cases {
+		a.FromAxisAngle(float32(Radians(float64(c.angleA))), c.axis.X, c.axis.Y, c.axis.Z)
+		b.FromAxisAngle(float32(Radians(float64(c.angleB))), c.axis.X, c.axis.Y, c.axis.Z)
+		want.FromAxisAngle(float32(Radians(float64(c.wantAngle))), c.axis.X, c.axis.Y, c.axis.Z)
+
+		get := Slerpf(a, b, c.inc)
+		if !closeEq32(get.Norm(), 1, epsilon32) {
+			t.Errorf("TestSlerpQuatf norm %d %v", testIndex, get)
+		}
+		if !get.Eq(want) && !get.Eq(want.MultScalar(-1)) {
+			t.Errorf("TestSlerpQuatf %d %v %v", testIndex, get, want)
+		}
+
+		// Quaternions in the opposite hemisphere represent the same rotation
+		// and must give the same result.
+		get2 := Slerpf(a, b.MultScalar(-1), c.inc)
+		if !get2.Eq(get) {
+			t.Errorf("TestSlerpQuatf hemisphere %d %v %v", testIndex, get2, get)
+		}
+	}
+}
+
+func TestNlerpQuatf(t *testing.T) {
+	cases := []struct {
+		angleA, angleB float32
+		axis           Vec3f
+		inc            float32
+		wantAngle      float32
+	}{
+		{0, 90, Vec3f{0, 0, 1}, 0, 0},
+		{0, 90, Vec3f{0, 0, 1}, 1, 90},
+		{0, 90, Vec3f{0, 0, 1}, 0.5, 45},
+		{-60, 60, Vec3f{0, 1, 0}, 0.5, 0},
+		{0, 350, Vec3f{0, 0, 1}, 0.5, -5},
+		{10, 10, Vec3f{0, 1, 0}, 0.5, 10},
+	}
+
+	var a, b, want Quatf
+	for testIndex, c := range cases {
+		a.FromAxisAngle(float32(Radians(float64(c.angleA))), c.axis.X, c.axis.Y, c.axis.Z)
+		b.FromAxisAngle(float32(Radians(float64(c.angleB))), c.axis.X, c.axis.Y, c.axis.Z)
+		want.FromAxisAngle(float32(Radians(float64(c.wantAngle))), c.axis.X, c.axis.Y, c.axis.Z)
+
+		get := Nlerpf(a, b, c.inc)
+		if !closeEq32(get.Norm(), 1, epsilon32) {
+			t.Errorf("TestNlerpQuatf norm %d %v", testIndex, get)
+		}
+		if !get.Eq(want) && !get.Eq(want.MultScalar(-1)) {
+			t.Errorf("TestNlerpQuatf %d %v %v", testIndex, get, want)
+		}
+		if !Nlerpf(a, b.MultScalar(-1), c.inc).Eq(get) {
+			t.Errorf("TestNlerpQuatf hemisphere %d", testIndex)
+		}
+	}
+}
+
+func TestConvertQuatf(t *testing.T) {
+	q := Quat{}
+	q.FromEuler(0.3, -1.2, 2)
+	qf := Quatf{}
+	qf.FromEuler(0.3, -1.2, 2)
+	if !qf.Eq(q.Quatf()) {
+		t.Errorf("TestConvertQuatf FromEuler %v %v", qf, q)
+	}
+	if get := qf.Quat(); math.Abs(get.Dot(q)) < 1-1e-6 {
+		t.Errorf("TestConvertQuatf %v %v", get, q)
+	}
+
+	pitch, yaw, roll := qf.Euler()
+	if !closeEq32(pitch, 0.3, epsilon32) || !closeEq32(yaw, -1.2, epsilon32) || !closeEq32(roll, 2, epsilon32) {
+		t.Errorf("TestConvertQuatf Euler %v %v %v", pitch, yaw, roll)
+	}
+}
+
+func TestTryInverseQuatf(t *testing.T) {
+	cases := []struct {
+		q, want Quatf
+		ok      bool
+	}{
+		{Quatf{1, 0, 0, 0}, Quatf{1, 0, 0, 0}, true},
+		{Quatf{0, 2, 0, 0}, Quatf{0, -0.5, 0, 0}, true},
+		// small quaternions have an exact inverse
+		{Quatf{1.0 / 1024, 0, 0, 0}, Quatf{1024, 0, 0, 0}, true},
+		{Quatf{0, 0, 0, 0}, Quatf{}, false},
+		{Quatf{float32(math.NaN()), 1, 0, 0}, Quatf{}, false},
+	}
+
+	for testIndex, c := range cases {
+		got, ok := c.q.TryInverse()
+		if ok != c.ok || got != c.want {
+			t.Errorf("TestTryInverseQuatf %d %v %v %v", testIndex, got, c.want, ok)
+		}
+	}
+}
+
+func TestFromEulerQuatf(t *testing.T) {
+	common_cases := []struct {
+		pitch, yaw, roll float32
+		start_vec        Vec3f
+		want             Vec3f
+	}{
+		//test basic rotations using a [0,1,0] vector
+		// pitch,yaw,roll
+		{0, 0, 90, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}},
+		{0, 90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{0, 0, -90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{0, -90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}}, //6
+
+		// test basic rotation using a [1,0,0] vector
+		{0, 0, 90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{0, 90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, -90, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{0, -90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //13
+
+		// basic rotation using a non major axis vector
+		{0, 0, 90, Vec3f{1, 1, 0}, Vec3f{-1, 1, 0}},
+		{0, 90, 0, Vec3f{1, -1, 0}, Vec3f{0, -1, -1}},
+		{90, 0, 0, Vec3f{-1, -1, 0}, Vec3f{-1, 0, -1}}, //16
+
+		// two rotations
+		{90, 0, 45, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{90, 45, 0, Vec3f{0, 0, 1}, Vec3f{0, -1, 0}},
+		{45, 90, 0, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{45, 90, 90, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, sqrt32(2) / 2, 0}}, //20
+	}
+
+	q := Quatf{}
+	for testIndex, c := range common_cases {
+		q.FromEuler(float32(Radians(float64(c.pitch))), float32(Radians(float64(c.yaw))), float32(Radians(float64(c.roll))))
+		get := q.RotateVec3(c.start_vec)
+		if get.Eq(c.want) == false {
+			t.Errorf("TestFromEuler %d \n %v\n%v\n\n", testIndex, q, get)
+		}
+	}
+}
+
+func TestEulerQuatf(t *testing.T) {
+	// The {45, 90, 90} gimbal lock cases of TestEulerQuat are left out, at a
+	// yaw of exactly 90 degrees float32 rounding makes the extracted pitch
+	// and roll ill conditioned.
+	common_cases2 := []struct {
+		pitch, yaw, roll float32
+		start_vec        Vec3f
+		want             Vec3f
+	}{
+		{180, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 180, 0, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //2
+		{180, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{0, 0, 180, Vec3f{0, 1, 0}, Vec3f{0, -1, 0}}, //5
+		{180, 0, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, -1}},
+		{0, 0, 180, Vec3f{0, 0, 1}, Vec3f{0, 0, 1}}, //8
+
+		{180, 0, 0, Vec3f{-1, 0, 0}, Vec3f{-1, 0, 0}},
+		{0, 180, 0, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{-1, 0, 0}, Vec3f{1, 0, 0}}, //11
+		{180, 0, 0, Vec3f{0, -1, 0}, Vec3f{0, 1, 0}},
+		{0, 180, 0, Vec3f{0, -1, 0}, Vec3f{0, -1, 0}},
+		{0, 0, 180, Vec3f{0, -1, 0}, Vec3f{0, 1, 0}}, //14
+		{180, 0, 0, Vec3f{0, 0, -1}, Vec3f{0, 0, 1}},
+		{0, 180, 0, Vec3f{0, 0, -1}, Vec3f{0, 0, 1}},
+		{0, 0, 180, Vec3f{0, 0, -1}, Vec3f{0, 0, -1}}, //17
+
+		{0, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{0, 0, 0, Vec3f{0, 0, 1}, Vec3f{0, 0, 1}}, //20
+
+		//test basic rotations using a [0,1,0] vector
+		// pitch,yaw,roll
+		{0, 0, 90, Vec3f{0, 1, 0}, Vec3f{-1, 0, 0}}, //21
+		{0, 90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{0, 0, -90, Vec3f{0, 1, 0}, Vec3f{1, 0, 0}},
+		{0, -90, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}},
+		{-90, 0, 0, Vec3f{0, 1, 0}, Vec3f{0, 0, -1}},
+		{0, 180, 0, Vec3f{0, 1, 0}, Vec3f{0, 1, 0}}, //27
+
+		// test basic rotation using a [1,0,0] vector
+		{0, 0, 90, Vec3f{1, 0, 0}, Vec3f{0, 1, 0}},
+		{0, 90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, -1}},
+		{90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, -90, Vec3f{1, 0, 0}, Vec3f{0, -1, 0}},
+		{0, -90, 0, Vec3f{1, 0, 0}, Vec3f{0, 0, 1}},
+		{-90, 0, 0, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{0, 0, 180, Vec3f{1, 0, 0}, Vec3f{-1, 0, 0}}, //34
+
+		// basic rotation using a non major axis vector
+		{0, 0, 90, Vec3f{1, 1, 0}, Vec3f{-1, 1, 0}},
+		{0, 90, 0, Vec3f{1, -1, 0}, Vec3f{0, -1, -1}},
+		{90, 0, 0, Vec3f{-1, -1, 0}, Vec3f{-1, 0, -1}}, //37
+
+		// two rotations
+		{90, 0, 45, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}},
+		{90, 45, 0, Vec3f{0, 0, 1}, Vec3f{0, -1, 0}},
+		{45, 90, 0, Vec3f{0, 0, 1}, Vec3f{sqrt32(2) / 2, -sqrt32(2) / 2, 0}}, //40
+	}
+
+	q := Quatf{}
+	for testIndex, c := range common_cases2 {
+		q.FromEuler(float32(Radians(float64(c.pitch))), float32(Radians(float64(c.yaw))), float32(Radians(float64(c.roll))))
+		pitch, yaw, roll := q.Euler()
+
+		if closeEq32(yaw, float32(Radians(float64(c.yaw))), epsilon32) &&
+			closeEq32(pitch, float32(Radians(float64(c.pitch))), epsilon32) &&
+			closeEq32(roll, float32(Radians(float64(c.roll))), epsilon32) {
+			continue
+		}
+
+		// The euler angles don't match, but lets see if it forms a equivalent
+		// quaternion rotation
+		q.FromEuler(pitch, yaw, roll)
+		get := q.RotateVec3(c.start_vec)
+		if get.Eq(c.want) {
+			continue
+		}
+
+		t.Errorf("TestEuler %d %f %f %f ", testIndex, pitch, yaw, roll)
+		// if !closeEq32(yaw, float32(Radians(float64(c.yaw))), epsilon32) ||
+		// 	!closeEq32(pitch, float32(Radians(float64(c.pitch))), epsilon32) ||
+		// 	!closeEq32(roll, float32(Radians(float64(c.roll))), epsilon32) {
+		// 	t.Errorf("TestEuler %d %f %f %f ", testIndex, pitch, yaw, roll)
+		// }
+	}
+}
+
+func TestSlerpRotateQuatf(t *testing.T) {
+	// Interpolating between two arbitrary orientations must rotate with a
+	// constant angular velocity.
+	var a, b Quatf
+	a.FromEuler(float32(Radians(float64(10))), float32(Radians(float64(20))), float32(Radians(float64(30))))
+	b.FromEuler(float32(Radians(float64(-40))), float32(Radians(float64(70))), float32(Radians(float64(-15))))
+	v := Vec3f{0.2, 0.5, -0.8}
+
+	total := b.Mult(a.Inverse()).Angle()
+	for _, inc := range []float32{0, 0.1, 0.3, 0.5, 0.9, 1} {
+		q := Slerpf(a, b, inc)
+		get := q.Mult(a.Inverse()).Angle()
+		if !closeEq32(get, total*inc, 1e-3) {
+			t.Errorf("TestSlerpRotateQuatf %v %v %v", inc, get, total*inc)
+		}
+	}
+	if !Slerpf(a, b, 0).RotateVec3(v).Eq(a.RotateVec3(v)) ||
+		!Slerpf(a, b, 1).RotateVec3(v).Eq(b.RotateVec3(v)) {
+		t.Errorf("TestSlerpRotateQuatf end points")
+	}
+}
+
+func TestSquadQuatf(t *testing.T) {
+	var q0, q1, q2, q3 Quatf
+	q0.FromEuler(float32(Radians(float64(0))), float32(Radians(float64(10))), float32(Radians(float64(0))))
+	q1.FromEuler(float32(Radians(float64(30))), float32(Radians(float64(60))), float32(Radians(float64(10))))
+	q2.FromEuler(float32(Radians(float64(-20))), float32(Radians(float64(90))), float32(Radians(float64(45))))
+	q3.FromEuler(float32(Radians(float64(10))), float32(Radians(float64(120))), float32(Radians(float64(-30))))
+	keys := []Quatf{q0, q1, q2, q3}
+
+	controls := make([]Quatf, len(keys))
+	for k, _ := range keys {
+		prev, next := keys[k], keys[k]
+		if k > 0 {
+			prev = keys[k-1]
+		}
+		if k < len(keys)-1 {
+			next = keys[k+1]
+		}
+		controls[k] = SquadControlf(prev, keys[k], next)
+	}
+	segment := func(k int, inc float32) Quatf {
+		return Squadf(keys[k], keys[k+1], controls[k], controls[k+1], inc)
+	}
+
+	for k := 0; k < len(keys)-1; k++ {
+		// The curve passes through the keyframes
+		if !segment(k, 0).Eq(keys[k]) || !segment(k, 1).Eq(keys[k+1]) {
+			t.Errorf("TestSquadQuatf end points %d", k)
+		}
+		if get := segment(k, 0.5); !closeEq32(get.Norm(), 1, 1e-7) {
+			t.Errorf("TestSquadQuatf norm %d %v", k, get.Norm())
+		}
+	}
+
+	// The curve is C1 continuous across the keyframes. float32 rounding limits how
+	// small the step can be, so the tolerance is looser than TestSquadQuat.
+	var h float32 = 1e-3
+	for k := 0; k < len(keys)-2; k++ {
+		before := segment(k, 1).Sub(segment(k, 1-h)).DivScalar(h)
+		after := segment(k+1, h).Sub(segment(k+1, 0)).DivScalar(h)
+		if !closeEq32(before.Sub(after).Norm(), 0, 1e-2) {
+			t.Errorf("TestSquadQuatf C1 %d %v %v", k, before, after)
+		}
+	}
+
+	// Keyframes evenly spaced about a single axis reduce to slerp
+	var a, b, c, d Quatf
+	a.FromAxisAngle(float32(Radians(float64(0))), 0, 0, 1)
+	b.FromAxisAngle(float32(Radians(float64(20))), 0, 0, 1)
+	c.FromAxisAngle(float32(Radians(float64(40))), 0, 0, 1)
+	d.FromAxisAngle(float32(Radians(float64(60))), 0, 0, 1)
+	sb := SquadControlf(a, b, c)
+	sc := SquadControlf(b, c, d)
+	if !sb.Eq(b) || !sc.Eq(c) {
+		t.Errorf("TestSquadQuatf control %v %v", sb, sc)
+	}
+	for _, inc := range []float32{0.1, 0.5, 0.8} {
+		if get := Squadf(b, c, sb, sc, inc); !get.Eq(Slerpf(b, c, inc)) {
+			t.Errorf("TestSquadQuatf slerp %v %v", inc, get)
+		}
+	}
+}
+
+func TestLogExpQuatf(t *testing.T) {
+	cases := []struct {
+		q, log Quatf
+	}{
+		{Quatf{1, 0, 0, 0}, Quatf{0, 0, 0, 0}},
+		{Quatf{cos32(0.5), sin32(0.5), 0, 0}, Quatf{0, 0.5, 0, 0}},
+		{Quatf{cos32(1), 0, sin32(1), 0}, Quatf{0, 0, 1, 0}},
+		{Quatf{0, 0, 0, 1}, Quatf{0, 0, 0, math.Pi / 2}},
+		{Quatf{cos32(3), 0, 0, -sin32(3)}, Quatf{0, 0, 0, -3}},
+		{Quatf{2, 0, 0, 0}, Quatf{math.Ln2, 0, 0, 0}},
+		{Quatf{2 * cos32(0.3), 0, 2 * sin32(0.3), 0}, Quatf{math.Ln2, 0, 0.3, 0}},
+
+		// small angles
+		{Quatf{cos32(1e-9), 1e-9, 0, 0}, Quatf{0, 1e-9, 0, 0}},
+		{Quatf{cos32(1e-5), 0, sin32(1e-5), 0}, Quatf{0, 0, 1e-5, 0}},
+	}
+
+	for testIndex, c := range cases {
+		if get := c.q.Log(); !get.Eq(c.log) {
+			t.Errorf("TestLogExpQuatf log %d %v %v", testIndex, get, c.log)
+		}
+		if get := c.log.Exp(); !get.Eq(c.q) {
+			t.Errorf("TestLogExpQuatf exp %d %v %v", testIndex, get, c.q)
+		}
+		if get := c.q.Log().Exp(); !get.Eq(c.q) {
+			t.Errorf("TestLogExpQuatf round trip %d %v %v", testIndex, get, c.q)
+		}
+	}
+
+	// The small angle branch must agree with the exact formula at the switch
+	for _, a := range []float32{smallAngle * 0.999, smallAngle * 1.001} {
+		q := Quatf{cos32(a), 0, 0, sin32(a)}
+		if get := q.Log(); !closeEq32(get.Z, a, 1e-15) {
+			t.Errorf("TestLogExpQuatf small log %v %v", a, get)
+		}
+		if get := (Quatf{0, 0, 0, a}).Exp(); !closeEq32(get.Z, sin32(a), 1e-15) {
+			t.Errorf("TestLogExpQuatf small exp %v %v", a, get)
+		}
+	}
+}
+
+func TestPowQuatf(t *testing.T) {
+	cases := []struct {
+		angle float32
+		axis  Vec3f
+		pow   float32
+	}{
+		{90, Vec3f{0, 0, 1}, 0.5},
+		{90, Vec3f{0, 0, 1}, 2},
+		{60, Vec3f{1, 0, 0}, -1},
+		{120, Vec3f{0, 1, 0}, 0},
+		{45, Vec3f{0, 1, 0}, 1},
+		{1e-6, Vec3f{0, 1, 0}, 0.5},
+		{170, Vec3f{0, 0.6, 0.8}, 1.0 / 3},
+	}
+
+	var q, want Quatf
+	for testIndex, c := range cases {
+		q.FromAxisAngle(float32(Radians(float64(c.angle))), c.axis.X, c.axis.Y, c.axis.Z)
+		want.FromAxisAngle(float32(Radians(float64(c.angle*c.pow))), c.axis.X, c.axis.Y, c.axis.Z)
+		if get := q.Pow(c.pow); !get.Eq(want) {
+			t.Errorf("TestPowQuatf %d %v %v", testIndex, get, want)
+		}
+	}
+
+	// Applying half a rotation twice is the full rotation
+	q.FromEuler(0.3, -1.2, 2.1)
+	half := q.Pow(0.5)
+	if get := half.Mult(half); !get.Eq(q) {
+		t.Errorf("TestPowQuatf half %v %v", get, q)
+	}
+	if get := q.Pow(-1); !get.Eq(q.Inverse()) {
+		t.Errorf("TestPowQuatf inverse %v %v", get, q.Inverse())
+	}
+
+	// Non unit quaternions scale the norm
+	if get := (Quatf{4, 0, 0, 0}).Pow(0.5); !get.Eq(Quatf{2, 0, 0, 0}) {
+		t.Errorf("TestPowQuatf norm %v", get)
+	}
+}
+
+func TestRotationVectorQuatf(t *testing.T) {
+	cases := []struct {
+		q Quatf
+		v Vec3f
+	}{
+		{Quatf{1, 0, 0, 0}, Vec3f{0, 0, 0}},
+		{Quatf{cos32(math.Pi / 4), 0, 0, sin32(math.Pi / 4)}, Vec3f{0, 0, math.Pi / 2}},
+		{Quatf{0, 1, 0, 0}, Vec3f{math.Pi, 0, 0}},
+		{Quatf{cos32(0.25), 0, sin32(0.25) * 0.6, sin32(0.25) * 0.8}, Vec3f{0, 0.3, 0.4}},
+		{Quatf{cos32(1e-8), sin32(1e-8), 0, 0}, Vec3f{2e-8, 0, 0}},
+	}
+
+	for testIndex, c := range cases {
+		if get := c.q.RotationVector(); !get.Eq(c.v) {
+			t.Errorf("TestRotationVectorQuatf %d %v %v", testIndex, get, c.v)
+		}
+		// q and -q are the same rotation and give the same vector.
+		// At pi both directions about the axis are equally short.
+		if get := c.q.MultScalar(-1).RotationVector(); c.q.W != 0 && !get.Eq(c.v) {
+			t.Errorf("TestRotationVectorQuatf negate %d %v %v", testIndex, get, c.v)
+		}
+		var q Quatf
+		if q.FromRotationVector(c.v); !q.Eq(c.q) && !q.Eq(c.q.MultScalar(-1)) {
+			t.Errorf("TestRotationVectorQuatf from %d %v %v", testIndex, q, c.q)
+		}
+	}
+
+	// Integrating a constant angular velocity matches a single rotation
+	omega := Vec3f{0.4, -1.1, 0.7}
+	var dt float32 = 0.01
+	var step, q, want Quatf
+	step.FromRotationVector(omega.MultScalar(dt))
+	q.Set(1, 0, 0, 0)
+	for i := 0; i < 100; i++ {
+		q = step.Mult(q)
+	}
+	want.FromRotationVector(omega)
+	if !q.Eq(want) {
+		t.Errorf("TestRotationVectorQuatf integrate %v %v", q, want)
+	}
+}
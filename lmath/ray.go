@@ -0,0 +1,61 @@
+package lmath
+
+// A half-infinite line starting at Origin and extending along Dir.
+// Dir should be unit length so that the parameter 't' of a point along the
+// ray is its distance from the origin.
+type Ray struct {
+	Origin Vec3
+	Dir    Vec3
+}
+
+// Return the point along the ray at the parameter t (ie. Origin + Dir*t).
+func (this Ray) At(t float64) Vec3 {
+	return this.Origin.Add(this.Dir.MultScalar(t))
+}
+
+// Return the point on the ray which is closest to p.
+func (this Ray) ClosestPoint(p Vec3) Vec3 {
+	t := p.Sub(this.Origin).Dot(this.Dir) / this.Dir.LengthSq()
+	if t < 0 {
+		t = 0
+	}
+	return this.At(t)
+}
+
+// Return the distance from p to the closest point on the ray.
+func (this Ray) Distance(p Vec3) float64 {
+	return p.Sub(this.ClosestPoint(p)).Length()
+}
+
+// Return true if the point lies on the ray.
+// Equality is measured using an epsilon (< 0.0000001).
+func (this Ray) Contains(p Vec3) bool {
+	return this.Distance(p) < epsilon
+}
+
+// Return a new ray transformed by the matrix.
+// The origin is transformed as a point and the direction by the upper 3x3.
+// The direction is not re-normalized so 't' values are preserved.
+func (this Ray) Transform(m Mat4) Ray {
+	return Ray{m.MultVec3(this.Origin), m.TransformDirection(this.Dir)}
+}
+
+// Return a new ray rotated about the origin by the quaternion.
+func (this Ray) Rotate(q Quat) Ray {
+	return Ray{q.RotateVec3(this.Origin), q.RotateVec3(this.Dir)}
+}
+
+// Return true if no element is NaN or infinite.
+func (this Ray) IsFinite() bool {
+	return this.Origin.IsFinite() && this.Dir.IsFinite()
+}
+
+// Return true if any element is NaN.
+func (this Ray) HasNaN() bool {
+	return this.Origin.HasNaN() || this.Dir.HasNaN()
+}
+
+// Equal if the origin and direction are equal within the tolerance.
+func (this Ray) ApproxEq(other Ray, tol Tolerance) bool {
+	return this.Origin.ApproxEq(other.Origin, tol) && this.Dir.ApproxEq(other.Dir, tol)
+}
@@ -0,0 +1,61 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClosestPointRay(t *testing.T) {
+	r := Ray{Vec3{1, 0, 0}, Vec3{0, 1, 0}}
+	cases := []struct {
+		p, want Vec3
+		dist    float64
+	}{
+		{Vec3{1, 5, 0}, Vec3{1, 5, 0}, 0},
+		{Vec3{3, 2, 0}, Vec3{1, 2, 0}, 2},
+		{Vec3{1, 4, -3}, Vec3{1, 4, 0}, 3},
+		// behind the origin
+		{Vec3{1, -2, 0}, Vec3{1, 0, 0}, 2},
+		{Vec3{4, -4, 0}, Vec3{1, 0, 0}, 5},
+	}
+
+	for testIndex, c := range cases {
+		if get := r.ClosestPoint(c.p); !get.Eq(c.want) {
+			t.Errorf("TestClosestPointRay %d %v", testIndex, get)
+		}
+		if get := r.Distance(c.p); !closeEq(get, c.dist, epsilon) {
+			t.Errorf("TestClosestPointRay dist %d %v", testIndex, get)
+		}
+		if get := r.Contains(c.p); get != (c.dist == 0) {
+			t.Errorf("TestClosestPointRay contains %d %v", testIndex, get)
+		}
+	}
+
+	if get := r.At(3); !get.Eq(Vec3{1, 3, 0}) {
+		t.Errorf("TestClosestPointRay At %v", get)
+	}
+}
+
+func TestTransformRay(t *testing.T) {
+	r := Ray{Vec3{1, 0, 0}, Vec3{0, 1, 0}}
+
+	var m, tr, s Mat4
+	tr.ToTranslate(0, 0, 5)
+	s.ToScale(2, 3, 1)
+	m = tr.Mult(s)
+	get := r.Transform(m)
+	if !get.Origin.Eq(Vec3{2, 0, 5}) || !get.Dir.Eq(Vec3{0, 3, 0}) {
+		t.Errorf("TestTransformRay %v", get)
+	}
+	// points along the ray keep the same parameter
+	if !get.At(2).Eq(m.MultVec3(r.At(2))) {
+		t.Errorf("TestTransformRay At %v", get.At(2))
+	}
+
+	var q Quat
+	q.FromAxisAngle(math.Pi/2, 0, 0, 1)
+	get = r.Rotate(q)
+	if !get.Origin.Eq(Vec3{0, 1, 0}) || !get.Dir.Eq(Vec3{-1, 0, 0}) {
+		t.Errorf("TestTransformRay rotate %v", get)
+	}
+}
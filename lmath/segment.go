@@ -0,0 +1,119 @@
+package lmath
+
+// A line segment between the points A and B.
+type Segment struct {
+	A, B Vec3
+}
+
+// Return the length of the segment.
+func (this Segment) Length() float64 {
+	return this.B.Sub(this.A).Length()
+}
+
+// Return the point along the segment at the parameter t (ie. A + (B-A)*t).
+// t of 0 gives A and t of 1 gives B.
+func (this Segment) At(t float64) Vec3 {
+	return this.A.Add(this.B.Sub(this.A).MultScalar(t))
+}
+
+// Return the parameter t of the point on the segment which is closest to p.
+func (this Segment) closestT(p Vec3) float64 {
+	d := this.B.Sub(this.A)
+	l := d.LengthSq()
+	if l < epsilon*epsilon {
+		return 0
+	}
+	return Clamp(p.Sub(this.A).Dot(d)/l, 0, 1)
+}
+
+// Return the point on the segment which is closest to p.
+func (this Segment) ClosestPoint(p Vec3) Vec3 {
+	return this.At(this.closestT(p))
+}
+
+// Return the distance from p to the closest point on the segment.
+func (this Segment) Distance(p Vec3) float64 {
+	return p.Sub(this.ClosestPoint(p)).Length()
+}
+
+// Return true if the point lies on the segment.
+// Equality is measured using an epsilon (< 0.0000001).
+func (this Segment) Contains(p Vec3) bool {
+	return this.Distance(p) < epsilon
+}
+
+// Return the closest pair of points between the two segments.
+// 'a' lies on this segment and 'b' lies on the other.
+func (this Segment) ClosestPoints(other Segment) (a, b Vec3) {
+	// Reference : Christer Ericson, "Real-Time Collision Detection" 5.1.9
+	d1 := this.B.Sub(this.A)
+	d2 := other.B.Sub(other.A)
+	r := this.A.Sub(other.A)
+	l1 := d1.LengthSq()
+	l2 := d2.LengthSq()
+	f := d2.Dot(r)
+
+	var s, t float64
+	switch {
+	case l1 < epsilon*epsilon && l2 < epsilon*epsilon:
+		// both segments are points
+		return this.A, other.A
+	case l1 < epsilon*epsilon:
+		// this segment is a point
+		t = Clamp(f/l2, 0, 1)
+	default:
+		c := d1.Dot(r)
+		if l2 < epsilon*epsilon {
+			// the other segment is a point
+			s = Clamp(-c/l1, 0, 1)
+			break
+		}
+
+		bb := d1.Dot(d2)
+		denom := l1*l2 - bb*bb
+		if denom != 0 {
+			// Not parallel, otherwise any s will do so use 0
+			s = Clamp((bb*f-c*l2)/denom, 0, 1)
+		}
+		t = (bb*s + f) / l2
+		if t < 0 {
+			t = 0
+			s = Clamp(-c/l1, 0, 1)
+		} else if t > 1 {
+			t = 1
+			s = Clamp((bb-c)/l1, 0, 1)
+		}
+	}
+	return this.At(s), other.At(t)
+}
+
+// Return the shortest distance between the two segments.
+func (this Segment) SegmentDistance(other Segment) float64 {
+	a, b := this.ClosestPoints(other)
+	return a.Sub(b).Length()
+}
+
+// Return a new segment transformed by the matrix.
+func (this Segment) Transform(m Mat4) Segment {
+	return Segment{m.MultVec3(this.A), m.MultVec3(this.B)}
+}
+
+// Return a new segment rotated about the origin by the quaternion.
+func (this Segment) Rotate(q Quat) Segment {
+	return Segment{q.RotateVec3(this.A), q.RotateVec3(this.B)}
+}
+
+// Return true if no element is NaN or infinite.
+func (this Segment) IsFinite() bool {
+	return this.A.IsFinite() && this.B.IsFinite()
+}
+
+// Return true if any element is NaN.
+func (this Segment) HasNaN() bool {
+	return this.A.HasNaN() || this.B.HasNaN()
+}
+
+// Equal if the end points are equal within the tolerance, in the same order.
+func (this Segment) ApproxEq(other Segment, tol Tolerance) bool {
+	return this.A.ApproxEq(other.A, tol) && this.B.ApproxEq(other.B, tol)
+}
@@ -0,0 +1,91 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClosestPointSegment(t *testing.T) {
+	s := Segment{Vec3{0, 0, 0}, Vec3{4, 0, 0}}
+	cases := []struct {
+		p, want Vec3
+		dist    float64
+	}{
+		{Vec3{2, 0, 0}, Vec3{2, 0, 0}, 0},
+		{Vec3{1, 3, 0}, Vec3{1, 0, 0}, 3},
+		{Vec3{-3, 4, 0}, Vec3{0, 0, 0}, 5},
+		{Vec3{6, 0, 0}, Vec3{4, 0, 0}, 2},
+	}
+
+	for testIndex, c := range cases {
+		if get := s.ClosestPoint(c.p); !get.Eq(c.want) {
+			t.Errorf("TestClosestPointSegment %d %v", testIndex, get)
+		}
+		if get := s.Distance(c.p); !closeEq(get, c.dist, epsilon) {
+			t.Errorf("TestClosestPointSegment dist %d %v", testIndex, get)
+		}
+		if get := s.Contains(c.p); get != (c.dist == 0) {
+			t.Errorf("TestClosestPointSegment contains %d %v", testIndex, get)
+		}
+	}
+
+	if s.Length() != 4 || !s.At(0.25).Eq(Vec3{1, 0, 0}) {
+		t.Errorf("TestClosestPointSegment length %v", s.Length())
+	}
+	// degenerate segment
+	p := Segment{Vec3{1, 1, 1}, Vec3{1, 1, 1}}
+	if get := p.ClosestPoint(Vec3{5, 5, 5}); !get.Eq(Vec3{1, 1, 1}) {
+		t.Errorf("TestClosestPointSegment point %v", get)
+	}
+}
+
+func TestClosestPointsSegment(t *testing.T) {
+	cases := []struct {
+		s1, s2 Segment
+		a, b   Vec3
+	}{
+		// crossing
+		{Segment{Vec3{-1, 0, 0}, Vec3{1, 0, 0}}, Segment{Vec3{0, -1, 2}, Vec3{0, 1, 2}},
+			Vec3{0, 0, 0}, Vec3{0, 0, 2}},
+		// end points closest
+		{Segment{Vec3{0, 0, 0}, Vec3{1, 0, 0}}, Segment{Vec3{3, 0, 0}, Vec3{3, 5, 0}},
+			Vec3{1, 0, 0}, Vec3{3, 0, 0}},
+		{Segment{Vec3{0, 0, 0}, Vec3{1, 0, 0}}, Segment{Vec3{2, 1, 0}, Vec3{5, 1, 0}},
+			Vec3{1, 0, 0}, Vec3{2, 1, 0}},
+		// parallel and overlapping
+		{Segment{Vec3{0, 0, 0}, Vec3{2, 0, 0}}, Segment{Vec3{1, 1, 0}, Vec3{3, 1, 0}},
+			Vec3{1, 0, 0}, Vec3{1, 1, 0}},
+		// degenerate segments
+		{Segment{Vec3{0, 0, 0}, Vec3{0, 0, 0}}, Segment{Vec3{-1, 1, 0}, Vec3{1, 1, 0}},
+			Vec3{0, 0, 0}, Vec3{0, 1, 0}},
+		{Segment{Vec3{-1, 1, 0}, Vec3{1, 1, 0}}, Segment{Vec3{3, 0, 0}, Vec3{3, 0, 0}},
+			Vec3{1, 1, 0}, Vec3{3, 0, 0}},
+		{Segment{Vec3{2, 2, 2}, Vec3{2, 2, 2}}, Segment{Vec3{3, 3, 3}, Vec3{3, 3, 3}},
+			Vec3{2, 2, 2}, Vec3{3, 3, 3}},
+	}
+
+	for testIndex, c := range cases {
+		a, b := c.s1.ClosestPoints(c.s2)
+		if !a.Eq(c.a) || !b.Eq(c.b) {
+			t.Errorf("TestClosestPointsSegment %d %v %v", testIndex, a, b)
+		}
+		if get := c.s1.SegmentDistance(c.s2); !closeEq(get, c.a.Sub(c.b).Length(), epsilon) {
+			t.Errorf("TestClosestPointsSegment dist %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestTransformSegment(t *testing.T) {
+	s := Segment{Vec3{1, 0, 0}, Vec3{1, 2, 0}}
+	var m Mat4
+	m.ToTranslate(0, 0, 1)
+	if get := s.Transform(m); !get.A.Eq(Vec3{1, 0, 1}) || !get.B.Eq(Vec3{1, 2, 1}) {
+		t.Errorf("TestTransformSegment %v", get)
+	}
+
+	var q Quat
+	q.FromAxisAngle(math.Pi, 0, 1, 0)
+	if get := s.Rotate(q); !get.A.Eq(Vec3{-1, 0, 0}) || !get.B.Eq(Vec3{-1, 2, 0}) {
+		t.Errorf("TestTransformSegment rotate %v", get)
+	}
+}
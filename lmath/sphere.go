@@ -0,0 +1,78 @@
+package lmath
+
+import (
+	"math"
+)
+
+// A sphere with the given center and radius.
+type Sphere struct {
+	Center Vec3
+	Radius float64
+}
+
+// Return true if the point is inside or on the sphere.
+func (this Sphere) Contains(p Vec3) bool {
+	return p.Sub(this.Center).LengthSq() <= this.Radius*this.Radius
+}
+
+// Return true if the other sphere is completely inside this sphere.
+func (this Sphere) ContainsSphere(other Sphere) bool {
+	return other.Center.Sub(this.Center).Length()+other.Radius <= this.Radius
+}
+
+// Return the point in the sphere which is closest to p.
+// Points inside the sphere are returned unchanged.
+func (this Sphere) ClosestPoint(p Vec3) Vec3 {
+	d := p.Sub(this.Center)
+	l := d.Length()
+	if l <= this.Radius {
+		return p
+	}
+	return this.Center.Add(d.MultScalar(this.Radius / l))
+}
+
+// Return the distance from p to the sphere. Zero when p is inside.
+func (this Sphere) Distance(p Vec3) float64 {
+	return math.Max(0, p.Sub(this.Center).Length()-this.Radius)
+}
+
+// Return the smallest sphere which contains both spheres.
+func (this Sphere) Merge(other Sphere) Sphere {
+	d := other.Center.Sub(this.Center)
+	l := d.Length()
+	if l+other.Radius <= this.Radius {
+		return this
+	}
+	if l+this.Radius <= other.Radius {
+		return other
+	}
+	r := (l + this.Radius + other.Radius) / 2
+	return Sphere{this.Center.Add(d.MultScalar((r - this.Radius) / l)), r}
+}
+
+// Return a new sphere transformed by the matrix.
+// With non-uniform scaling the radius grows by the largest scale so the
+// result still bounds the transformed sphere.
+func (this Sphere) Transform(m Mat4) Sphere {
+	return Sphere{m.MultVec3(this.Center), this.Radius * maxScale(m)}
+}
+
+// Return a new sphere rotated about the origin by the quaternion.
+func (this Sphere) Rotate(q Quat) Sphere {
+	return Sphere{q.RotateVec3(this.Center), this.Radius}
+}
+
+// Return true if no element is NaN or infinite.
+func (this Sphere) IsFinite() bool {
+	return this.Center.IsFinite() && isFinite(this.Radius)
+}
+
+// Return true if any element is NaN.
+func (this Sphere) HasNaN() bool {
+	return this.Center.HasNaN() || math.IsNaN(this.Radius)
+}
+
+// Equal if the center and radius are equal within the tolerance.
+func (this Sphere) ApproxEq(other Sphere, tol Tolerance) bool {
+	return this.Center.ApproxEq(other.Center, tol) && tol.Eq(this.Radius, other.Radius)
+}
@@ -0,0 +1,80 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestContainsSphere(t *testing.T) {
+	s := Sphere{Vec3{1, 1, 1}, 2}
+	cases := []struct {
+		p       Vec3
+		inside  bool
+		dist    float64
+		closest Vec3
+	}{
+		{Vec3{1, 1, 1}, true, 0, Vec3{1, 1, 1}},
+		{Vec3{2, 1, 1}, true, 0, Vec3{2, 1, 1}},
+		{Vec3{3, 1, 1}, true, 0, Vec3{3, 1, 1}},
+		{Vec3{1, 5, 1}, false, 2, Vec3{1, 3, 1}},
+		{Vec3{1, 1, -4}, false, 3, Vec3{1, 1, -1}},
+	}
+
+	for testIndex, c := range cases {
+		if get := s.Contains(c.p); get != c.inside {
+			t.Errorf("TestContainsSphere %d %v", testIndex, get)
+		}
+		if get := s.Distance(c.p); !closeEq(get, c.dist, epsilon) {
+			t.Errorf("TestContainsSphere dist %d %v", testIndex, get)
+		}
+		if get := s.ClosestPoint(c.p); !get.Eq(c.closest) {
+			t.Errorf("TestContainsSphere closest %d %v", testIndex, get)
+		}
+	}
+
+	if !s.ContainsSphere(Sphere{Vec3{2, 1, 1}, 1}) || s.ContainsSphere(Sphere{Vec3{2, 1, 1}, 1.5}) {
+		t.Errorf("TestContainsSphere sphere")
+	}
+}
+
+func TestMergeSphere(t *testing.T) {
+	cases := []struct {
+		a, b, want Sphere
+	}{
+		{Sphere{Vec3{0, 0, 0}, 1}, Sphere{Vec3{4, 0, 0}, 1}, Sphere{Vec3{2, 0, 0}, 3}},
+		{Sphere{Vec3{0, 0, 0}, 1}, Sphere{Vec3{0, 4, 0}, 3}, Sphere{Vec3{0, 3, 0}, 4}},
+		// one sphere inside the other
+		{Sphere{Vec3{0, 0, 0}, 5}, Sphere{Vec3{1, 0, 0}, 1}, Sphere{Vec3{0, 0, 0}, 5}},
+		{Sphere{Vec3{1, 0, 0}, 1}, Sphere{Vec3{0, 0, 0}, 5}, Sphere{Vec3{0, 0, 0}, 5}},
+		{Sphere{Vec3{1, 1, 1}, 1}, Sphere{Vec3{1, 1, 1}, 1}, Sphere{Vec3{1, 1, 1}, 1}},
+	}
+
+	for testIndex, c := range cases {
+		get := c.a.Merge(c.b)
+		if !get.Center.Eq(c.want.Center) || !closeEq(get.Radius, c.want.Radius, epsilon) {
+			t.Errorf("TestMergeSphere %d %v", testIndex, get)
+		}
+		if !get.ContainsSphere(c.a) || !get.ContainsSphere(c.b) {
+			t.Errorf("TestMergeSphere contains %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestTransformSphere(t *testing.T) {
+	s := Sphere{Vec3{1, 0, 0}, 2}
+
+	var tr, sc Mat4
+	tr.ToTranslate(0, 3, 0)
+	sc.ToScale(1, 4, 2)
+	get := s.Transform(tr.Mult(sc))
+	if !get.Center.Eq(Vec3{1, 3, 0}) || !closeEq(get.Radius, 8, epsilon) {
+		t.Errorf("TestTransformSphere %v", get)
+	}
+
+	var q Quat
+	q.FromAxisAngle(math.Pi/2, 0, 1, 0)
+	get = s.Rotate(q)
+	if !get.Center.Eq(Vec3{0, 0, -1}) || get.Radius != 2 {
+		t.Errorf("TestTransformSphere rotate %v", get)
+	}
+}
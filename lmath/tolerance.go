@@ -0,0 +1,114 @@
+package lmath
+
+import (
+	"math"
+)
+
+// Tolerance configures how close two floats must be for ApproxEq.
+// Two values are equal when they pass any of the non-zero checks, so a
+// Tolerance with both Abs and Rel set accepts small values near zero by Abs
+// and large values by Rel.
+//
+//	Abs: |a-b| <= Abs
+//	Rel: |a-b| <= Rel * max(|a|,|b|)
+//	ULP: at most ULP representable floats between a and b
+//
+// The float32 types count ULPs between float32 values.
+// NaN is never equal to anything.
+type Tolerance struct {
+	Abs float64
+	Rel float64
+	ULP uint64
+}
+
+// An absolute difference of epsilon, the limit the Eq methods compare against.
+// Eq doesn't use it and treats a difference of exactly epsilon as unequal,
+// where ApproxEq with DefaultTolerance accepts it.
+var DefaultTolerance = Tolerance{Abs: epsilon}
+
+// Return a tolerance allowing an absolute difference of e.
+func AbsTolerance(e float64) Tolerance {
+	return Tolerance{Abs: e}
+}
+
+// Return a tolerance allowing a difference of e relative to the larger value.
+func RelTolerance(e float64) Tolerance {
+	return Tolerance{Rel: e}
+}
+
+// Return a tolerance allowing n representable floats between the values.
+func ULPTolerance(n uint64) Tolerance {
+	return Tolerance{ULP: n}
+}
+
+// Return true if a and b are equal within the tolerance.
+func (this Tolerance) Eq(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+	diff := math.Abs(a - b)
+	if diff <= this.Abs {
+		return true
+	}
+	if diff <= this.Rel*math.Max(math.Abs(a), math.Abs(b)) {
+		return true
+	}
+	return this.ULP > 0 && ulpDistance(a, b) <= this.ULP
+}
+
+// Return true if a and b are equal within the tolerance, counting ULPs
+// between float32 values.
+func (this Tolerance) Eq32(a, b float32) bool {
+	if a == b {
+		return true
+	}
+	a64, b64 := float64(a), float64(b)
+	if math.IsNaN(a64) || math.IsNaN(b64) || math.IsInf(a64, 0) || math.IsInf(b64, 0) {
+		return false
+	}
+	diff := math.Abs(a64 - b64)
+	if diff <= this.Abs {
+		return true
+	}
+	if diff <= this.Rel*math.Max(math.Abs(a64), math.Abs(b64)) {
+		return true
+	}
+	return this.ULP > 0 && ulpDistance32(a, b) <= this.ULP
+}
+
+// Return the number of representable float64 values between a and b.
+func ulpDistance(a, b float64) uint64 {
+	// map the bits onto a line where adjacent floats are adjacent integers,
+	// with the negative floats mirrored below zero
+	order := func(f float64) int64 {
+		bits := int64(math.Float64bits(f))
+		if bits < 0 {
+			return math.MinInt64 - bits
+		}
+		return bits
+	}
+	ia, ib := order(a), order(b)
+	if ia > ib {
+		return uint64(ia) - uint64(ib)
+	}
+	return uint64(ib) - uint64(ia)
+}
+
+// Return the number of representable float32 values between a and b.
+func ulpDistance32(a, b float32) uint64 {
+	order := func(f float32) int64 {
+		bits := int64(int32(math.Float32bits(f)))
+		if bits < 0 {
+			return math.MinInt32 - bits
+		}
+		return bits
+	}
+	ia, ib := order(a), order(b)
+	if ia > ib {
+		return uint64(ia - ib)
+	}
+	return uint64(ib - ia)
+}
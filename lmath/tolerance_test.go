@@ -0,0 +1,190 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqTolerance(t *testing.T) {
+	next := math.Nextafter(1, 2)
+	cases := []struct {
+		tol  Tolerance
+		a, b float64
+		want bool
+	}{
+		{DefaultTolerance, 1, 1 + 1e-10, true},
+		{DefaultTolerance, 1e6, 1e6 + 1e-6, false},
+		{AbsTolerance(0.1), 1, 1.05, true},
+		{AbsTolerance(0.1), 1, 1.2, false},
+		{RelTolerance(1e-9), 1e6, 1e6 + 1e-6, true},
+		{RelTolerance(1e-9), 1e-12, 2e-12, false},
+		{RelTolerance(1e-9), 0, 1e-300, false},
+		{ULPTolerance(1), 1, next, true},
+		{ULPTolerance(1), 1, math.Nextafter(next, 2), false},
+		{ULPTolerance(2), -0.0, math.SmallestNonzeroFloat64, true},
+		{ULPTolerance(2), -math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat64, true},
+		{ULPTolerance(1), -1, 1, false},
+		{Tolerance{Abs: 1e-9, Rel: 1e-9}, 1e-12, 2e-12, true},
+		{Tolerance{Abs: 1e-9, Rel: 1e-9}, 1e6, 1e6 + 1e-6, true},
+		{AbsTolerance(1), math.NaN(), math.NaN(), false},
+		{AbsTolerance(1), math.Inf(1), math.Inf(1), true},
+		{AbsTolerance(1), math.Inf(1), math.MaxFloat64, false},
+		{ULPTolerance(math.MaxUint64), math.Inf(-1), math.Inf(1), false},
+	}
+
+	for testIndex, c := range cases {
+		if got := c.tol.Eq(c.a, c.b); got != c.want {
+			t.Errorf("TestEqTolerance %d %v", testIndex, got)
+		}
+		if got := c.tol.Eq(c.b, c.a); got != c.want {
+			t.Errorf("TestEqTolerance %d swapped %v", testIndex, got)
+		}
+	}
+}
+
+func TestEq32Tolerance(t *testing.T) {
+	next := math.Nextafter32(1, 2)
+	cases := []struct {
+		tol  Tolerance
+		a, b float32
+		want bool
+	}{
+		{ULPTolerance(1), 1, next, true},
+		{ULPTolerance(1), 1, math.Nextafter32(next, 2), false},
+		{ULPTolerance(4), 1e6, 1e6 + 0.25, true},
+		{ULPTolerance(2), -math.SmallestNonzeroFloat32, math.SmallestNonzeroFloat32, true},
+		{RelTolerance(1e-6), 1e6, 1e6 + 0.5, true},
+		{AbsTolerance(0.1), 1e6, 1e6 + 0.5, false},
+		{AbsTolerance(1), float32(math.NaN()), 0, false},
+	}
+
+	for testIndex, c := range cases {
+		if got := c.tol.Eq32(c.a, c.b); got != c.want {
+			t.Errorf("TestEq32Tolerance %d %v", testIndex, got)
+		}
+		if got := c.tol.Eq32(c.b, c.a); got != c.want {
+			t.Errorf("TestEq32Tolerance %d swapped %v", testIndex, got)
+		}
+	}
+}
+
+func TestApproxEqTolerance(t *testing.T) {
+	// large world coordinates fail the default absolute epsilon
+	v := Vec3{1e7, -2e7, 3e7}
+	w := Vec3{1e7 + 1e-8, -2e7, 3e7 - 1e-8}
+	if v.ApproxEq(w, DefaultTolerance) || !v.ApproxEq(w, RelTolerance(1e-12)) || !v.ApproxEq(w, ULPTolerance(8)) {
+		t.Errorf("TestApproxEqTolerance Vec3")
+	}
+
+	m := Mat4{}
+	m.FromTRS(v, QuatIdentity, Vec3{1, 1, 1})
+	n := Mat4{}
+	n.FromTRS(w, QuatIdentity, Vec3{1, 1, 1})
+	if m.ApproxEq(n, DefaultTolerance) || !m.ApproxEq(n, RelTolerance(1e-12)) {
+		t.Errorf("TestApproxEqTolerance Mat4")
+	}
+	if !m.Mat4f().ApproxEq(n.Mat4f(), ULPTolerance(0)) {
+		t.Errorf("TestApproxEqTolerance Mat4f")
+	}
+	if !Mat3Identity.ApproxEq(Mat3Identity.MultScalar(1+1e-12), DefaultTolerance) {
+		t.Errorf("TestApproxEqTolerance Mat3")
+	}
+
+	q := transformTestRotation(1, 0, 1, 0)
+	neg := q.MultScalar(-1)
+	if q.ApproxEq(neg, DefaultTolerance) || !q.ApproxEqRotation(neg, DefaultTolerance) {
+		t.Errorf("TestApproxEqTolerance Quat")
+	}
+	if q.ApproxEqRotation(transformTestRotation(1.1, 0, 1, 0), DefaultTolerance) {
+		t.Errorf("TestApproxEqTolerance Quat different")
+	}
+	qf := q.Quatf()
+	if !qf.ApproxEqRotation(neg.Quatf(), ULPTolerance(0)) {
+		t.Errorf("TestApproxEqTolerance Quatf")
+	}
+
+	tr := Transform{v, q, Vec3{1, 1, 1}}
+	if !tr.ApproxEq(Transform{w, neg, Vec3{1, 1, 1}}, RelTolerance(1e-12)) {
+		t.Errorf("TestApproxEqTolerance Transform")
+	}
+	dq := DualQuatFromTransform(tr)
+	dqNeg := DualQuat{dq.Real.MultScalar(-1), dq.Dual.MultScalar(-1)}
+	if dq.ApproxEq(dqNeg, DefaultTolerance) || !dq.ApproxEqRotation(dqNeg, DefaultTolerance) {
+		t.Errorf("TestApproxEqTolerance DualQuat")
+	}
+}
+
+func TestApproxEqShapes(t *testing.T) {
+	// far from the origin only a relative tolerance accepts the rounding,
+	// diff compares against a shape which is really different
+	v := Vec3{1e7, -2e7, 3e7}
+	w := Vec3{1e7 + 1e-8, -2e7, 3e7 - 1e-8}
+	u := Vec3{0, 0, 1}
+	q := transformTestRotation(1, 0, 1, 0)
+	rel := RelTolerance(1e-12)
+
+	// an infinite far plane has a D of -Inf, which still compares equal
+	var proj Mat4
+	clip := ClipSpace{InfiniteFar: true}
+	proj.ToPerspectiveClip(Radians(60), 1, 0.5, 50, clip)
+	fa := FrustumFromMat4Clip(proj, clip)
+	fb, fc := fa, fa
+	fa.Planes[FrustumNear].D = 3e7
+	fb.Planes[FrustumNear].D = 3e7 - 1e-8
+	fc.Planes[FrustumLeft] = fc.Planes[FrustumRight]
+	if !math.IsInf(fa.Planes[FrustumFar].D, -1) {
+		t.Errorf("TestApproxEqShapes far plane %v", fa.Planes[FrustumFar])
+	}
+
+	cases := []struct {
+		name           string
+		def, rel, diff bool
+	}{
+		{"Ray",
+			Ray{v, u}.ApproxEq(Ray{w, u}, DefaultTolerance),
+			Ray{v, u}.ApproxEq(Ray{w, u}, rel),
+			Ray{v, u}.ApproxEq(Ray{v, Vec3{0, 1, 0}}, rel)},
+		{"AABB",
+			AABB{u, v}.ApproxEq(AABB{u, w}, DefaultTolerance),
+			AABB{u, v}.ApproxEq(AABB{u, w}, rel),
+			AABB{u, v}.ApproxEq(AABB{u.MultScalar(2), v}, rel)},
+		{"Sphere",
+			Sphere{v, 2}.ApproxEq(Sphere{w, 2}, DefaultTolerance),
+			Sphere{v, 2}.ApproxEq(Sphere{w, 2}, rel),
+			Sphere{v, 2}.ApproxEq(Sphere{v, 2.1}, rel)},
+		{"Plane",
+			Plane{u, 3e7}.ApproxEq(Plane{u, 3e7 - 1e-8}, DefaultTolerance),
+			Plane{u, 3e7}.ApproxEq(Plane{u, 3e7 - 1e-8}, rel),
+			Plane{u, 3e7}.ApproxEq(Plane{u.MultScalar(-1), -3e7}, rel)},
+		{"OBB",
+			OBB{v, u, q}.ApproxEq(OBB{w, u, q.MultScalar(-1)}, DefaultTolerance),
+			OBB{v, u, q}.ApproxEq(OBB{w, u, q.MultScalar(-1)}, rel),
+			OBB{v, u, q}.ApproxEq(OBB{v, u, QuatIdentity}, rel)},
+		{"Triangle",
+			Triangle{u, v, v.Add(u)}.ApproxEq(Triangle{u, w, v.Add(u)}, DefaultTolerance),
+			Triangle{u, v, v.Add(u)}.ApproxEq(Triangle{u, w, v.Add(u)}, rel),
+			Triangle{u, v, v.Add(u)}.ApproxEq(Triangle{v, v.Add(u), u}, rel)},
+		{"Segment",
+			Segment{u, v}.ApproxEq(Segment{u, w}, DefaultTolerance),
+			Segment{u, v}.ApproxEq(Segment{u, w}, rel),
+			Segment{u, v}.ApproxEq(Segment{v, u}, rel)},
+		{"Capsule",
+			Capsule{u, v, 1}.ApproxEq(Capsule{u, w, 1}, DefaultTolerance),
+			Capsule{u, v, 1}.ApproxEq(Capsule{u, w, 1}, rel),
+			Capsule{u, v, 1}.ApproxEq(Capsule{u, v, 1.5}, rel)},
+		{"Frustum",
+			fa.ApproxEq(fb, DefaultTolerance),
+			fa.ApproxEq(fb, rel),
+			fa.ApproxEq(fc, rel)},
+		{"Viewport",
+			Viewport{0, 0, 3e7, 2e7}.ApproxEq(Viewport{0, 0, 3e7 + 1e-8, 2e7}, DefaultTolerance),
+			Viewport{0, 0, 3e7, 2e7}.ApproxEq(Viewport{0, 0, 3e7 + 1e-8, 2e7}, rel),
+			Viewport{0, 0, 3e7, 2e7}.ApproxEq(Viewport{1, 0, 3e7, 2e7}, rel)},
+	}
+
+	for _, c := range cases {
+		if c.def || !c.rel || c.diff {
+			t.Errorf("TestApproxEqShapes %s %v %v %v", c.name, c.def, c.rel, c.diff)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package lmath
+
+import (
+	"math"
+)
+
+// A transformation made of a position, rotation and scale.
+// Points are scaled first, then rotated and finally translated
+// (ie. the matrix T * R * S). Keeping the three parts separate avoids the
+// numerical drift of repeatedly multiplying Mat4 values together.
+type Transform struct {
+	Position Vec3
+
+	// Should always be unit length
+	Rotation Quat
+	Scale    Vec3
+}
+
+var (
+	TransformIdentity = Transform{Vec3{0, 0, 0}, QuatIdentity, Vec3{1, 1, 1}}
+)
+
+// Return the transform represented by the matrix.
+// ok is false when the matrix can't be cleanly decomposed, see Mat4.Decompose
+func TransformFromMat4(m Mat4) (out Transform, ok bool) {
+	out.Position, out.Rotation, out.Scale, ok = m.Decompose()
+	return
+}
+
+// Return the matrix of the transform.
+func (this Transform) Mat4() Mat4 {
+	m := Mat4{}
+	m.FromTRS(this.Position, this.Rotation, this.Scale)
+	return m
+}
+
+// Combine the transforms as parent * child, so the child is applied first.
+// Returns a new transform with the result.
+// Like any TRS representation this is only exact when the parent has a uniform
+// scale or the child has no rotation, otherwise the shear of the real product
+// is lost.
+func (this Transform) Mult(child Transform) Transform {
+	this.MultIn(child)
+	return this
+}
+
+// Combine the transforms as this * child, so the child is applied first.
+// Store the result into 'this'. See Mult
+// Return a pointer to 'this'
+func (this *Transform) MultIn(child Transform) *Transform {
+	this.Position = this.TransformPoint(child.Position)
+	this.Rotation = this.Rotation.Mult(child.Rotation)
+	this.Scale.OuterIn(child.Scale)
+	return this
+}
+
+// Return the inverse of the transform.
+// This is only exact when the scale is uniform.
+func (this Transform) Inverse() Transform {
+	this.InverseIn()
+	return this
+}
+
+// Invert the transform. See Inverse
+// Return a pointer to 'this'
+func (this *Transform) InverseIn() *Transform {
+	this.Rotation = this.Rotation.Conjugate()
+	this.Scale.Set(1/this.Scale.X, 1/this.Scale.Y, 1/this.Scale.Z)
+	this.Position = this.Rotation.RotateVec3(this.Position.MultScalar(-1)).Outer(this.Scale)
+	return this
+}
+
+// Return the inverse transform and true, or the identity and false when the
+// transform can't be inverted. A scale is treated as zero when it is below
+// epsilon relative to the largest scale, so uniformly small scales still
+// invert. The rotation must not be zero.
+func (this Transform) TryInverse() (Transform, bool) {
+	s := this.Scale
+	limit := epsilon * math.Max(math.Abs(s.X), math.Max(math.Abs(s.Y), math.Abs(s.Z)))
+	if math.Abs(s.X) <= limit || math.Abs(s.Y) <= limit || math.Abs(s.Z) <= limit ||
+		this.Rotation.NormSq() == 0 {
+		return TransformIdentity, false
+	}
+	out := this.Inverse()
+	return out, out.IsFinite()
+}
+
+// Return true if no element is NaN or infinite.
+func (this Transform) IsFinite() bool {
+	return this.Position.IsFinite() && this.Rotation.IsFinite() && this.Scale.IsFinite()
+}
+
+// Return true if any element is NaN.
+func (this Transform) HasNaN() bool {
+	return this.Position.HasNaN() || this.Rotation.HasNaN() || this.Scale.HasNaN()
+}
+
+// Equal if all the components are equal within the tolerance.
+// The rotations are compared with ApproxEqRotation.
+func (this Transform) ApproxEq(other Transform, tol Tolerance) bool {
+	return this.Position.ApproxEq(other.Position, tol) &&
+		this.Rotation.ApproxEqRotation(other.Rotation, tol) &&
+		this.Scale.ApproxEq(other.Scale, tol)
+}
+
+// Return the point transformed by the scale, rotation and position.
+func (this Transform) TransformPoint(p Vec3) Vec3 {
+	return this.Rotation.RotateVec3(p.Outer(this.Scale)).Add(this.Position)
+}
+
+// Return the direction transformed by the scale and rotation.
+// The position is ignored.
+func (this Transform) TransformDirection(d Vec3) Vec3 {
+	return this.Rotation.RotateVec3(d.Outer(this.Scale))
+}
+
+// Rotate the transform so its forward axis (-Z) points from the position to
+// the target, keeping the local Y axis as close to up as possible. This
+// matches the orientation of a camera built with Mat4.ToLookAt.
+// The target must not be at the position and up must not be parallel to the
+// view direction.
+// Return a pointer to 'this'
+func (this *Transform) LookAt(target, up Vec3) *Transform {
+	f := target.Sub(this.Position).Normalize()
+	s := f.Cross(up).Normalize()
+	u := s.Cross(f)
+
+	m := Mat3{}
+	m.SetCol(0, s.X, s.Y, s.Z)
+	m.SetCol(1, u.X, u.Y, u.Z)
+	m.SetCol(2, -f.X, -f.Y, -f.Z)
+	this.Rotation.FromMat3(m)
+	return this
+}
+
+// Interpolate between the two transforms.
+// The position and scale are linearly interpolated and the rotation is
+// spherically interpolated along the shortest path. inc is specified between
+// the range 0 - 1.
+func LerpTransform(a, b Transform, inc float64) Transform {
+	return Transform{
+		a.Position.Add(b.Position.Sub(a.Position).MultScalar(inc)),
+		Slerp(a.Rotation, b.Rotation, inc),
+		a.Scale.Add(b.Scale.Sub(a.Scale).MultScalar(inc)),
+	}
+}
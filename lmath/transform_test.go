@@ -0,0 +1,189 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func transformTestRotation(angle float64, x, y, z float64) Quat {
+	axis := Vec3{x, y, z}.Normalize()
+	q := Quat{}
+	q.FromAxisAngle(angle, axis.X, axis.Y, axis.Z)
+	return q
+}
+
+func TestMat4Transform(t *testing.T) {
+	cases := []Transform{
+		TransformIdentity,
+		{Vec3{1, 2, 3}, QuatIdentity, Vec3{1, 1, 1}},
+		{Vec3{1, 2, 3}, transformTestRotation(0.7, 1, 2, 3), Vec3{2, 3, 4}},
+		{Vec3{-4, 0, 1}, transformTestRotation(2.5, 0, 1, 0), Vec3{0.5, 0.5, 0.5}},
+	}
+	points := []Vec3{{0, 0, 0}, {1, 0, 0}, {1, -2, 3}}
+
+	for testIndex, c := range cases {
+		m := c.Mat4()
+		for _, p := range points {
+			get := c.TransformPoint(p)
+			want := m.TransformPoint(p)
+			if get.Sub(want).Length() > 1e-9 {
+				t.Errorf("TestMat4Transform %d point %v %v", testIndex, get, want)
+			}
+			get = c.TransformDirection(p)
+			want = m.TransformDirection(p)
+			if get.Sub(want).Length() > 1e-9 {
+				t.Errorf("TestMat4Transform %d direction %v %v", testIndex, get, want)
+			}
+		}
+
+		back, ok := TransformFromMat4(m)
+		if !ok || back.Position.Sub(c.Position).Length() > 1e-9 ||
+			back.Scale.Sub(c.Scale).Length() > 1e-9 ||
+			math.Abs(back.Rotation.Dot(c.Rotation)) < 1-1e-9 {
+			t.Errorf("TestMat4Transform %d from mat4 %v %v", testIndex, back, c)
+		}
+	}
+}
+
+func TestMultTransform(t *testing.T) {
+	cases := []struct {
+		parent, child Transform
+	}{
+		{TransformIdentity, Transform{Vec3{1, 2, 3}, transformTestRotation(1, 0, 0, 1), Vec3{1, 2, 1}}},
+		{
+			Transform{Vec3{1, 2, 3}, transformTestRotation(0.7, 1, 2, 3), Vec3{2, 2, 2}},
+			Transform{Vec3{-1, 0, 5}, transformTestRotation(1.2, 0, 1, 0), Vec3{1, 3, 0.5}},
+		},
+		{
+			// non-uniform parent scale is exact when the child isn't rotated
+			Transform{Vec3{0, -3, 1}, transformTestRotation(2, 1, 0, 0), Vec3{1, 2, 3}},
+			Transform{Vec3{4, 4, 4}, QuatIdentity, Vec3{2, 1, 1}},
+		},
+	}
+
+	for testIndex, c := range cases {
+		get := c.parent.Mult(c.child).Mat4()
+		want := c.parent.Mat4().Mult(c.child.Mat4())
+		for k := 0; k < 16; k++ {
+			if !closeEq(get.At(k), want.At(k), 1e-9) {
+				t.Errorf("TestMultTransform %d\n%v\n%v", testIndex, &get, &want)
+				break
+			}
+		}
+	}
+}
+
+func TestInverseTransform(t *testing.T) {
+	cases := []Transform{
+		TransformIdentity,
+		{Vec3{1, 2, 3}, transformTestRotation(0.7, 1, 2, 3), Vec3{1, 1, 1}},
+		{Vec3{-4, 0, 1}, transformTestRotation(2.5, 0, 1, 0), Vec3{3, 3, 3}},
+	}
+	p := Vec3{1, -2, 3}
+
+	for testIndex, c := range cases {
+		inv := c.Inverse()
+		get := inv.TransformPoint(c.TransformPoint(p))
+		if get.Sub(p).Length() > 1e-9 {
+			t.Errorf("TestInverseTransform %d %v %v", testIndex, get, p)
+		}
+
+		m := c.Mult(inv).Mat4()
+		for k := 0; k < 16; k++ {
+			if !closeEq(m.At(k), Mat4Identity.At(k), 1e-9) {
+				t.Errorf("TestInverseTransform %d identity\n%v", testIndex, &m)
+				break
+			}
+		}
+	}
+}
+
+func TestLookAtTransform(t *testing.T) {
+	cases := []struct {
+		position, target, up Vec3
+	}{
+		{Vec3{0, 0, 0}, Vec3{0, 0, -1}, Vec3{0, 1, 0}},
+		{Vec3{1, 2, 3}, Vec3{4, -1, 0}, Vec3{0, 1, 0}},
+		{Vec3{0, 5, 0}, Vec3{0, 0, 0}, Vec3{0, 0, -1}},
+	}
+
+	for testIndex, c := range cases {
+		tr := TransformIdentity
+		tr.Position = c.position
+		tr.LookAt(c.target, c.up)
+
+		forward := tr.TransformDirection(Vec3{0, 0, -1})
+		want := c.target.Sub(c.position).Normalize()
+		if forward.Sub(want).Length() > 1e-9 {
+			t.Errorf("TestLookAtTransform %d forward %v %v", testIndex, forward, want)
+		}
+		if tr.TransformDirection(Vec3{0, 1, 0}).Dot(c.up) <= 0 {
+			t.Errorf("TestLookAtTransform %d up %v", testIndex, tr.Rotation)
+		}
+
+		// the inverse is the view matrix
+		view := Mat4{}
+		view.ToLookAt(c.position, c.target, c.up)
+		get := tr.Inverse().Mat4()
+		for k := 0; k < 16; k++ {
+			if !closeEq(get.At(k), view.At(k), 1e-9) {
+				t.Errorf("TestLookAtTransform %d view\n%v\n%v", testIndex, &get, &view)
+				break
+			}
+		}
+	}
+}
+
+func TestLerpTransform(t *testing.T) {
+	a := Transform{Vec3{0, 0, 0}, QuatIdentity, Vec3{1, 1, 1}}
+	b := Transform{Vec3{2, 4, -2}, transformTestRotation(math.Pi/2, 0, 1, 0), Vec3{3, 1, 5}}
+
+	cases := []struct {
+		inc  float64
+		want Transform
+	}{
+		{0, a},
+		{1, b},
+		{0.5, Transform{Vec3{1, 2, -1}, transformTestRotation(math.Pi/4, 0, 1, 0), Vec3{2, 1, 3}}},
+	}
+
+	for testIndex, c := range cases {
+		get := LerpTransform(a, b, c.inc)
+		if get.Position.Sub(c.want.Position).Length() > 1e-9 ||
+			get.Scale.Sub(c.want.Scale).Length() > 1e-9 ||
+			math.Abs(get.Rotation.Dot(c.want.Rotation)) < 1-1e-9 {
+			t.Errorf("TestLerpTransform %d %v %v", testIndex, get, c.want)
+		}
+	}
+}
+
+func TestTryInverseTransform(t *testing.T) {
+	tr := Transform{Vec3{1, 2, 3}, transformTestRotation(0.5, 0, 1, 0), Vec3{2, 2, 2}}
+	inv, ok := tr.TryInverse()
+	if !ok || inv.Mult(tr).Position.Length() > 1e-9 {
+		t.Errorf("TestTryInverseTransform %v %v", inv, ok)
+	}
+
+	tr.Scale.Y = 0
+	if _, ok := tr.TryInverse(); ok {
+		t.Errorf("TestTryInverseTransform zero scale")
+	}
+	// scales are compared against the largest one
+	tr.Scale.Y = 1e-12
+	if _, ok := tr.TryInverse(); ok {
+		t.Errorf("TestTryInverseTransform relative scale")
+	}
+	small := Transform{Vec3{}, transformTestRotation(0.5, 0, 1, 0), Vec3{1e-10, 1e-10, 1e-10}}
+	inv, ok = small.TryInverse()
+	if !ok || !inv.Mult(small).ApproxEq(TransformIdentity, DefaultTolerance) {
+		t.Errorf("TestTryInverseTransform small scale %v %v", inv, ok)
+	}
+	tr.Scale.Y = 2
+	tr.Position.X = math.NaN()
+	if _, ok := tr.TryInverse(); ok {
+		t.Errorf("TestTryInverseTransform NaN")
+	}
+	if tr.IsFinite() || !tr.HasNaN() {
+		t.Errorf("TestTryInverseTransform IsFinite")
+	}
+}
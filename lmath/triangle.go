@@ -0,0 +1,137 @@
+package lmath
+
+// A triangle with the corners A, B and C.
+// The front face is the side where A, B, C wind counter-clockwise.
+type Triangle struct {
+	A, B, C Vec3
+}
+
+// Return the unit normal of the front face of the triangle.
+func (this Triangle) Normal() Vec3 {
+	return this.B.Sub(this.A).Cross(this.C.Sub(this.A)).Normalize()
+}
+
+// Return the area of the triangle.
+func (this Triangle) Area() float64 {
+	return this.B.Sub(this.A).Cross(this.C.Sub(this.A)).Length() / 2
+}
+
+// Return the centroid (center of mass) of the triangle.
+func (this Triangle) Centroid() Vec3 {
+	return this.A.Add(this.B).Add(this.C).DivScalar(3)
+}
+
+// Return the plane the triangle lies in.
+func (this Triangle) Plane() Plane {
+	return PlaneFromPoints(this.A, this.B, this.C)
+}
+
+// Return the barycentric coordinates (u,v,w) of p with respect to the
+// triangle, such that p == A*u + B*v + C*w when p lies in the plane of the
+// triangle. Points outside of the plane are projected onto it.
+func (this Triangle) Barycentric(p Vec3) (u, v, w float64) {
+	// Reference : Christer Ericson, "Real-Time Collision Detection" 3.4
+	v0 := this.B.Sub(this.A)
+	v1 := this.C.Sub(this.A)
+	v2 := p.Sub(this.A)
+	d00 := v0.Dot(v0)
+	d01 := v0.Dot(v1)
+	d11 := v1.Dot(v1)
+	d20 := v2.Dot(v0)
+	d21 := v2.Dot(v1)
+	denom := d00*d11 - d01*d01
+	v = (d11*d20 - d01*d21) / denom
+	w = (d00*d21 - d01*d20) / denom
+	u = 1 - v - w
+	return
+}
+
+// Return the point on the triangle which is closest to p.
+func (this Triangle) ClosestPoint(p Vec3) Vec3 {
+	// Reference : Christer Ericson, "Real-Time Collision Detection" 5.1.5
+	// Find which voronoi region of the triangle p lies in.
+	a, b, c := this.A, this.B, this.C
+	ab := b.Sub(a)
+	ac := c.Sub(a)
+
+	ap := p.Sub(a)
+	d1 := ab.Dot(ap)
+	d2 := ac.Dot(ap)
+	if d1 <= 0 && d2 <= 0 {
+		return a
+	}
+
+	bp := p.Sub(b)
+	d3 := ab.Dot(bp)
+	d4 := ac.Dot(bp)
+	if d3 >= 0 && d4 <= d3 {
+		return b
+	}
+
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		// edge ab
+		return a.Add(ab.MultScalar(d1 / (d1 - d3)))
+	}
+
+	cp := p.Sub(c)
+	d5 := ab.Dot(cp)
+	d6 := ac.Dot(cp)
+	if d6 >= 0 && d5 <= d6 {
+		return c
+	}
+
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		// edge ac
+		return a.Add(ac.MultScalar(d2 / (d2 - d6)))
+	}
+
+	va := d3*d6 - d5*d4
+	if va <= 0 && (d4-d3) >= 0 && (d5-d6) >= 0 {
+		// edge bc
+		return b.Add(c.Sub(b).MultScalar((d4 - d3) / ((d4 - d3) + (d5 - d6))))
+	}
+
+	// inside the face
+	denom := 1 / (va + vb + vc)
+	return a.Add(ab.MultScalar(vb * denom)).Add(ac.MultScalar(vc * denom))
+}
+
+// Return the distance from p to the closest point on the triangle.
+func (this Triangle) Distance(p Vec3) float64 {
+	return p.Sub(this.ClosestPoint(p)).Length()
+}
+
+// Return true if the point lies on the triangle.
+// Equality is measured using an epsilon (< 0.0000001).
+func (this Triangle) Contains(p Vec3) bool {
+	return this.Distance(p) < epsilon
+}
+
+// Return a new triangle transformed by the matrix.
+func (this Triangle) Transform(m Mat4) Triangle {
+	return Triangle{m.MultVec3(this.A), m.MultVec3(this.B), m.MultVec3(this.C)}
+}
+
+// Return a new triangle rotated about the origin by the quaternion.
+func (this Triangle) Rotate(q Quat) Triangle {
+	return Triangle{q.RotateVec3(this.A), q.RotateVec3(this.B), q.RotateVec3(this.C)}
+}
+
+// Return true if no element is NaN or infinite.
+func (this Triangle) IsFinite() bool {
+	return this.A.IsFinite() && this.B.IsFinite() && this.C.IsFinite()
+}
+
+// Return true if any element is NaN.
+func (this Triangle) HasNaN() bool {
+	return this.A.HasNaN() || this.B.HasNaN() || this.C.HasNaN()
+}
+
+// Equal if the vertices are equal within the tolerance, in the same order.
+func (this Triangle) ApproxEq(other Triangle, tol Tolerance) bool {
+	return this.A.ApproxEq(other.A, tol) &&
+		this.B.ApproxEq(other.B, tol) &&
+		this.C.ApproxEq(other.C, tol)
+}
@@ -0,0 +1,89 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPropertiesTriangle(t *testing.T) {
+	tri := Triangle{Vec3{0, 0, 0}, Vec3{2, 0, 0}, Vec3{0, 2, 0}}
+	if !tri.Normal().Eq(Vec3{0, 0, 1}) {
+		t.Errorf("TestPropertiesTriangle normal %v", tri.Normal())
+	}
+	if tri.Area() != 2 {
+		t.Errorf("TestPropertiesTriangle area %v", tri.Area())
+	}
+	if !tri.Centroid().Eq(Vec3{2.0 / 3, 2.0 / 3, 0}) {
+		t.Errorf("TestPropertiesTriangle centroid %v", tri.Centroid())
+	}
+	if p := tri.Plane(); !p.Normal.Eq(Vec3{0, 0, 1}) || p.D != 0 {
+		t.Errorf("TestPropertiesTriangle plane %v", p)
+	}
+
+	cases := []struct {
+		p       Vec3
+		u, v, w float64
+	}{
+		{Vec3{0, 0, 0}, 1, 0, 0},
+		{Vec3{2, 0, 0}, 0, 1, 0},
+		{Vec3{0, 2, 0}, 0, 0, 1},
+		{Vec3{1, 1, 0}, 0, 0.5, 0.5},
+		{Vec3{0.5, 0.5, 3}, 0.5, 0.25, 0.25},
+		{Vec3{-2, 0, 0}, 2, -1, 0},
+	}
+	for testIndex, c := range cases {
+		u, v, w := tri.Barycentric(c.p)
+		if !closeEq(u, c.u, epsilon) || !closeEq(v, c.v, epsilon) || !closeEq(w, c.w, epsilon) {
+			t.Errorf("TestPropertiesTriangle barycentric %d %v %v %v", testIndex, u, v, w)
+		}
+	}
+}
+
+func TestClosestPointTriangle(t *testing.T) {
+	tri := Triangle{Vec3{0, 0, 0}, Vec3{4, 0, 0}, Vec3{0, 4, 0}}
+	cases := []struct {
+		p, want Vec3
+	}{
+		// vertex regions
+		{Vec3{-1, -1, 0}, Vec3{0, 0, 0}},
+		{Vec3{6, -1, 1}, Vec3{4, 0, 0}},
+		{Vec3{-1, 6, -1}, Vec3{0, 4, 0}},
+		// edge regions
+		{Vec3{2, -3, 0}, Vec3{2, 0, 0}},
+		{Vec3{-3, 2, 2}, Vec3{0, 2, 0}},
+		{Vec3{3, 3, 0}, Vec3{2, 2, 0}},
+		// face region
+		{Vec3{1, 1, 5}, Vec3{1, 1, 0}},
+		{Vec3{1, 2, 0}, Vec3{1, 2, 0}},
+	}
+
+	for testIndex, c := range cases {
+		if get := tri.ClosestPoint(c.p); !get.Eq(c.want) {
+			t.Errorf("TestClosestPointTriangle %d %v", testIndex, get)
+		}
+		want := c.p.Sub(c.want).Length()
+		if get := tri.Distance(c.p); !closeEq(get, want, epsilon) {
+			t.Errorf("TestClosestPointTriangle dist %d %v", testIndex, get)
+		}
+		if get := tri.Contains(c.p); get != (want == 0) {
+			t.Errorf("TestClosestPointTriangle contains %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestTransformTriangle(t *testing.T) {
+	tri := Triangle{Vec3{0, 0, 0}, Vec3{1, 0, 0}, Vec3{0, 1, 0}}
+	var m Mat4
+	m.ToScale(2, 3, 1)
+	get := tri.Transform(m)
+	if !get.B.Eq(Vec3{2, 0, 0}) || !get.C.Eq(Vec3{0, 3, 0}) || get.Area() != 3 {
+		t.Errorf("TestTransformTriangle %v", get)
+	}
+
+	var q Quat
+	q.FromAxisAngle(math.Pi/2, 1, 0, 0)
+	get = tri.Rotate(q)
+	if !get.C.Eq(Vec3{0, 0, 1}) || !get.Normal().Eq(Vec3{0, -1, 0}) {
+		t.Errorf("TestTransformTriangle rotate %v", get)
+	}
+}
@@ -0,0 +1,278 @@
+package lmath
+
+import (
+	"math"
+)
+
+// A Vector 2 containing the two components
+// X, Y
+type Vec2 struct {
+	X, Y float64
+}
+
+var (
+	Vec2Right = Vec2{1, 0}
+	Vec2Up    = Vec2{0, 1}
+	Vec2Zero  = Vec2{0, 0}
+)
+
+// Returns a new vector which is the result of adding 'this' with the
+// other vector
+func (this Vec2) Add(other Vec2) Vec2 {
+	this.AddIn(other)
+	return this
+}
+
+// Adds 'this' with the other vector.
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec2) AddIn(other Vec2) *Vec2 {
+	this.X += other.X
+	this.Y += other.Y
+	return this
+}
+
+// Returns a new vector which is the result of subtracting 'this' with the
+// other vector
+func (this Vec2) Sub(other Vec2) Vec2 {
+	this.SubIn(other)
+	return this
+}
+
+// Subtracts'this' with the other vector.
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec2) SubIn(other Vec2) *Vec2 {
+	this.X -= other.X
+	this.Y -= other.Y
+	return this
+}
+
+// Returns a new vector with the scalar added to every element
+func (this Vec2) AddScalar(scale float64) Vec2 {
+	this.AddInScalar(scale)
+	return this
+}
+
+// Add the scale to every element in the vector
+// Return this
+func (this *Vec2) AddInScalar(scale float64) *Vec2 {
+	this.X += scale
+	this.Y += scale
+	return this
+}
+
+// Returns a new vector with the scalar subtracted to every element
+func (this Vec2) SubScalar(scale float64) Vec2 {
+	this.SubInScalar(scale)
+	return this
+}
+
+// Subtract the scale from every element in the vector
+// Return a pointer to 'this'
+func (this *Vec2) SubInScalar(scale float64) *Vec2 {
+	this.X -= scale
+	this.Y -= scale
+	return this
+}
+
+// Returns a new vector where every element is multiplied by the scale
+func (this Vec2) MultScalar(scale float64) Vec2 {
+	this.MultInScalar(scale)
+	return this
+}
+
+// Multiply the each element of this vector with the scale value.
+// Return a pointer to 'this'
+func (this *Vec2) MultInScalar(scale float64) *Vec2 {
+	this.X *= scale
+	this.Y *= scale
+	return this
+}
+
+// Returns a new vector where every element is division by the scale
+func (this Vec2) DivScalar(scale float64) Vec2 {
+	this.DivInScalar(scale)
+	return this
+}
+
+// Divide the each element of this vector with the scale value.
+// Return a pointer to 'this'
+func (this *Vec2) DivInScalar(scale float64) *Vec2 {
+	this.X /= scale
+	this.Y /= scale
+	return this
+}
+
+// Do a pair-wise element multiplication with the provided vector
+// Returns a new vector with the result
+func (this Vec2) Outer(other Vec2) Vec2 {
+	this.OuterIn(other)
+	return this
+}
+
+// Do a element-wise multiplication with the provided vector
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec2) OuterIn(other Vec2) *Vec2 {
+	this.X = this.X * other.X
+	this.Y = this.Y * other.Y
+	return this
+}
+
+// Returns the Dot product between 'this' and the other vector
+func (this Vec2) Dot(other Vec2) float64 {
+	return this.X*other.X + this.Y*other.Y
+}
+
+// Return the length of the vector
+// sqrt(x^2 + y^2)
+func (this Vec2) Length() float64 {
+	return math.Sqrt(this.X*this.X + this.Y*this.Y)
+}
+
+// Return the squared length of the vector
+// x^2 + y^2
+func (this Vec2) LengthSq() float64 {
+	return this.X*this.X + this.Y*this.Y
+}
+
+// Checks for equality between the vectors.
+// Equal is all elements are equal within an epsilon ( < 0.0000001)
+func (this Vec2) Eq(other Vec2) bool {
+	return closeEq(this.X, other.X, epsilon) &&
+		closeEq(this.Y, other.Y, epsilon)
+}
+
+// Checks for equality between the vectors.
+// Equal is all elements are equal within an user specified e
+func (this Vec2) CloseEq(other Vec2, e float64) bool {
+	return closeEq(this.X, other.X, e) &&
+		closeEq(this.Y, other.Y, e)
+}
+
+// Equal if all elements are equal within the tolerance
+func (this Vec2) ApproxEq(other Vec2, tol Tolerance) bool {
+	return tol.Eq(this.X, other.X) &&
+		tol.Eq(this.Y, other.Y)
+}
+
+// Return true if no element is NaN or infinite.
+func (this Vec2) IsFinite() bool {
+	return isFinite(this.X) &&
+		isFinite(this.Y)
+}
+
+// Return true if any element is NaN.
+func (this Vec2) HasNaN() bool {
+	return math.IsNaN(this.X) ||
+		math.IsNaN(this.Y)
+}
+
+// Return a new vector which is the normalized version of 'this'
+func (this Vec2) Normalize() Vec2 {
+	this.NormalizeIn()
+	return this
+}
+
+// Normalize the vector
+// Return a pointer to 'this'
+func (this *Vec2) NormalizeIn() *Vec2 {
+	mag := this.Length()
+	return this.DivInScalar(mag)
+}
+
+// Return the normalized vector, or fallback when the length is zero or not
+// finite so the vector can't be normalized.
+func (this Vec2) SafeNormalize(fallback Vec2) Vec2 {
+	this.SafeNormalizeIn(fallback)
+	return this
+}
+
+// Normalize the vector, or set it to fallback when the length is zero or not
+// finite so the vector can't be normalized.
+// Return a pointer to 'this'
+func (this *Vec2) SafeNormalizeIn(fallback Vec2) *Vec2 {
+	mag := this.Length()
+	if mag == 0 || !isFinite(mag) {
+		*this = fallback
+		return this
+	}
+	return this.DivInScalar(mag)
+}
+
+// Set X,Y parameters of the vector.
+func (this *Vec2) Set(x, y float64) *Vec2 {
+	this.X = x
+	this.Y = y
+	return this
+}
+
+// Make a vector which is the projection of this onto other
+func (this Vec2) Proj(other Vec2) Vec2 {
+	n := this.Length() * other.Length()
+	return other.Normalize().MultScalar(this.Dot(other) / n)
+}
+
+// Return a copy of this vector
+func (this Vec2) Copy() Vec2 {
+	return this
+}
+
+// Retrieve both x,y paramters at once
+func (this Vec2) Dump() (float64, float64) {
+	return this.X, this.Y
+}
+
+// Retrieve both x,y paramters at once, returned as float32
+func (this Vec2) Dumpf32() (float32, float32) {
+	return float32(this.X), float32(this.Y)
+}
+
+// convert to Vec3. Third component is set to zero.
+func (this Vec2) Vec3() Vec3 {
+	return Vec3{this.X, this.Y, 0}
+}
+
+//==============================================================================
+// Vector 2 specific methods
+
+// Returns a new vector which is perpendicular to 'this'.
+// The vector is rotated 90 degrees counter-clockwise, [x,y] => [-y,x]
+func (this Vec2) Perp() Vec2 {
+	this.PerpIn()
+	return this
+}
+
+// Rotate 'this' 90 degrees counter-clockwise, [x,y] => [-y,x]
+// Return a pointer to 'this'
+func (this *Vec2) PerpIn() *Vec2 {
+	this.X, this.Y = -this.Y, this.X
+	return this
+}
+
+// Returns the 2D cross product 'this' X 'other'.
+// This is the z component of the 3D cross product of the two vectors,
+// which is positive when other is counter-clockwise from 'this'.
+func (this Vec2) Cross(other Vec2) float64 {
+	return this.X*other.Y - this.Y*other.X
+}
+
+// Return the signed angle (radians) required to rotate 'this' onto other.
+// Counter-clockwise rotations are positive. The result is in the range [-pi,pi]
+func (this Vec2) Angle(other Vec2) float64 {
+	return math.Atan2(this.Cross(other), this.Dot(other))
+}
+
+// Apply the matrix against the Vector, treating the vector as a point [x,y,1]
+// Return a new vector with the result v*m
+func (this Vec2) MultMat3(right Mat3) Vec2 {
+	// 0   1   2
+	// 3   4   5
+	// 6   7   8
+	this.Set(
+		this.X*right.At(0)+this.Y*right.At(3)+right.At(6),
+		this.X*right.At(1)+this.Y*right.At(4)+right.At(7),
+	)
+	return this
+}
@@ -0,0 +1,426 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqualVec2(t *testing.T) {
+	var cases = []struct {
+		orig, other Vec2
+		want        bool
+	}{
+		{Vec2{0, 0}, Vec2{1, 2}, false},
+		{Vec2{1, 2}, Vec2{0, 0}, false},
+		{Vec2{1, 2}, Vec2{-1, -2}, false},
+		{Vec2{0, 0}, Vec2{0, 0}, true},
+		{Vec2{1.0, 2.0}, Vec2{1.0, 2.0}, true},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Eq(test.other)
+		if get != test.want {
+			t.Errorf("TestEqualVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestCloseEqVec2(t *testing.T) {
+	var cases = []struct {
+		orig, other Vec2
+		e           float64
+		want        bool
+	}{
+		{Vec2{0, 0}, Vec2{0.05, 0.05}, 0.1, true},
+		{Vec2{0, 0}, Vec2{0.05, 0.15}, 0.1, false},
+		{Vec2{1, 2}, Vec2{1, 2}, 0.1, true},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.CloseEq(test.other, test.e)
+		if get != test.want {
+			t.Errorf("TestCloseEqVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestAddVec2(t *testing.T) {
+	var cases = []struct {
+		orig, other, want Vec2
+	}{
+		{Vec2{0, 0}, Vec2{1, 2}, Vec2{1, 2}},
+		{Vec2{1, 2}, Vec2{0, 0}, Vec2{1, 2}},
+		{Vec2{1, 2}, Vec2{-1, -2}, Vec2{0, 0}},
+		{Vec2{0, 0}, Vec2{0, 0}, Vec2{0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Add(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestAddVec2 %d", testIndex)
+		}
+
+		get2 := test.orig.AddIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestAddVec2 AddIn %d", testIndex)
+		}
+	}
+}
+
+func TestSubVec2(t *testing.T) {
+	var cases = []struct {
+		orig, other, want Vec2
+	}{
+		{Vec2{0, 0}, Vec2{1, 2}, Vec2{-1, -2}},
+		{Vec2{1, 2}, Vec2{0, 0}, Vec2{1, 2}},
+		{Vec2{1, 2}, Vec2{-1, -2}, Vec2{2, 4}},
+		{Vec2{0, 0}, Vec2{0, 0}, Vec2{0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Sub(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSubVec2 %d", testIndex)
+		}
+
+		get2 := test.orig.SubIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestSubVec2 SubIn %d", testIndex)
+		}
+	}
+}
+
+func TestAddScalarVec2(t *testing.T) {
+	cases := []struct {
+		orig, want Vec2
+		scale      float64
+	}{
+		{Vec2{0, 0}, Vec2{1, 1}, 1},
+		{Vec2{1, 2}, Vec2{0, 1}, -1},
+		{Vec2{1, 2}, Vec2{1, 2}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.AddScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestAddScalarVec2 %d", testIndex)
+		}
+
+		get2 := test.orig.AddInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestAddInScalarVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestSubScalarVec2(t *testing.T) {
+	cases := []struct {
+		orig, want Vec2
+		scale      float64
+	}{
+		{Vec2{0, 0}, Vec2{-1, -1}, 1},
+		{Vec2{1, 2}, Vec2{2, 3}, -1},
+		{Vec2{1, 2}, Vec2{1, 2}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.SubScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSubScalarVec2 %d", testIndex)
+		}
+
+		get2 := test.orig.SubInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestSubInScalarVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestMultScalarVec2(t *testing.T) {
+	cases := []struct {
+		orig, want Vec2
+		scale      float64
+	}{
+		{Vec2{0, 0}, Vec2{0, 0}, 2},
+		{Vec2{1, 2}, Vec2{-1, -2}, -1},
+		{Vec2{1, 2}, Vec2{0, 0}, 0},
+		{Vec2{1, 2}, Vec2{2.5, 5}, 2.5},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.MultScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestMultScalarVec2 %d", testIndex)
+		}
+
+		get2 := test.orig.MultInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestMultInScalarVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestDivScalarVec2(t *testing.T) {
+	cases := []struct {
+		orig, want Vec2
+		scale      float64
+	}{
+		{Vec2{0, 0}, Vec2{0, 0}, 2},
+		{Vec2{1, 2}, Vec2{-1, -2}, -1},
+		{Vec2{1, 2}, Vec2{0.5, 1}, 2},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.DivScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestDivScalarVec2 %d", testIndex)
+		}
+
+		get2 := test.orig.DivInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestDivInScalarVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestOuterVec2(t *testing.T) {
+	var cases = []struct {
+		orig, other, want Vec2
+	}{
+		{Vec2{0, 0}, Vec2{1, 2}, Vec2{0, 0}},
+		{Vec2{1, 2}, Vec2{1, 2}, Vec2{1, 4}},
+		{Vec2{1, 2}, Vec2{-1, -2}, Vec2{-1, -4}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Outer(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestOuterVec2 %d", testIndex)
+		}
+
+		get2 := test.orig.OuterIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestOuterInVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestDotVec2(t *testing.T) {
+	var cases = []struct {
+		orig  Vec2
+		other Vec2
+		want  float64
+	}{
+		{Vec2{0, 0}, Vec2{1, 2}, 0},
+		{Vec2{1, 2}, Vec2{0, 0}, 0},
+		{Vec2{1, 2}, Vec2{-1, -2}, -5},
+		{Vec2{1, 2}, Vec2{1, 2}, 5},
+		{Vec2{1, 0}, Vec2{0, 1}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Dot(test.other)
+		if get != test.want {
+			t.Errorf("TestDotVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestLengthVec2(t *testing.T) {
+	var cases = []struct {
+		orig Vec2
+		want float64
+	}{
+		{Vec2{0, 0}, 0},
+		{Vec2{3, 4}, 5},
+		{Vec2{1, 0}, 1},
+		{Vec2{1, 2}, math.Sqrt(5)},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Length()
+		if get != test.want {
+			t.Errorf("TestLengthVec2 %d", testIndex)
+		}
+		if !closeEq(test.orig.LengthSq(), test.want*test.want, epsilon) {
+			t.Errorf("TestLengthSqVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestNormalizeVec2(t *testing.T) {
+	var cases = []struct {
+		orig, want Vec2
+	}{
+		{Vec2{3, 4}, Vec2{0.6, 0.8}},
+		{Vec2{1, 0}, Vec2{1, 0}},
+		{Vec2{0, -2}, Vec2{0, -1}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Normalize()
+		if get.Eq(test.want) == false {
+			t.Errorf("TestNormalizeVec2 %d", testIndex)
+		}
+
+		get2 := test.orig.NormalizeIn()
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestNormalizeInVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestSetVec2(t *testing.T) {
+	var cases = []struct {
+		x, y       float64
+		orig, want Vec2
+	}{
+		{1, 2, Vec2{0, 0}, Vec2{1, 2}},
+		{0, 1, Vec2{1, 2}, Vec2{0, 1}},
+		{-1, 2, Vec2{1, -1}, Vec2{-1, 2}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Set(test.x, test.y)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSetVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestProjVec2(t *testing.T) {
+	var cases = []struct {
+		from, on, want Vec2
+	}{
+		{Vec2{1, 1}, Vec2{1, 0}, Vec2{math.Sqrt(2) / 2, 0}},
+		{Vec2{1, 0}, Vec2{1, 0}, Vec2{1, 0}},
+		{Vec2{0, 1}, Vec2{1, 0}, Vec2{0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.from.Proj(test.on)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestProjVec2 %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestPerpVec2(t *testing.T) {
+	var cases = []struct {
+		orig, want Vec2
+	}{
+		{Vec2{1, 0}, Vec2{0, 1}},
+		{Vec2{0, 1}, Vec2{-1, 0}},
+		{Vec2{1, 2}, Vec2{-2, 1}},
+		{Vec2{0, 0}, Vec2{0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Perp()
+		if get.Eq(test.want) == false || get.Dot(test.orig) != 0 {
+			t.Errorf("TestPerpVec2 %d", testIndex)
+		}
+
+		get2 := test.orig.PerpIn()
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestPerpInVec2 %d", testIndex)
+		}
+	}
+}
+
+func TestCrossVec2(t *testing.T) {
+	var cases = []struct {
+		orig, other Vec2
+		want        float64
+	}{
+		{Vec2{1, 0}, Vec2{0, 1}, 1},
+		{Vec2{0, 1}, Vec2{1, 0}, -1},
+		{Vec2{1, 2}, Vec2{2, 4}, 0},
+		{Vec2{1, 2}, Vec2{3, 4}, -2},
+		{Vec2{0, 0}, Vec2{3, 4}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Cross(test.other)
+		if get != test.want {
+			t.Errorf("TestCrossVec2 %d %v", testIndex, get)
+		}
+
+		// Must match the z component of the 3D cross product
+		get3 := test.orig.Vec3().Cross(test.other.Vec3())
+		if !get3.Eq(Vec3{0, 0, test.want}) {
+			t.Errorf("TestCrossVec2 Vec3 %d %v", testIndex, get3)
+		}
+	}
+}
+
+func TestAngleVec2(t *testing.T) {
+	var cases = []struct {
+		orig, other Vec2
+		want        float64
+	}{
+		{Vec2{1, 0}, Vec2{0, 1}, 90},
+		{Vec2{0, 1}, Vec2{1, 0}, -90},
+		{Vec2{1, 0}, Vec2{1, 0}, 0},
+		{Vec2{1, 0}, Vec2{-1, 0}, 180},
+		{Vec2{1, 0}, Vec2{1, 1}, 45},
+		{Vec2{1, 0}, Vec2{1, -1}, -45},
+		{Vec2{2, 0}, Vec2{-3, -3}, -135},
+		{Vec2{0, -1}, Vec2{1, 0}, 90},
+	}
+
+	for testIndex, test := range cases {
+		get := Degrees(test.orig.Angle(test.other))
+		if !closeEq(get, test.want, epsilon) {
+			t.Errorf("TestAngleVec2 %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestMultMat3Vec2(t *testing.T) {
+	var cases = []struct {
+		orig Vec2
+		m    [9]float64
+		want Vec2
+	}{
+		{Vec2{1, 2}, [9]float64{1, 0, 0, 0, 1, 0, 0, 0, 1}, Vec2{1, 2}},
+		{Vec2{1, 2}, [9]float64{1, 2, 0, 3, 4, 0, 5, 6, 1}, Vec2{12, 16}},
+	}
+
+	for testIndex, test := range cases {
+		m := Mat3{}
+		m.Load(test.m)
+		get := test.orig.MultMat3(m)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestMultMat3Vec2 %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestSafeNormalizeVec2(t *testing.T) {
+	fallback := Vec2{1, 0}
+	cases := []struct {
+		v, want Vec2
+	}{
+		{Vec2{3, 4}, Vec2{0.6, 0.8}},
+		// short vectors still have a direction
+		{Vec2{3e-12, 4e-12}, Vec2{0.6, 0.8}},
+		{Vec2{}, fallback},
+		{Vec2{math.NaN(), 1}, fallback},
+		{Vec2{1, math.Inf(1)}, fallback},
+	}
+
+	for testIndex, c := range cases {
+		got := c.v.SafeNormalize(fallback)
+		if !closeEq(got.X, c.want.X, 1e-6) || !closeEq(got.Y, c.want.Y, 1e-6) {
+			t.Errorf("TestSafeNormalizeVec2 %d %v %v", testIndex, got, c.want)
+		}
+		if got.HasNaN() {
+			t.Errorf("TestSafeNormalizeVec2 %d NaN %v", testIndex, got)
+		}
+		c.v.SafeNormalizeIn(fallback)
+		if c.v != got {
+			t.Errorf("TestSafeNormalizeVec2 %d In %v %v", testIndex, c.v, got)
+		}
+	}
+}
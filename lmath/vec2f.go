@@ -0,0 +1,282 @@
+package lmath
+
+import (
+	"math"
+)
+
+// A float32 Vector 2 containing the two components
+// X, Y
+// Has the same semantics as Vec2, use it for data which is uploaded to the GPU
+// or stored in bulk.
+type Vec2f struct {
+	X, Y float32
+}
+
+var (
+	Vec2fRight = Vec2f{1, 0}
+	Vec2fUp    = Vec2f{0, 1}
+	Vec2fZero  = Vec2f{0, 0}
+)
+
+// Returns a new vector which is the result of adding 'this' with the
+// other vector
+func (this Vec2f) Add(other Vec2f) Vec2f {
+	this.AddIn(other)
+	return this
+}
+
+// Adds 'this' with the other vector.
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec2f) AddIn(other Vec2f) *Vec2f {
+	this.X += other.X
+	this.Y += other.Y
+	return this
+}
+
+// Returns a new vector which is the result of subtracting 'this' with the
+// other vector
+func (this Vec2f) Sub(other Vec2f) Vec2f {
+	this.SubIn(other)
+	return this
+}
+
+// Subtracts'this' with the other vector.
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec2f) SubIn(other Vec2f) *Vec2f {
+	this.X -= other.X
+	this.Y -= other.Y
+	return this
+}
+
+// Returns a new vector with the scalar added to every element
+func (this Vec2f) AddScalar(scale float32) Vec2f {
+	this.AddInScalar(scale)
+	return this
+}
+
+// Add the scale to every element in the vector
+// Return this
+func (this *Vec2f) AddInScalar(scale float32) *Vec2f {
+	this.X += scale
+	this.Y += scale
+	return this
+}
+
+// Returns a new vector with the scalar subtracted to every element
+func (this Vec2f) SubScalar(scale float32) Vec2f {
+	this.SubInScalar(scale)
+	return this
+}
+
+// Subtract the scale from every element in the vector
+// Return a pointer to 'this'
+func (this *Vec2f) SubInScalar(scale float32) *Vec2f {
+	this.X -= scale
+	this.Y -= scale
+	return this
+}
+
+// Returns a new vector where every element is multiplied by the scale
+func (this Vec2f) MultScalar(scale float32) Vec2f {
+	this.MultInScalar(scale)
+	return this
+}
+
+// Multiply the each element of this vector with the scale value.
+// Return a pointer to 'this'
+func (this *Vec2f) MultInScalar(scale float32) *Vec2f {
+	this.X *= scale
+	this.Y *= scale
+	return this
+}
+
+// Returns a new vector where every element is division by the scale
+func (this Vec2f) DivScalar(scale float32) Vec2f {
+	this.DivInScalar(scale)
+	return this
+}
+
+// Divide the each element of this vector with the scale value.
+// Return a pointer to 'this'
+func (this *Vec2f) DivInScalar(scale float32) *Vec2f {
+	this.X /= scale
+	this.Y /= scale
+	return this
+}
+
+// Do a pair-wise element multiplication with the provided vector
+// Returns a new vector with the result
+func (this Vec2f) Outer(other Vec2f) Vec2f {
+	this.OuterIn(other)
+	return this
+}
+
+// Do a element-wise multiplication with the provided vector
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec2f) OuterIn(other Vec2f) *Vec2f {
+	this.X = this.X * other.X
+	this.Y = this.Y * other.Y
+	return this
+}
+
+// Returns the Dot product between 'this' and the other vector
+func (this Vec2f) Dot(other Vec2f) float32 {
+	return this.X*other.X + this.Y*other.Y
+}
+
+// Return the length of the vector
+// sqrt(x^2 + y^2)
+func (this Vec2f) Length() float32 {
+	return sqrt32(this.X*this.X + this.Y*this.Y)
+}
+
+// Return the squared length of the vector
+// x^2 + y^2
+func (this Vec2f) LengthSq() float32 {
+	return this.X*this.X + this.Y*this.Y
+}
+
+// Checks for equality between the vectors.
+// Equal is all elements are equal within an epsilon32 ( < 0.00001)
+func (this Vec2f) Eq(other Vec2f) bool {
+	return closeEq32(this.X, other.X, epsilon32) &&
+		closeEq32(this.Y, other.Y, epsilon32)
+}
+
+// Checks for equality between the vectors.
+// Equal is all elements are equal within an user specified e
+func (this Vec2f) CloseEq(other Vec2f, e float32) bool {
+	return closeEq32(this.X, other.X, e) &&
+		closeEq32(this.Y, other.Y, e)
+}
+
+// Equal if all elements are equal within the tolerance
+func (this Vec2f) ApproxEq(other Vec2f, tol Tolerance) bool {
+	return tol.Eq32(this.X, other.X) &&
+		tol.Eq32(this.Y, other.Y)
+}
+
+// Return true if no element is NaN or infinite.
+func (this Vec2f) IsFinite() bool {
+	return isFinite(float64(this.X)) &&
+		isFinite(float64(this.Y))
+}
+
+// Return true if any element is NaN.
+func (this Vec2f) HasNaN() bool {
+	return math.IsNaN(float64(this.X)) ||
+		math.IsNaN(float64(this.Y))
+}
+
+// Return a new vector which is the normalized version of 'this'
+func (this Vec2f) Normalize() Vec2f {
+	this.NormalizeIn()
+	return this
+}
+
+// Normalize the vector
+// Return a pointer to 'this'
+func (this *Vec2f) NormalizeIn() *Vec2f {
+	mag := this.Length()
+	return this.DivInScalar(mag)
+}
+
+// Return the normalized vector, or fallback when the length is zero or not
+// finite so the vector can't be normalized.
+func (this Vec2f) SafeNormalize(fallback Vec2f) Vec2f {
+	this.SafeNormalizeIn(fallback)
+	return this
+}
+
+// Normalize the vector, or set it to fallback when the length is zero or not
+// finite so the vector can't be normalized.
+// Return a pointer to 'this'
+func (this *Vec2f) SafeNormalizeIn(fallback Vec2f) *Vec2f {
+	mag := this.Length()
+	if mag == 0 || !isFinite(float64(mag)) {
+		*this = fallback
+		return this
+	}
+	return this.DivInScalar(mag)
+}
+
+// Set X,Y parameters of the vector.
+func (this *Vec2f) Set(x, y float32) *Vec2f {
+	this.X = x
+	this.Y = y
+	return this
+}
+
+// Make a vector which is the projection of this onto other
+func (this Vec2f) Proj(other Vec2f) Vec2f {
+	n := this.Length() * other.Length()
+	return other.Normalize().MultScalar(this.Dot(other) / n)
+}
+
+// Return a copy of this vector
+func (this Vec2f) Copy() Vec2f {
+	return this
+}
+
+// Retrieve both x,y paramters at once
+func (this Vec2f) Dump() (float32, float32) {
+	return this.X, this.Y
+}
+
+// convert to Vec3f. Third component is set to zero.
+func (this Vec2f) Vec3f() Vec3f {
+	return Vec3f{this.X, this.Y, 0}
+}
+
+// Returns a new vector which is perpendicular to 'this'.
+// The vector is rotated 90 degrees counter-clockwise, [x,y] => [-y,x]
+func (this Vec2f) Perp() Vec2f {
+	this.PerpIn()
+	return this
+}
+
+// Rotate 'this' 90 degrees counter-clockwise, [x,y] => [-y,x]
+// Return a pointer to 'this'
+func (this *Vec2f) PerpIn() *Vec2f {
+	this.X, this.Y = -this.Y, this.X
+	return this
+}
+
+// Returns the 2D cross product 'this' X 'other'.
+// This is the z component of the 3D cross product of the two vectors,
+// which is positive when other is counter-clockwise from 'this'.
+func (this Vec2f) Cross(other Vec2f) float32 {
+	return this.X*other.Y - this.Y*other.X
+}
+
+// Apply the matrix against the Vector, treating the vector as a point [x,y,1]
+// Return a new vector with the result v*m
+func (this Vec2f) MultMat3(right Mat3f) Vec2f {
+	// 0   1   2
+	// 3   4   5
+	// 6   7   8
+	this.Set(
+		this.X*right.At(0)+this.Y*right.At(3)+right.At(6),
+		this.X*right.At(1)+this.Y*right.At(4)+right.At(7),
+	)
+	return this
+}
+
+// Return the signed angle (radians) required to rotate 'this' onto other.
+// Counter-clockwise rotations are positive. The result is in the range [-pi,pi]
+func (this Vec2f) Angle(other Vec2f) float32 {
+	return float32(math.Atan2(float64(this.Cross(other)), float64(this.Dot(other))))
+}
+
+// convert to the float64 Vec2
+func (this Vec2f) Vec2() Vec2 {
+	return Vec2{float64(this.X), float64(this.Y)}
+}
+
+// convert to the float32 Vec2f
+func (this Vec2) Vec2f() Vec2f {
+	return Vec2f{float32(this.X), float32(this.Y)}
+}
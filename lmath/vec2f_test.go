@@ -0,0 +1,426 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqualVec2f(t *testing.T) {
+	var cases = []struct {
+		orig, other Vec2f
+		want        bool
+	}{
+		{Vec2f{0, 0}, Vec2f{1, 2}, false},
+		{Vec2f{1, 2}, Vec2f{0, 0}, false},
+		{Vec2f{1, 2}, Vec2f{-1, -2}, false},
+		{Vec2f{0, 0}, Vec2f{0, 0}, true},
+		{Vec2f{1.0, 2.0}, Vec2f{1.0, 2.0}, true},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Eq(test.other)
+		if get != test.want {
+			t.Errorf("TestEqualVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestCloseEqVec2f(t *testing.T) {
+	var cases = []struct {
+		orig, other Vec2f
+		e           float32
+		want        bool
+	}{
+		{Vec2f{0, 0}, Vec2f{0.05, 0.05}, 0.1, true},
+		{Vec2f{0, 0}, Vec2f{0.05, 0.15}, 0.1, false},
+		{Vec2f{1, 2}, Vec2f{1, 2}, 0.1, true},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.CloseEq(test.other, test.e)
+		if get != test.want {
+			t.Errorf("TestCloseEqVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestAddVec2f(t *testing.T) {
+	var cases = []struct {
+		orig, other, want Vec2f
+	}{
+		{Vec2f{0, 0}, Vec2f{1, 2}, Vec2f{1, 2}},
+		{Vec2f{1, 2}, Vec2f{0, 0}, Vec2f{1, 2}},
+		{Vec2f{1, 2}, Vec2f{-1, -2}, Vec2f{0, 0}},
+		{Vec2f{0, 0}, Vec2f{0, 0}, Vec2f{0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Add(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestAddVec2f %d", testIndex)
+		}
+
+		get2 := test.orig.AddIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestAddVec2f AddIn %d", testIndex)
+		}
+	}
+}
+
+func TestSubVec2f(t *testing.T) {
+	var cases = []struct {
+		orig, other, want Vec2f
+	}{
+		{Vec2f{0, 0}, Vec2f{1, 2}, Vec2f{-1, -2}},
+		{Vec2f{1, 2}, Vec2f{0, 0}, Vec2f{1, 2}},
+		{Vec2f{1, 2}, Vec2f{-1, -2}, Vec2f{2, 4}},
+		{Vec2f{0, 0}, Vec2f{0, 0}, Vec2f{0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Sub(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSubVec2f %d", testIndex)
+		}
+
+		get2 := test.orig.SubIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestSubVec2f SubIn %d", testIndex)
+		}
+	}
+}
+
+func TestAddScalarVec2f(t *testing.T) {
+	cases := []struct {
+		orig, want Vec2f
+		scale      float32
+	}{
+		{Vec2f{0, 0}, Vec2f{1, 1}, 1},
+		{Vec2f{1, 2}, Vec2f{0, 1}, -1},
+		{Vec2f{1, 2}, Vec2f{1, 2}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.AddScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestAddScalarVec2f %d", testIndex)
+		}
+
+		get2 := test.orig.AddInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestAddInScalarVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestSubScalarVec2f(t *testing.T) {
+	cases := []struct {
+		orig, want Vec2f
+		scale      float32
+	}{
+		{Vec2f{0, 0}, Vec2f{-1, -1}, 1},
+		{Vec2f{1, 2}, Vec2f{2, 3}, -1},
+		{Vec2f{1, 2}, Vec2f{1, 2}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.SubScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSubScalarVec2f %d", testIndex)
+		}
+
+		get2 := test.orig.SubInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestSubInScalarVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestMultScalarVec2f(t *testing.T) {
+	cases := []struct {
+		orig, want Vec2f
+		scale      float32
+	}{
+		{Vec2f{0, 0}, Vec2f{0, 0}, 2},
+		{Vec2f{1, 2}, Vec2f{-1, -2}, -1},
+		{Vec2f{1, 2}, Vec2f{0, 0}, 0},
+		{Vec2f{1, 2}, Vec2f{2.5, 5}, 2.5},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.MultScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestMultScalarVec2f %d", testIndex)
+		}
+
+		get2 := test.orig.MultInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestMultInScalarVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestDivScalarVec2f(t *testing.T) {
+	cases := []struct {
+		orig, want Vec2f
+		scale      float32
+	}{
+		{Vec2f{0, 0}, Vec2f{0, 0}, 2},
+		{Vec2f{1, 2}, Vec2f{-1, -2}, -1},
+		{Vec2f{1, 2}, Vec2f{0.5, 1}, 2},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.DivScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestDivScalarVec2f %d", testIndex)
+		}
+
+		get2 := test.orig.DivInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestDivInScalarVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestOuterVec2f(t *testing.T) {
+	var cases = []struct {
+		orig, other, want Vec2f
+	}{
+		{Vec2f{0, 0}, Vec2f{1, 2}, Vec2f{0, 0}},
+		{Vec2f{1, 2}, Vec2f{1, 2}, Vec2f{1, 4}},
+		{Vec2f{1, 2}, Vec2f{-1, -2}, Vec2f{-1, -4}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Outer(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestOuterVec2f %d", testIndex)
+		}
+
+		get2 := test.orig.OuterIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestOuterInVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestDotVec2f(t *testing.T) {
+	var cases = []struct {
+		orig  Vec2f
+		other Vec2f
+		want  float32
+	}{
+		{Vec2f{0, 0}, Vec2f{1, 2}, 0},
+		{Vec2f{1, 2}, Vec2f{0, 0}, 0},
+		{Vec2f{1, 2}, Vec2f{-1, -2}, -5},
+		{Vec2f{1, 2}, Vec2f{1, 2}, 5},
+		{Vec2f{1, 0}, Vec2f{0, 1}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Dot(test.other)
+		if get != test.want {
+			t.Errorf("TestDotVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestLengthVec2f(t *testing.T) {
+	var cases = []struct {
+		orig Vec2f
+		want float32
+	}{
+		{Vec2f{0, 0}, 0},
+		{Vec2f{3, 4}, 5},
+		{Vec2f{1, 0}, 1},
+		{Vec2f{1, 2}, sqrt32(5)},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Length()
+		if !closeEq32(get, test.want, epsilon32) {
+			t.Errorf("TestLengthVec2f %d", testIndex)
+		}
+		if !closeEq32(test.orig.LengthSq(), test.want*test.want, epsilon32) {
+			t.Errorf("TestLengthSqVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestNormalizeVec2f(t *testing.T) {
+	var cases = []struct {
+		orig, want Vec2f
+	}{
+		{Vec2f{3, 4}, Vec2f{0.6, 0.8}},
+		{Vec2f{1, 0}, Vec2f{1, 0}},
+		{Vec2f{0, -2}, Vec2f{0, -1}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Normalize()
+		if get.Eq(test.want) == false {
+			t.Errorf("TestNormalizeVec2f %d", testIndex)
+		}
+
+		get2 := test.orig.NormalizeIn()
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestNormalizeInVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestSetVec2f(t *testing.T) {
+	var cases = []struct {
+		x, y       float32
+		orig, want Vec2f
+	}{
+		{1, 2, Vec2f{0, 0}, Vec2f{1, 2}},
+		{0, 1, Vec2f{1, 2}, Vec2f{0, 1}},
+		{-1, 2, Vec2f{1, -1}, Vec2f{-1, 2}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Set(test.x, test.y)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSetVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestProjVec2f(t *testing.T) {
+	var cases = []struct {
+		from, on, want Vec2f
+	}{
+		{Vec2f{1, 1}, Vec2f{1, 0}, Vec2f{sqrt32(2) / 2, 0}},
+		{Vec2f{1, 0}, Vec2f{1, 0}, Vec2f{1, 0}},
+		{Vec2f{0, 1}, Vec2f{1, 0}, Vec2f{0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.from.Proj(test.on)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestProjVec2f %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestPerpVec2f(t *testing.T) {
+	var cases = []struct {
+		orig, want Vec2f
+	}{
+		{Vec2f{1, 0}, Vec2f{0, 1}},
+		{Vec2f{0, 1}, Vec2f{-1, 0}},
+		{Vec2f{1, 2}, Vec2f{-2, 1}},
+		{Vec2f{0, 0}, Vec2f{0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Perp()
+		if get.Eq(test.want) == false || get.Dot(test.orig) != 0 {
+			t.Errorf("TestPerpVec2f %d", testIndex)
+		}
+
+		get2 := test.orig.PerpIn()
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestPerpInVec2f %d", testIndex)
+		}
+	}
+}
+
+func TestCrossVec2f(t *testing.T) {
+	var cases = []struct {
+		orig, other Vec2f
+		want        float32
+	}{
+		{Vec2f{1, 0}, Vec2f{0, 1}, 1},
+		{Vec2f{0, 1}, Vec2f{1, 0}, -1},
+		{Vec2f{1, 2}, Vec2f{2, 4}, 0},
+		{Vec2f{1, 2}, Vec2f{3, 4}, -2},
+		{Vec2f{0, 0}, Vec2f{3, 4}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Cross(test.other)
+		if get != test.want {
+			t.Errorf("TestCrossVec2f %d %v", testIndex, get)
+		}
+
+		// Must match the z component of the 3D cross product
+		get3 := test.orig.Vec3f().Cross(test.other.Vec3f())
+		if !get3.Eq(Vec3f{0, 0, test.want}) {
+			t.Errorf("TestCrossVec2f Vec3f %d %v", testIndex, get3)
+		}
+	}
+}
+
+func TestAngleVec2f(t *testing.T) {
+	var cases = []struct {
+		orig, other Vec2f
+		want        float32
+	}{
+		{Vec2f{1, 0}, Vec2f{0, 1}, 90},
+		{Vec2f{0, 1}, Vec2f{1, 0}, -90},
+		{Vec2f{1, 0}, Vec2f{1, 0}, 0},
+		{Vec2f{1, 0}, Vec2f{-1, 0}, 180},
+		{Vec2f{1, 0}, Vec2f{1, 1}, 45},
+		{Vec2f{1, 0}, Vec2f{1, -1}, -45},
+		{Vec2f{2, 0}, Vec2f{-3, -3}, -135},
+		{Vec2f{0, -1}, Vec2f{1, 0}, 90},
+	}
+
+	for testIndex, test := range cases {
+		get := float32(Degrees(float64(test.orig.Angle(test.other))))
+		if !closeEq32(get, test.want, epsilon32) {
+			t.Errorf("TestAngleVec2f %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestMultMat3Vec2f(t *testing.T) {
+	var cases = []struct {
+		orig Vec2f
+		m    [9]float32
+		want Vec2f
+	}{
+		{Vec2f{1, 2}, [9]float32{1, 0, 0, 0, 1, 0, 0, 0, 1}, Vec2f{1, 2}},
+		{Vec2f{1, 2}, [9]float32{1, 2, 0, 3, 4, 0, 5, 6, 1}, Vec2f{12, 16}},
+	}
+
+	for testIndex, test := range cases {
+		m := Mat3f{}
+		m.Load(test.m)
+		get := test.orig.MultMat3(m)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestMultMat3Vec2f %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestSafeNormalizeVec2f(t *testing.T) {
+	fallback := Vec2f{1, 0}
+	cases := []struct {
+		v, want Vec2f
+	}{
+		{Vec2f{3, 4}, Vec2f{0.6, 0.8}},
+		// short vectors still have a direction
+		{Vec2f{3e-12, 4e-12}, Vec2f{0.6, 0.8}},
+		{Vec2f{}, fallback},
+		{Vec2f{float32(math.NaN()), 1}, fallback},
+		{Vec2f{1, float32(math.Inf(1))}, fallback},
+	}
+
+	for testIndex, c := range cases {
+		got := c.v.SafeNormalize(fallback)
+		if !closeEq32(got.X, c.want.X, 1e-6) || !closeEq32(got.Y, c.want.Y, 1e-6) {
+			t.Errorf("TestSafeNormalizeVec2f %d %v %v", testIndex, got, c.want)
+		}
+		if got.HasNaN() {
+			t.Errorf("TestSafeNormalizeVec2f %d NaN %v", testIndex, got)
+		}
+		c.v.SafeNormalizeIn(fallback)
+		if c.v != got {
+			t.Errorf("TestSafeNormalizeVec2f %d In %v %v", testIndex, c.v, got)
+		}
+	}
+}
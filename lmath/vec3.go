@@ -164,6 +164,27 @@ func (this Vec3) CloseEq(other Vec3, e float64) bool {
 		closeEq(this.Z, other.Z, e)
 }
 
+// Equal if all elements are equal within the tolerance
+func (this Vec3) ApproxEq(other Vec3, tol Tolerance) bool {
+	return tol.Eq(this.X, other.X) &&
+		tol.Eq(this.Y, other.Y) &&
+		tol.Eq(this.Z, other.Z)
+}
+
+// Return true if no element is NaN or infinite.
+func (this Vec3) IsFinite() bool {
+	return isFinite(this.X) &&
+		isFinite(this.Y) &&
+		isFinite(this.Z)
+}
+
+// Return true if any element is NaN.
+func (this Vec3) HasNaN() bool {
+	return math.IsNaN(this.X) ||
+		math.IsNaN(this.Y) ||
+		math.IsNaN(this.Z)
+}
+
 // Return a new vector which is the normalized version of 'this'
 func (this Vec3) Normalize() Vec3 {
 	this.NormalizeIn()
@@ -177,6 +198,25 @@ func (this *Vec3) NormalizeIn() *Vec3 {
 	return this.DivInScalar(mag)
 }
 
+// Return the normalized vector, or fallback when the length is zero or not
+// finite so the vector can't be normalized.
+func (this Vec3) SafeNormalize(fallback Vec3) Vec3 {
+	this.SafeNormalizeIn(fallback)
+	return this
+}
+
+// Normalize the vector, or set it to fallback when the length is zero or not
+// finite so the vector can't be normalized.
+// Return a pointer to 'this'
+func (this *Vec3) SafeNormalizeIn(fallback Vec3) *Vec3 {
+	mag := this.Length()
+	if mag == 0 || !isFinite(mag) {
+		*this = fallback
+		return this
+	}
+	return this.DivInScalar(mag)
+}
+
 // Set X,Y,Z parameters of the vector.
 func (this *Vec3) Set(x, y, z float64) *Vec3 {
 	this.X = x
@@ -212,6 +252,11 @@ func (this Vec3) Vec4() Vec4{
 	return Vec4{this.X,this.Y,this.Z,0}
 }
 
+// convert to Vec2. Third component is dropped
+func (this Vec3) Vec2() Vec2 {
+	return Vec2{this.X, this.Y}
+}
+
 //==============================================================================
 // Vector 3 specific methods
 
@@ -329,3 +329,31 @@ func TestProjVec3(t *testing.T) {
 		}
 	}
 }
+
+func TestSafeNormalizeVec3(t *testing.T) {
+	fallback := Vec3{1, 0, 0}
+	cases := []struct {
+		v, want Vec3
+	}{
+		{Vec3{3, 4, 0}, Vec3{0.6, 0.8, 0}},
+		// short vectors still have a direction
+		{Vec3{3e-12, 4e-12, 0}, Vec3{0.6, 0.8, 0}},
+		{Vec3{}, fallback},
+		{Vec3{math.NaN(), 1, 1}, fallback},
+		{Vec3{1, math.Inf(1), 1}, fallback},
+	}
+
+	for testIndex, c := range cases {
+		got := c.v.SafeNormalize(fallback)
+		if !closeEq(got.X, c.want.X, 1e-6) || !closeEq(got.Y, c.want.Y, 1e-6) || !closeEq(got.Z, c.want.Z, 1e-6) {
+			t.Errorf("TestSafeNormalizeVec3 %d %v %v", testIndex, got, c.want)
+		}
+		if got.HasNaN() {
+			t.Errorf("TestSafeNormalizeVec3 %d NaN %v", testIndex, got)
+		}
+		c.v.SafeNormalizeIn(fallback)
+		if c.v != got {
+			t.Errorf("TestSafeNormalizeVec3 %d In %v %v", testIndex, c.v, got)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package lmath
+
+import (
+	"math"
+)
+
+// A float32 Vector 3 containing the three components
+// X, Y, Z
+// Has the same semantics as Vec3, use it for data which is uploaded to the GPU
+// or stored in bulk.
+type Vec3f struct {
+	X, Y, Z float32
+}
+
+var (
+	Vec3fRight   = Vec3f{1, 0, 0}
+	Vec3fUp      = Vec3f{0, 1, 0}
+	Vec3fForward = Vec3f{0, 0, 1}
+	Vec3fZero    = Vec3f{0, 0, 0}
+)
+
+// Returns a new vector which is the result of adding 'this' with the
+// other vector
+func (this Vec3f) Add(other Vec3f) Vec3f {
+	this.AddIn(other)
+	return this
+}
+
+// Adds 'this' with the other vector.
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec3f) AddIn(other Vec3f) *Vec3f {
+	this.X += other.X
+	this.Y += other.Y
+	this.Z += other.Z
+	return this
+}
+
+// Returns a new vector which is the result of subtracting 'this' with the
+// other vector
+func (this Vec3f) Sub(other Vec3f) Vec3f {
+	this.SubIn(other)
+	return this
+}
+
+// Subtracts'this' with the other vector.
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec3f) SubIn(other Vec3f) *Vec3f {
+	this.X -= other.X
+	this.Y -= other.Y
+	this.Z -= other.Z
+	return this
+}
+
+// Returns a new vector with the scalar added to every element
+func (this Vec3f) AddScalar(scale float32) Vec3f {
+	this.AddInScalar(scale)
+	return this
+}
+
+// Add the scale to every element in the vector
+// Return this
+func (this *Vec3f) AddInScalar(scale float32) *Vec3f {
+	this.X += scale
+	this.Y += scale
+	this.Z += scale
+	return this
+}
+
+// Returns a new vector with the scalar subtracted to every element
+func (this Vec3f) SubScalar(scale float32) Vec3f {
+	this.SubInScalar(scale)
+	return this
+}
+
+// Subtract the scale from every element in the vector
+// Return a pointer to 'this'
+func (this *Vec3f) SubInScalar(scale float32) *Vec3f {
+	this.X -= scale
+	this.Y -= scale
+	this.Z -= scale
+	return this
+}
+
+// Returns a new vector where every element is multiplied by the scale
+func (this Vec3f) MultScalar(scale float32) Vec3f {
+	this.MultInScalar(scale)
+	return this
+}
+
+// Multiply the each element of this vector with the scale value.
+// Return a pointer to 'this'
+func (this *Vec3f) MultInScalar(scale float32) *Vec3f {
+	this.X *= scale
+	this.Y *= scale
+	this.Z *= scale
+	return this
+}
+
+// Returns a new vector where every element is division by the scale
+func (this Vec3f) DivScalar(scale float32) Vec3f {
+	this.DivInScalar(scale)
+	return this
+}
+
+// Divide the each element of this vector with the scale value.
+// Return a pointer to 'this'
+func (this *Vec3f) DivInScalar(scale float32) *Vec3f {
+	this.X /= scale
+	this.Y /= scale
+	this.Z /= scale
+	return this
+}
+
+// Do a pair-wise element multiplication with the provided vector
+// Returns a new vector with the result
+func (this Vec3f) Outer(other Vec3f) Vec3f {
+	this.OuterIn(other)
+	return this
+}
+
+// Do a element-wise multiplication with the provided vector
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec3f) OuterIn(other Vec3f) *Vec3f {
+	this.X = this.X * other.X
+	this.Y = this.Y * other.Y
+	this.Z = this.Z * other.Z
+	return this
+}
+
+// Returns the Dot product between 'this' and the other vector
+func (this Vec3f) Dot(other Vec3f) float32 {
+	return this.X*other.X +
+		this.Y*other.Y +
+		this.Z*other.Z
+}
+
+// Return the length of the vector
+// sqrt(x^2 + y^2 + z^2)
+func (this Vec3f) Length() float32 {
+	return sqrt32(this.X*this.X + this.Y*this.Y + this.Z*this.Z)
+}
+
+// Return the squared length of the vector
+// x^2 + y^2 + z^2
+func (this Vec3f) LengthSq() float32 {
+	return this.X*this.X + this.Y*this.Y + this.Z*this.Z
+}
+
+// Checks for equality between the vectors.
+// Equal is all elements are equal within an epsilon32 ( < 0.00001)
+func (this Vec3f) Eq(other Vec3f) bool {
+	return closeEq32(this.X, other.X, epsilon32) &&
+		closeEq32(this.Y, other.Y, epsilon32) &&
+		closeEq32(this.Z, other.Z, epsilon32)
+}
+
+// Checks for equality between the vectors.
+// Equal is all elements are equal within an user specified e
+func (this Vec3f) CloseEq(other Vec3f, e float32) bool {
+	return closeEq32(this.X, other.X, e) &&
+		closeEq32(this.Y, other.Y, e) &&
+		closeEq32(this.Z, other.Z, e)
+}
+
+// Equal if all elements are equal within the tolerance
+func (this Vec3f) ApproxEq(other Vec3f, tol Tolerance) bool {
+	return tol.Eq32(this.X, other.X) &&
+		tol.Eq32(this.Y, other.Y) &&
+		tol.Eq32(this.Z, other.Z)
+}
+
+// Return true if no element is NaN or infinite.
+func (this Vec3f) IsFinite() bool {
+	return isFinite(float64(this.X)) &&
+		isFinite(float64(this.Y)) &&
+		isFinite(float64(this.Z))
+}
+
+// Return true if any element is NaN.
+func (this Vec3f) HasNaN() bool {
+	return math.IsNaN(float64(this.X)) ||
+		math.IsNaN(float64(this.Y)) ||
+		math.IsNaN(float64(this.Z))
+}
+
+// Return a new vector which is the normalized version of 'this'
+func (this Vec3f) Normalize() Vec3f {
+	this.NormalizeIn()
+	return this
+}
+
+// Normalize the vector
+// Return a pointer to 'this'
+func (this *Vec3f) NormalizeIn() *Vec3f {
+	mag := this.Length()
+	return this.DivInScalar(mag)
+}
+
+// Return the normalized vector, or fallback when the length is zero or not
+// finite so the vector can't be normalized.
+func (this Vec3f) SafeNormalize(fallback Vec3f) Vec3f {
+	this.SafeNormalizeIn(fallback)
+	return this
+}
+
+// Normalize the vector, or set it to fallback when the length is zero or not
+// finite so the vector can't be normalized.
+// Return a pointer to 'this'
+func (this *Vec3f) SafeNormalizeIn(fallback Vec3f) *Vec3f {
+	mag := this.Length()
+	if mag == 0 || !isFinite(float64(mag)) {
+		*this = fallback
+		return this
+	}
+	return this.DivInScalar(mag)
+}
+
+// Set X,Y,Z parameters of the vector.
+func (this *Vec3f) Set(x, y, z float32) *Vec3f {
+	this.X = x
+	this.Y = y
+	this.Z = z
+	return this
+}
+
+// Make a vector which is the projection of this onto other
+func (this Vec3f) Proj(other Vec3f) Vec3f {
+	n := this.Length() * other.Length()
+	return other.Normalize().MultScalar(this.Dot(other) / n)
+}
+
+// Return a copy of this vector
+func (this Vec3f) Copy() Vec3f {
+	return this
+}
+
+// Retrieve all three x,y,z paramters at once
+func (this Vec3f) Dump() (float32, float32, float32) {
+	return this.X, this.Y, this.Z
+}
+
+// convert to Vec4f. Forth component is set to zero.
+func (this Vec3f) Vec4f() Vec4f {
+	return Vec4f{this.X, this.Y, this.Z, 0}
+}
+
+// convert to Vec2f. Third component is dropped
+func (this Vec3f) Vec2f() Vec2f {
+	return Vec2f{this.X, this.Y}
+}
+
+// Returns a new vector which is the Cross product with 'this' X 'other'
+func (this Vec3f) Cross(other Vec3f) Vec3f {
+	this.CrossIn(other)
+	return this
+}
+
+// Take the cross product between 'this' X 'other'
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec3f) CrossIn(other Vec3f) *Vec3f {
+	x := (this.Y*other.Z - other.Y*this.Z)
+	y := -(this.X*other.Z - other.X*this.Z)
+	z := (this.X*other.Y - other.X*this.Y)
+	this.X, this.Y, this.Z = x, y, z
+	return this
+}
+
+// Apply the matrix against the Vector
+// Return a new vector with the result v*m
+func (this Vec3f) MultMat4(right Mat4f) Vec3f {
+	// 0   1   2   3
+	// 4   5   6   7
+	// 8   9   10  11
+	// 12  13  14  15
+	this.Set(
+		this.X*right.At(0)+this.Y*right.At(4)+this.Z*right.At(8)+right.At(12),
+		this.X*right.At(1)+this.Y*right.At(5)+this.Z*right.At(9)+right.At(13),
+		this.X*right.At(2)+this.Y*right.At(6)+this.Z*right.At(10)+right.At(14),
+	)
+	return this
+}
+
+// convert to the float64 Vec3
+func (this Vec3f) Vec3() Vec3 {
+	return Vec3{float64(this.X), float64(this.Y), float64(this.Z)}
+}
+
+// convert to the float32 Vec3f
+func (this Vec3) Vec3f() Vec3f {
+	return Vec3f{float32(this.X), float32(this.Y), float32(this.Z)}
+}
@@ -0,0 +1,374 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqualVec3f(t *testing.T) {
+	var cases = []struct {
+		orig, other Vec3f
+		want        bool
+	}{
+		{Vec3f{0, 0, 0}, Vec3f{1, 2, 3}, false},
+		{Vec3f{1, 2, 3}, Vec3f{0, 0, 0}, false},
+		{Vec3f{1, 2, 3}, Vec3f{-1, -2, -3}, false},
+		{Vec3f{0, 0, 0}, Vec3f{0, 0, 0}, true},
+		{Vec3f{1.0, 2.0, 3.0}, Vec3f{1.0, 2.0, 3.0}, true},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Eq(test.other)
+		if get != test.want {
+			t.Errorf("TestEqualVec3f %d", testIndex)
+		}
+	}
+}
+
+func TestAddVec3f(t *testing.T) {
+
+	var cases = []struct {
+		orig, other, want Vec3f
+	}{
+		{Vec3f{0, 0, 0}, Vec3f{1, 2, 3}, Vec3f{1, 2, 3}},
+		{Vec3f{1, 2, 3}, Vec3f{0, 0, 0}, Vec3f{1, 2, 3}},
+		{Vec3f{1, 2, 3}, Vec3f{-1, -2, -3}, Vec3f{0, 0, 0}},
+		{Vec3f{0, 0, 0}, Vec3f{0, 0, 0}, Vec3f{0, 0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Add(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestAddVec3f %d", testIndex)
+		}
+
+		get2 := test.orig.AddIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestAddVec3f AddIn %d", testIndex)
+		}
+	}
+}
+
+func TestSubVec3f(t *testing.T) {
+
+	var cases = []struct {
+		orig, other, want Vec3f
+	}{
+		{Vec3f{0, 0, 0}, Vec3f{1, 2, 3}, Vec3f{-1, -2, -3}},
+		{Vec3f{1, 2, 3}, Vec3f{0, 0, 0}, Vec3f{1, 2, 3}},
+		{Vec3f{1, 2, 3}, Vec3f{-1, -2, -3}, Vec3f{2, 4, 6}},
+		{Vec3f{0, 0, 0}, Vec3f{0, 0, 0}, Vec3f{0, 0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Sub(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSubVec3f %d", testIndex)
+		}
+
+		get2 := test.orig.SubIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestSubVec3f SubIn %d", testIndex)
+		}
+	}
+}
+
+func TestAddScalarVec3f(t *testing.T) {
+	cases := []struct {
+		orig, want Vec3f
+		scale      float32
+	}{
+		{Vec3f{0, 0, 0}, Vec3f{0, 0, 0}, 0},
+		{Vec3f{0, 0, 0}, Vec3f{1, 1, 1}, 1},
+		{Vec3f{0, 0, 0}, Vec3f{-1, -1, -1}, -1},
+		{Vec3f{1, 2, 3}, Vec3f{5, 6, 7}, 4},
+		{Vec3f{1, 2, 3}, Vec3f{-3, -2, -1}, -4},
+		{Vec3f{1, -2, 3}, Vec3f{5, 2, 7}, 4},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.AddScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestAddScalarVec3f %d", testIndex)
+		}
+
+		get2 := test.orig.AddInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestAddInScalarVec3f %d", testIndex)
+		}
+	}
+}
+
+func TestSubScalarVec3f(t *testing.T) {
+	cases := []struct {
+		orig, want Vec3f
+		scale      float32
+	}{
+		{Vec3f{0, 0, 0}, Vec3f{0, 0, 0}, 0},
+		{Vec3f{0, 0, 0}, Vec3f{-1, -1, -1}, 1},
+		{Vec3f{0, 0, 0}, Vec3f{1, 1, 1}, -1},
+		{Vec3f{1, 2, 3}, Vec3f{-3, -2, -1}, 4},
+		{Vec3f{1, 2, 3}, Vec3f{5, 6, 7}, -4},
+		{Vec3f{1, -2, 3}, Vec3f{-3, -6, -1}, 4},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.SubScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSubScalarVec3f %d", testIndex)
+		}
+
+		get2 := test.orig.SubInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestSubInScalarVec3f %d", testIndex)
+		}
+	}
+}
+
+func TestMultScalarVec3f(t *testing.T) {
+	var cases = []struct {
+		orig  Vec3f
+		scale float32
+		want  Vec3f
+	}{
+		{Vec3f{0, 0, 0}, 2.0, Vec3f{0, 0, 0}},
+		{Vec3f{0, 0, 0}, -2, Vec3f{0, 0, 0}},
+		{Vec3f{1, 2, 3}, 2, Vec3f{2, 4, 6}},
+		{Vec3f{1, 2, 3}, 0.5, Vec3f{0.5, 1, 1.5}},
+		{Vec3f{1, 2, 3}, -1, Vec3f{-1, -2, -3}},
+		{Vec3f{1, 2, 3}, -0.5, Vec3f{-0.5, -1, -1.5}},
+		{Vec3f{1, 2, 3}, 0, Vec3f{0, 0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.MultScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestMultVec3f %d", testIndex)
+		}
+
+		get2 := test.orig.MultInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestMultVec3f MultIn %d", testIndex)
+		}
+	}
+}
+
+func TestDivScalarVec3f(t *testing.T) {
+	var cases = []struct {
+		orig  Vec3f
+		scale float32
+		want  Vec3f
+	}{
+		{Vec3f{0, 0, 0}, 2.0, Vec3f{0, 0, 0}},
+		{Vec3f{0, 0, 0}, -2.0, Vec3f{0, 0, 0}},
+		{Vec3f{1, 2, 3}, 2, Vec3f{0.5, 1, 1.5}},
+		{Vec3f{1, 2, 3}, 0.5, Vec3f{2, 4, 6}},
+		{Vec3f{1, 2, 3}, -1, Vec3f{-1, -2, -3}},
+		{Vec3f{1, 2, 3}, -0.5, Vec3f{-2, -4, -6}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.DivScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestDivVec3f %d", testIndex)
+		}
+
+		get2 := test.orig.DivInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestDivInVec3f %d", testIndex)
+		}
+	}
+}
+
+func TestOuterVec3f(t *testing.T) {
+	cases := []struct {
+		orig, other, want Vec3f
+	}{
+		{Vec3f{0, 0, 0}, Vec3f{1, 2, 3}, Vec3f{0, 0, 0}},
+		{Vec3f{1, 2, 3}, Vec3f{0, 0, 0}, Vec3f{0, 0, 0}},
+		{Vec3f{1, 2, 3}, Vec3f{-1, -2, -3}, Vec3f{-1, -4, -9}},
+		{Vec3f{1, 2, 3}, Vec3f{1, 2, 3}, Vec3f{1, 4, 9}},
+		{Vec3f{0, 0, 0}, Vec3f{0, 0, 0}, Vec3f{0, 0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Outer(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestOuterVec3f %d", testIndex)
+		}
+
+		get2 := test.orig.OuterIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestOuterInVec3f %d", testIndex)
+		}
+	}
+}
+
+func TestDotVec3f(t *testing.T) {
+	var cases = []struct {
+		orig  Vec3f
+		other Vec3f
+		want  float32
+	}{
+		{Vec3f{0, 0, 0}, Vec3f{1, 2, 3}, 0},
+		{Vec3f{1, 2, 3}, Vec3f{0, 0, 0}, 0},
+		{Vec3f{1, 2, 3}, Vec3f{-1, -2, -3}, -14},
+		{Vec3f{1, 2, 3}, Vec3f{1, 2, 3}, 14},
+		{Vec3f{0, 0, 0}, Vec3f{0, 0, 0}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Dot(test.other)
+		if get != test.want {
+			t.Errorf("TestDotVec3f %d", testIndex)
+		}
+	}
+}
+
+func TestCrossVec3f(t *testing.T) {
+	var cases = []struct {
+		orig, other, want Vec3f
+	}{
+		{Vec3f{0, 0, 0}, Vec3f{1, 2, 3}, Vec3f{0, 0, 0}},
+		{Vec3f{1, 2, 3}, Vec3f{0, 0, 0}, Vec3f{0, 0, 0}},
+		{Vec3f{1, 0, 0}, Vec3f{0, 1, 0}, Vec3f{0, 0, 1}},
+		{Vec3f{1, 2, 3}, Vec3f{-1, -2, -3}, Vec3f{0, 0, 0}},
+		{Vec3f{sqrt32(2), sqrt32(2), 0}, Vec3f{0, 0, -1}, Vec3f{-sqrt32(2), sqrt32(2), 0}},
+		{Vec3f{0, 0, -1}, Vec3f{sqrt32(2), sqrt32(2), 0}, Vec3f{sqrt32(2), -sqrt32(2), 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Cross(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestCrossVec3f %d", testIndex)
+		}
+
+		get2 := test.orig.CrossIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestCrossVec3f %d", testIndex)
+		}
+	}
+}
+
+func TestLengthVec3f(t *testing.T) {
+	var cases = []struct {
+		orig Vec3f
+		want float32
+	}{
+		{Vec3f{0, 0, 0}, 0},
+		{Vec3f{1, 2, 3}, sqrt32(14)},
+		{Vec3f{1, 0, 0}, 1},
+		{Vec3f{1 / sqrt32(14), 2 / sqrt32(14), 3 / sqrt32(14)}, 1},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Length()
+		if !closeEq32(get, test.want, epsilon32) {
+			t.Errorf("TestLengthVec3f %d", testIndex)
+		}
+	}
+}
+
+func TestNormalizeVec3f(t *testing.T) {
+	var cases = []struct {
+		orig, want Vec3f
+	}{
+		{Vec3f{1, 2, 3}, Vec3f{1 / sqrt32(14), 2 / sqrt32(14), 3 / sqrt32(14)}},
+		{Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		{Vec3f{1 / sqrt32(14), 2 / sqrt32(14), 3 / sqrt32(14)}, Vec3f{1 / sqrt32(14), 2 / sqrt32(14), 3 / sqrt32(14)}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Normalize()
+		if get.Eq(test.want) == false {
+			t.Errorf("TestNormalizeVec3f %d", testIndex)
+		}
+
+		get2 := test.orig.NormalizeIn()
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestNormalizeInVec3f %d", testIndex)
+		}
+	}
+}
+
+func TestSetVec3f(t *testing.T) {
+	var cases = []struct {
+		x, y, z    float32
+		orig, want Vec3f
+	}{
+		{1, 2, 3, Vec3f{0, 0, 0}, Vec3f{1, 2, 3}},
+		{0, 0, 1, Vec3f{0, 0, 0}, Vec3f{0, 0, 1}},
+		{0, 0, 1, Vec3f{1, 2, 3}, Vec3f{0, 0, 1}},
+		{-1, 2, 4, Vec3f{1, -1, 3}, Vec3f{-1, 2, 4}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Set(test.x, test.y, test.z)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSetVec3f %d", testIndex)
+		}
+	}
+}
+
+func TestProjVec3f(t *testing.T) {
+	v := 0.781430525 / sqrt32(3)
+	var cases = []struct {
+		from, on, want Vec3f
+	}{
+		{Vec3f{1, 1, 0}, Vec3f{1, 0, 0}, Vec3f{sqrt32(2) / 2, 0, 0}},
+		{Vec3f{1, 0, 0}, Vec3f{1, 0, 0}, Vec3f{1, 0, 0}},
+		// should probably be checkig [0,0,0] projected [1,0,0] => {NaN,NaN,NaN}
+		{Vec3f{20, 50, 3}, Vec3f{1, 1, 1}, Vec3f{v, v, v}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.from.Proj(test.on)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestProjVec3f %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestConvertVec3f(t *testing.T) {
+	cases := []Vec3{{0, 0, 0}, {1, 2, 3}, {-0.5, 1e3, 0.125}}
+	for testIndex, c := range cases {
+		get := c.Vec3f().Vec3()
+		if !get.CloseEq(c, 1e-6) {
+			t.Errorf("TestConvertVec3f %d %v %v", testIndex, get, c)
+		}
+	}
+	if get := (Vec2{1, 2}).Vec2f().Vec2(); !get.Eq(Vec2{1, 2}) {
+		t.Errorf("TestConvertVec3f vec2 %v", get)
+	}
+	if get := (Vec4{1, 2, 3, 4}).Vec4f().Vec4(); !get.Eq(Vec4{1, 2, 3, 4}) {
+		t.Errorf("TestConvertVec3f vec4 %v", get)
+	}
+}
+
+func TestSafeNormalizeVec3f(t *testing.T) {
+	fallback := Vec3f{1, 0, 0}
+	cases := []struct {
+		v, want Vec3f
+	}{
+		{Vec3f{3, 4, 0}, Vec3f{0.6, 0.8, 0}},
+		// short vectors still have a direction
+		{Vec3f{3e-12, 4e-12, 0}, Vec3f{0.6, 0.8, 0}},
+		{Vec3f{}, fallback},
+		{Vec3f{float32(math.NaN()), 1, 1}, fallback},
+		{Vec3f{1, float32(math.Inf(1)), 1}, fallback},
+	}
+
+	for testIndex, c := range cases {
+		got := c.v.SafeNormalize(fallback)
+		if !closeEq32(got.X, c.want.X, 1e-6) || !closeEq32(got.Y, c.want.Y, 1e-6) || !closeEq32(got.Z, c.want.Z, 1e-6) {
+			t.Errorf("TestSafeNormalizeVec3f %d %v %v", testIndex, got, c.want)
+		}
+		if got.HasNaN() {
+			t.Errorf("TestSafeNormalizeVec3f %d NaN %v", testIndex, got)
+		}
+		c.v.SafeNormalizeIn(fallback)
+		if c.v != got {
+			t.Errorf("TestSafeNormalizeVec3f %d In %v %v", testIndex, c.v, got)
+		}
+	}
+}
@@ -177,6 +177,30 @@ func (this Vec4) CloseEq(other Vec4, e float64) bool {
 		closeEq(this.W, other.W, e)
 }
 
+// Equal if all elements are equal within the tolerance
+func (this Vec4) ApproxEq(other Vec4, tol Tolerance) bool {
+	return tol.Eq(this.X, other.X) &&
+		tol.Eq(this.Y, other.Y) &&
+		tol.Eq(this.Z, other.Z) &&
+		tol.Eq(this.W, other.W)
+}
+
+// Return true if no element is NaN or infinite.
+func (this Vec4) IsFinite() bool {
+	return isFinite(this.X) &&
+		isFinite(this.Y) &&
+		isFinite(this.Z) &&
+		isFinite(this.W)
+}
+
+// Return true if any element is NaN.
+func (this Vec4) HasNaN() bool {
+	return math.IsNaN(this.X) ||
+		math.IsNaN(this.Y) ||
+		math.IsNaN(this.Z) ||
+		math.IsNaN(this.W)
+}
+
 // Return a new vector which is the normalized version of 'this'
 func (this Vec4) Normalize() Vec4 {
 	this.NormalizeIn()
@@ -190,6 +214,25 @@ func (this *Vec4) NormalizeIn() *Vec4 {
 	return this.DivInScalar(mag)
 }
 
+// Return the normalized vector, or fallback when the length is zero or not
+// finite so the vector can't be normalized.
+func (this Vec4) SafeNormalize(fallback Vec4) Vec4 {
+	this.SafeNormalizeIn(fallback)
+	return this
+}
+
+// Normalize the vector, or set it to fallback when the length is zero or not
+// finite so the vector can't be normalized.
+// Return a pointer to 'this'
+func (this *Vec4) SafeNormalizeIn(fallback Vec4) *Vec4 {
+	mag := this.Length()
+	if mag == 0 || !isFinite(mag) {
+		*this = fallback
+		return this
+	}
+	return this.DivInScalar(mag)
+}
+
 // Set X,Y,Z,W parameters of the vector.
 func (this *Vec4) Set(x, y, z, w float64) *Vec4 {
 	this.X = x
@@ -305,3 +305,31 @@ func TestProjVec4(t *testing.T) {
 		}
 	}
 }
+
+func TestSafeNormalizeVec4(t *testing.T) {
+	fallback := Vec4{1, 0, 0, 0}
+	cases := []struct {
+		v, want Vec4
+	}{
+		{Vec4{3, 4, 0, 0}, Vec4{0.6, 0.8, 0, 0}},
+		// short vectors still have a direction
+		{Vec4{3e-12, 4e-12, 0, 0}, Vec4{0.6, 0.8, 0, 0}},
+		{Vec4{}, fallback},
+		{Vec4{math.NaN(), 1, 1, 1}, fallback},
+		{Vec4{1, math.Inf(1), 1, 1}, fallback},
+	}
+
+	for testIndex, c := range cases {
+		got := c.v.SafeNormalize(fallback)
+		if !closeEq(got.X, c.want.X, 1e-6) || !closeEq(got.Y, c.want.Y, 1e-6) || !closeEq(got.Z, c.want.Z, 1e-6) || !closeEq(got.W, c.want.W, 1e-6) {
+			t.Errorf("TestSafeNormalizeVec4 %d %v %v", testIndex, got, c.want)
+		}
+		if got.HasNaN() {
+			t.Errorf("TestSafeNormalizeVec4 %d NaN %v", testIndex, got)
+		}
+		c.v.SafeNormalizeIn(fallback)
+		if c.v != got {
+			t.Errorf("TestSafeNormalizeVec4 %d In %v %v", testIndex, c.v, got)
+		}
+	}
+}
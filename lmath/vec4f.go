@@ -0,0 +1,291 @@
+package lmath
+
+import (
+	"math"
+)
+
+// A float32 Vector 4 containing the four components
+// X, Y, Z, W
+// Has the same semantics as Vec4, use it for data which is uploaded to the GPU
+// or stored in bulk.
+type Vec4f struct {
+	X, Y, Z, W float32
+}
+
+var (
+	Vec4fRight   = Vec4f{1, 0, 0, 1}
+	Vec4fUp      = Vec4f{0, 1, 0, 1}
+	Vec4fForward = Vec4f{0, 0, 1, 1}
+	Vec4fZero    = Vec4f{0, 0, 0, 0}
+)
+
+// Returns a new vector which is the result of adding 'this' with the
+// other vector
+func (this Vec4f) Add(other Vec4f) (out Vec4f) {
+	this.AddIn(other)
+	return this
+	// out = this
+	// out.AddIn(other)
+	// return
+}
+
+// Adds 'this' with the other vector.
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec4f) AddIn(other Vec4f) *Vec4f {
+	this.X += other.X
+	this.Y += other.Y
+	this.Z += other.Z
+	this.W += other.W
+	return this
+}
+
+// Returns a new vector which is the result of subtracting 'this' with the
+// other vector
+func (this Vec4f) Sub(other Vec4f) Vec4f {
+	this.SubIn(other)
+	return this
+}
+
+// Subtracts'this' with the other vector.
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec4f) SubIn(other Vec4f) *Vec4f {
+	this.X -= other.X
+	this.Y -= other.Y
+	this.Z -= other.Z
+	this.W -= other.W
+	return this
+}
+
+// Returns a new vector with the scalar added to every element
+func (this Vec4f) AddScalar(scale float32) Vec4f {
+	this.AddInScalar(scale)
+	return this
+}
+
+// Add the scale to every element in the vector
+// Return this
+func (this *Vec4f) AddInScalar(scale float32) *Vec4f {
+	this.X += scale
+	this.Y += scale
+	this.Z += scale
+	this.W += scale
+	return this
+}
+
+// Returns a new vector with the scalar subtracted to every element
+func (this Vec4f) SubScalar(scale float32) Vec4f {
+	this.SubInScalar(scale)
+	return this
+}
+
+// Subtract the scale from every element in the vector
+// Return a pointer to 'this'
+func (this *Vec4f) SubInScalar(scale float32) *Vec4f {
+	this.X -= scale
+	this.Y -= scale
+	this.Z -= scale
+	this.W -= scale
+	return this
+}
+
+// Returns a new vector where every element is multiplied by the scale
+func (this Vec4f) MultScalar(scale float32) Vec4f {
+	this.MultInScalar(scale)
+	return this
+}
+
+// Multiply the each element of this vector with the scale value.
+// Return a pointer to 'this'
+func (this *Vec4f) MultInScalar(scale float32) *Vec4f {
+	this.X *= scale
+	this.Y *= scale
+	this.Z *= scale
+	this.W *= scale
+	return this
+}
+
+// Returns a new vector where every element is division by the scale
+func (this Vec4f) DivScalar(scale float32) Vec4f {
+	this.DivInScalar(scale)
+	return this
+}
+
+// Divide the each element of this vector with the scale value.
+// Return a pointer to 'this'
+func (this *Vec4f) DivInScalar(scale float32) *Vec4f {
+	this.X /= scale
+	this.Y /= scale
+	this.Z /= scale
+	this.W /= scale
+	return this
+}
+
+// Do a pair-wise element multiplication with the provided vector
+// Returns a new vector with the result
+func (this Vec4f) Outer(other Vec4f) Vec4f {
+	this.OuterIn(other)
+	return this
+}
+
+// Do a element-wise multiplication with the provided vector
+// Store the result into 'this'
+// Return a pointer to 'this'
+func (this *Vec4f) OuterIn(other Vec4f) *Vec4f {
+	this.X = this.X * other.X
+	this.Y = this.Y * other.Y
+	this.Z = this.Z * other.Z
+	this.W = this.W * other.W
+	return this
+}
+
+// Returns the Dot product between 'this' and the other vector
+func (this Vec4f) Dot(other Vec4f) float32 {
+	return this.X*other.X +
+		this.Y*other.Y +
+		this.Z*other.Z +
+		this.W*other.W
+}
+
+// Return the length of the vector
+// sqrt(x^2 + y^2 + z^2)
+func (this Vec4f) Length() float32 {
+	return sqrt32(this.X*this.X + this.Y*this.Y + this.Z*this.Z + this.W*this.W)
+}
+
+// Return the squared length of the vector
+// x^2 + y^2 + z^2
+func (this Vec4f) LengthSq() float32 {
+	return this.X*this.X + this.Y*this.Y + this.Z*this.Z + this.W*this.W
+}
+
+// Checks for equality between the vectors.
+// Equal is all elements are equal within an epsilon32 ( < 0.00001)
+func (this Vec4f) Eq(other Vec4f) bool {
+	return closeEq32(this.X, other.X, epsilon32) &&
+		closeEq32(this.Y, other.Y, epsilon32) &&
+		closeEq32(this.Z, other.Z, epsilon32) &&
+		closeEq32(this.W, other.W, epsilon32)
+}
+
+// Checks for equality between the vectors.
+// Equal is all elements are equal within an user specified e value
+func (this Vec4f) CloseEq(other Vec4f, e float32) bool {
+	return closeEq32(this.X, other.X, e) &&
+		closeEq32(this.Y, other.Y, e) &&
+		closeEq32(this.Z, other.Z, e) &&
+		closeEq32(this.W, other.W, e)
+}
+
+// Equal if all elements are equal within the tolerance
+func (this Vec4f) ApproxEq(other Vec4f, tol Tolerance) bool {
+	return tol.Eq32(this.X, other.X) &&
+		tol.Eq32(this.Y, other.Y) &&
+		tol.Eq32(this.Z, other.Z) &&
+		tol.Eq32(this.W, other.W)
+}
+
+// Return true if no element is NaN or infinite.
+func (this Vec4f) IsFinite() bool {
+	return isFinite(float64(this.X)) &&
+		isFinite(float64(this.Y)) &&
+		isFinite(float64(this.Z)) &&
+		isFinite(float64(this.W))
+}
+
+// Return true if any element is NaN.
+func (this Vec4f) HasNaN() bool {
+	return math.IsNaN(float64(this.X)) ||
+		math.IsNaN(float64(this.Y)) ||
+		math.IsNaN(float64(this.Z)) ||
+		math.IsNaN(float64(this.W))
+}
+
+// Return a new vector which is the normalized version of 'this'
+func (this Vec4f) Normalize() Vec4f {
+	this.NormalizeIn()
+	return this
+}
+
+// Normalize the vector
+// Return a pointer to 'this'
+func (this *Vec4f) NormalizeIn() *Vec4f {
+	mag := this.Length()
+	return this.DivInScalar(mag)
+}
+
+// Return the normalized vector, or fallback when the length is zero or not
+// finite so the vector can't be normalized.
+func (this Vec4f) SafeNormalize(fallback Vec4f) Vec4f {
+	this.SafeNormalizeIn(fallback)
+	return this
+}
+
+// Normalize the vector, or set it to fallback when the length is zero or not
+// finite so the vector can't be normalized.
+// Return a pointer to 'this'
+func (this *Vec4f) SafeNormalizeIn(fallback Vec4f) *Vec4f {
+	mag := this.Length()
+	if mag == 0 || !isFinite(float64(mag)) {
+		*this = fallback
+		return this
+	}
+	return this.DivInScalar(mag)
+}
+
+// Set X,Y,Z,W parameters of the vector.
+func (this *Vec4f) Set(x, y, z, w float32) *Vec4f {
+	this.X = x
+	this.Y = y
+	this.Z = z
+	this.W = w
+	return this
+}
+
+// Make a vector which is the projection of this onto other
+func (this Vec4f) Proj(other Vec4f) Vec4f {
+	n := this.Length() * other.Length()
+	return other.Normalize().MultScalar(this.Dot(other) / n)
+}
+
+// Return a copy of this vector
+func (this Vec4f) Copy() Vec4f {
+	return this
+}
+
+// Retrieve all three x,y,z,w paramters at once
+func (this Vec4f) Dump() (float32, float32, float32, float32) {
+	return this.X, this.Y, this.Z, this.W
+}
+
+// convert to Vec4f. Forth component is dropped
+func (this Vec4f) Vec3f() Vec3f {
+	return Vec3f{this.X, this.Y, this.Z}
+}
+
+// Apply the matrix against the Vector
+// Return a new vector with the result v*m
+func (this Vec4f) MultMat4(right Mat4f) Vec4f {
+	// 0   1   2   3
+	// 4   5   6   7
+	// 8   9   10  11
+	// 12  13  14  15
+	this.Set(
+		this.X*right.At(0)+this.Y*right.At(4)+this.Z*right.At(8)+this.W*right.At(12),
+		this.X*right.At(1)+this.Y*right.At(5)+this.Z*right.At(9)+this.W*right.At(13),
+		this.X*right.At(2)+this.Y*right.At(6)+this.Z*right.At(10)+this.W*right.At(14),
+		this.X*right.At(3)+this.Y*right.At(7)+this.Z*right.At(11)+this.W*right.At(15),
+	)
+	return this
+}
+
+// convert to the float64 Vec4
+func (this Vec4f) Vec4() Vec4 {
+	return Vec4{float64(this.X), float64(this.Y), float64(this.Z), float64(this.W)}
+}
+
+// convert to the float32 Vec4f
+func (this Vec4) Vec4f() Vec4f {
+	return Vec4f{float32(this.X), float32(this.Y), float32(this.Z), float32(this.W)}
+}
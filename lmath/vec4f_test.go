@@ -0,0 +1,334 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqualVec4f(t *testing.T) {
+	var cases = []struct {
+		orig, other Vec4f
+		want        bool
+	}{
+		{Vec4f{0, 0, 0, 1}, Vec4f{1, 2, 3, 1}, false},
+		{Vec4f{1, 2, 3, 1}, Vec4f{0, 0, 0, 1}, false},
+		{Vec4f{1, 2, 3, 1}, Vec4f{-1, -2, -3, 1}, false},
+		{Vec4f{0, 0, 0, 1}, Vec4f{0, 0, 0, 1}, true},
+		{Vec4f{1.0, 2.0, 3.0, 1.0}, Vec4f{1.0, 2.0, 3.0, 1.0}, true},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Eq(test.other)
+		if get != test.want {
+			t.Errorf("TestEqualVec4f %d", testIndex)
+		}
+	}
+}
+
+func TestAddVec4f(t *testing.T) {
+
+	var cases = []struct {
+		orig, other, want Vec4f
+	}{
+		{Vec4f{0, 0, 0, 0}, Vec4f{1, 2, 3, 4}, Vec4f{1, 2, 3, 4}},
+		{Vec4f{1, 2, 3, 4}, Vec4f{0, 0, 0, 0}, Vec4f{1, 2, 3, 4}},
+		{Vec4f{1, 2, 3, 4}, Vec4f{-1, -2, -3, -4}, Vec4f{0, 0, 0, 0}},
+		{Vec4f{0, 0, 0, 0}, Vec4f{0, 0, 0, 1}, Vec4f{0, 0, 0, 1}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Add(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestAddVec4f %d %v", testIndex, get)
+		}
+
+		get2 := test.orig.AddIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestAddVec4f AddIn %d", testIndex)
+		}
+	}
+}
+
+func TestSubVec4f(t *testing.T) {
+
+	var cases = []struct {
+		orig, other, want Vec4f
+	}{
+		{Vec4f{0, 0, 0, 0}, Vec4f{1, 2, 3, 4}, Vec4f{-1, -2, -3, -4}},
+		{Vec4f{1, 2, 3, 4}, Vec4f{0, 0, 0, 0}, Vec4f{1, 2, 3, 4}},
+		{Vec4f{1, 2, 3, 4}, Vec4f{-1, -2, -3, -4}, Vec4f{2, 4, 6, 8}},
+		{Vec4f{0, 0, 0, 0}, Vec4f{0, 0, 0, 0}, Vec4f{0, 0, 0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Sub(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSubVec4f %d", testIndex)
+		}
+
+		get2 := test.orig.SubIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestSubVec4f SubIn %d", testIndex)
+		}
+	}
+}
+
+func TestAddScalarVec4f(t *testing.T) {
+	cases := []struct {
+		orig, want Vec4f
+		scale      float32
+	}{
+		{Vec4f{0, 0, 0, 0}, Vec4f{0, 0, 0, 0}, 0},
+		{Vec4f{0, 0, 0, 0}, Vec4f{1, 1, 1, 1}, 1},
+		{Vec4f{0, 0, 0, 0}, Vec4f{-1, -1, -1, -1}, -1},
+		{Vec4f{1, 2, 3, 4}, Vec4f{5, 6, 7, 8}, 4},
+		{Vec4f{1, 2, 3, 4}, Vec4f{-3, -2, -1, 0}, -4},
+		{Vec4f{1, -2, 3, -4}, Vec4f{5, 2, 7, 0}, 4},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.AddScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestAddScalarVec4f %d", testIndex)
+		}
+
+		get2 := test.orig.AddInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestAddInScalarVec4f %d", testIndex)
+		}
+	}
+}
+
+func TestSubScalarVec4f(t *testing.T) {
+	cases := []struct {
+		orig, want Vec4f
+		scale      float32
+	}{
+		{Vec4f{0, 0, 0, 0}, Vec4f{0, 0, 0, 0}, 0},
+		{Vec4f{0, 0, 0, 0}, Vec4f{-1, -1, -1, -1}, 1},
+		{Vec4f{0, 0, 0, 0}, Vec4f{1, 1, 1, 1}, -1},
+		{Vec4f{1, 2, 3, 4}, Vec4f{-3, -2, -1, 0}, 4},
+		{Vec4f{1, 2, 3, 4}, Vec4f{5, 6, 7, 8}, -4}, //4
+		{Vec4f{1, -2, 3, -4}, Vec4f{-3, -6, -1, -8}, 4},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.SubScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSubScalarVec4f %d", testIndex)
+		}
+
+		get2 := test.orig.SubInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestSubInScalarVec4f %d", testIndex)
+		}
+	}
+}
+
+func TestMultScalarVec4f(t *testing.T) {
+	var cases = []struct {
+		orig  Vec4f
+		scale float32
+		want  Vec4f
+	}{
+		{Vec4f{0, 0, 0, 0}, 2.0, Vec4f{0, 0, 0, 0}},
+		{Vec4f{0, 0, 0, 0}, -2, Vec4f{0, 0, 0, 0}},
+		{Vec4f{1, 2, 3, 4}, 2, Vec4f{2, 4, 6, 8}},
+		{Vec4f{1, 2, 3, 4}, 0.5, Vec4f{0.5, 1, 1.5, 2}},
+		{Vec4f{1, 2, 3, 4}, -1, Vec4f{-1, -2, -3, -4}},
+		{Vec4f{1, 2, 3, 4}, -0.5, Vec4f{-0.5, -1, -1.5, -2.0}},
+		{Vec4f{1, 2, 3, 4}, 0, Vec4f{0, 0, 0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.MultScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestMultVec4f %d", testIndex)
+		}
+
+		get2 := test.orig.MultInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestMultVec4f MultIn %d", testIndex)
+		}
+	}
+}
+
+func TestDivScalarVec4f(t *testing.T) {
+	var cases = []struct {
+		orig  Vec4f
+		scale float32
+		want  Vec4f
+	}{
+		{Vec4f{0, 0, 0, 0}, 2.0, Vec4f{0, 0, 0, 0}},
+		{Vec4f{0, 0, 0, 0}, -2.0, Vec4f{0, 0, 0, 0}},
+		{Vec4f{1, 2, 3, 4}, 2, Vec4f{0.5, 1, 1.5, 2.0}},
+		{Vec4f{1, 2, 3, 4}, 0.5, Vec4f{2, 4, 6, 8}},
+		{Vec4f{1, 2, 3, 4}, -1, Vec4f{-1, -2, -3, -4}},
+		{Vec4f{1, 2, 3, 4}, -0.5, Vec4f{-2, -4, -6, -8}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.DivScalar(test.scale)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestDivVec4f %d", testIndex)
+		}
+
+		get2 := test.orig.DivInScalar(test.scale)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestDivInVec4f %d", testIndex)
+		}
+	}
+}
+
+func TestOuterVec4f(t *testing.T) {
+	cases := []struct {
+		orig, other, want Vec4f
+	}{
+		{Vec4f{0, 0, 0, 0}, Vec4f{1, 2, 3, 4}, Vec4f{0, 0, 0, 0}},
+		{Vec4f{1, 2, 3, 4}, Vec4f{0, 0, 0, 0}, Vec4f{0, 0, 0, 0}},
+		{Vec4f{1, 2, 3, 4}, Vec4f{-1, -2, -3, -4}, Vec4f{-1, -4, -9, -16}},
+		{Vec4f{1, 2, 3, 4}, Vec4f{1, 2, 3, 4}, Vec4f{1, 4, 9, 16}},
+		{Vec4f{0, 0, 0, 0}, Vec4f{0, 0, 0, 0}, Vec4f{0, 0, 0, 0}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Outer(test.other)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestOuterVec4f %d", testIndex)
+		}
+
+		get2 := test.orig.OuterIn(test.other)
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestOuterInVec4f %d", testIndex)
+		}
+	}
+}
+
+func TestDotVec4f(t *testing.T) {
+	var cases = []struct {
+		orig  Vec4f
+		other Vec4f
+		want  float32
+	}{
+		{Vec4f{0, 0, 0, 0}, Vec4f{1, 2, 3, 4}, 0},
+		{Vec4f{1, 2, 3, 4}, Vec4f{0, 0, 0, 0}, 0},
+		{Vec4f{1, 2, 3, 4}, Vec4f{-1, -2, -3, -4}, -30},
+		{Vec4f{1, 2, 3, 4}, Vec4f{1, 2, 3, 4}, 30},
+		{Vec4f{0, 0, 0, 0}, Vec4f{0, 0, 0, 0}, 0},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Dot(test.other)
+		if get != test.want {
+			t.Errorf("TestDotVec4f %d", testIndex)
+		}
+	}
+}
+
+func TestLengthVec4f(t *testing.T) {
+	var cases = []struct {
+		orig Vec4f
+		want float32
+	}{
+		{Vec4f{0, 0, 0, 0}, 0},
+		{Vec4f{1, 2, 3, 4}, sqrt32(30)},
+		{Vec4f{1, 0, 0, 0}, 1},
+		{Vec4f{1 / sqrt32(30), 2 / sqrt32(30), 3 / sqrt32(30), 4 / sqrt32(30)}, 1},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Length()
+		if !closeEq32(get, test.want, epsilon32) {
+			t.Errorf("TestLengthVec4f %d %f", testIndex, get)
+		}
+	}
+}
+
+func TestNormalizeVec4f(t *testing.T) {
+	sqrt_30 := sqrt32(30)
+	var cases = []struct {
+		orig, want Vec4f
+	}{
+		{Vec4f{1, 2, 3, 4}, Vec4f{1 / sqrt_30, 2 / sqrt_30, 3 / sqrt_30, 4 / sqrt_30}},
+		{Vec4f{1, 0, 0, 0}, Vec4f{1, 0, 0, 0}},
+		{Vec4f{1 / sqrt_30, 2 / sqrt_30, 3 / sqrt_30, 4 / sqrt_30}, Vec4f{1 / sqrt_30, 2 / sqrt_30, 3 / sqrt_30, 4 / sqrt_30}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Normalize()
+		if get.Eq(test.want) == false {
+			t.Errorf("TestNormalizeVec4f %d", testIndex)
+		}
+
+		get2 := test.orig.NormalizeIn()
+		if get2 != &test.orig || get2.Eq(test.want) == false {
+			t.Errorf("TestNormalizeInVec4f %d", testIndex)
+		}
+	}
+}
+
+func TestSetVec4f(t *testing.T) {
+	var cases = []struct {
+		x, y, z, w float32
+		orig, want Vec4f
+	}{
+		{1, 2, 3, 4, Vec4f{0, 0, 0, 0}, Vec4f{1, 2, 3, 4}},
+		{0, 0, 1, 0, Vec4f{0, 0, 0, 0}, Vec4f{0, 0, 1, 0}},
+		{0, 0, 1, 0, Vec4f{1, 2, 3, 4}, Vec4f{0, 0, 1, 0}},
+		{-1, 2, 4, 6, Vec4f{1, -1, 3, 90}, Vec4f{-1, 2, 4, 6}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.orig.Set(test.x, test.y, test.z, test.w)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestSetVec4f %d", testIndex)
+		}
+	}
+}
+
+func TestProjVec4f(t *testing.T) {
+	v := float32(0.808359542 / 2)
+	var cases = []struct {
+		from, on, want Vec4f
+	}{
+		{Vec4f{1, 1, 0, 0}, Vec4f{1, 0, 0, 0}, Vec4f{sqrt32(2) / 2, 0, 0, 0}},
+		{Vec4f{1, 0, 0, 0}, Vec4f{1, 0, 0, 0}, Vec4f{1, 0, 0, 0}},
+		// should probably be checkig [0,0,0] projected [1,0,0] => {NaN,NaN,NaN}
+		{Vec4f{20, 50, 3, 20}, Vec4f{1, 1, 1, 1}, Vec4f{v, v, v, v}},
+	}
+
+	for testIndex, test := range cases {
+		get := test.from.Proj(test.on)
+		if get.Eq(test.want) == false {
+			t.Errorf("TestProjVec4f %d %v", testIndex, get)
+		}
+	}
+}
+
+func TestSafeNormalizeVec4f(t *testing.T) {
+	fallback := Vec4f{1, 0, 0, 0}
+	cases := []struct {
+		v, want Vec4f
+	}{
+		{Vec4f{3, 4, 0, 0}, Vec4f{0.6, 0.8, 0, 0}},
+		// short vectors still have a direction
+		{Vec4f{3e-12, 4e-12, 0, 0}, Vec4f{0.6, 0.8, 0, 0}},
+		{Vec4f{}, fallback},
+		{Vec4f{float32(math.NaN()), 1, 1, 1}, fallback},
+		{Vec4f{1, float32(math.Inf(1)), 1, 1}, fallback},
+	}
+
+	for testIndex, c := range cases {
+		got := c.v.SafeNormalize(fallback)
+		if !closeEq32(got.X, c.want.X, 1e-6) || !closeEq32(got.Y, c.want.Y, 1e-6) || !closeEq32(got.Z, c.want.Z, 1e-6) || !closeEq32(got.W, c.want.W, 1e-6) {
+			t.Errorf("TestSafeNormalizeVec4f %d %v %v", testIndex, got, c.want)
+		}
+		if got.HasNaN() {
+			t.Errorf("TestSafeNormalizeVec4f %d NaN %v", testIndex, got)
+		}
+		c.v.SafeNormalizeIn(fallback)
+		if c.v != got {
+			t.Errorf("TestSafeNormalizeVec4f %d In %v %v", testIndex, c.v, got)
+		}
+	}
+}
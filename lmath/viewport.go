@@ -0,0 +1,113 @@
+package lmath
+
+// A rectangle of the screen which normalized device coordinates are mapped
+// onto. Values are in pixels.
+//
+// Screen coordinates used by Project, Unproject and PickRay have their origin
+// at the top-left of the screen with +Y pointing down, matching the usual
+// mouse coordinates. The depth (Z) of a screen coordinate is the window depth
+// as stored in the depth buffer, the normalized device depth mapped linearly
+// onto [0,1]. It is taken after the projection's reverse-Z remapping, so the
+// near plane is at 0 and the far plane at 1, or the other way around when
+// the ClipSpace has ReverseZ set.
+type Viewport struct {
+	X, Y, Width, Height float64
+}
+
+// Equal if the position and size are equal within the tolerance.
+func (this Viewport) ApproxEq(other Viewport, tol Tolerance) bool {
+	return tol.Eq(this.X, other.X) && tol.Eq(this.Y, other.Y) &&
+		tol.Eq(this.Width, other.Width) && tol.Eq(this.Height, other.Height)
+}
+
+// Project the world space point onto the screen using the OpenGL clip space.
+// See ProjectClip
+func Project(world Vec3, viewProj Mat4, viewport Viewport) Vec3 {
+	return ProjectClip(world, viewProj, viewport, ClipSpaceOpenGL)
+}
+
+// Project the world space point onto the screen.
+// viewProj is the combined view-projection matrix (ie. proj * view) built for
+// the given clip space. Returns the screen coordinate with the depth in Z.
+// Points behind the camera give meaningless results.
+func ProjectClip(world Vec3, viewProj Mat4, viewport Viewport, clip ClipSpace) Vec3 {
+	return viewport.fromNDC(viewProj.TransformPoint(world), clip)
+}
+
+// Unproject the screen coordinate back into world space using the OpenGL
+// clip space. See UnprojectClip
+func Unproject(screen Vec3, viewProj Mat4, viewport Viewport) Vec3 {
+	return UnprojectClip(screen, viewProj, viewport, ClipSpaceOpenGL)
+}
+
+// Unproject the screen coordinate (with the depth in Z) back into world space.
+// This is the inverse of ProjectClip. viewProj must be invertible.
+func UnprojectClip(screen Vec3, viewProj Mat4, viewport Viewport, clip ClipSpace) Vec3 {
+	return viewport.unprojectInverse(screen, viewProj.Inverse(), clip)
+}
+
+// Return the world space ray passing through the screen position (ie. the
+// mouse position) using the OpenGL clip space. See PickRayClip
+func PickRay(x, y float64, viewProj Mat4, viewport Viewport) Ray {
+	return PickRayClip(x, y, viewProj, viewport, ClipSpaceOpenGL)
+}
+
+// Return the world space ray passing through the screen position (ie. the
+// mouse position). The ray starts on the near plane and has a unit direction
+// pointing away from the camera. Works with perspective, orthographic and
+// infinite far projections.
+func PickRayClip(x, y float64, viewProj Mat4, viewport Viewport, clip ClipSpace) Ray {
+	near := 0.0
+	if clip.ReverseZ {
+		near = 1
+	}
+	// The middle of the depth range is finite even with an infinite far plane
+	inv := viewProj.Inverse()
+	a := viewport.unprojectInverse(Vec3{x, y, near}, inv, clip)
+	b := viewport.unprojectInverse(Vec3{x, y, 0.5}, inv, clip)
+	return Ray{a, b.Sub(a).Normalize()}
+}
+
+// Unproject using the already inverted view-projection matrix
+func (this Viewport) unprojectInverse(screen Vec3, inv Mat4, clip ClipSpace) Vec3 {
+	return inv.TransformPoint(this.toNDC(screen, clip))
+}
+
+// Map normalized device coordinates onto the screen
+func (this Viewport) fromNDC(ndc Vec3, clip ClipSpace) (out Vec3) {
+	// With FlipY +Y in clip space already points down the screen
+	y := -ndc.Y
+	if clip.FlipY {
+		y = ndc.Y
+	}
+	lo, hi := ndcDepth(clip)
+	out.Set(
+		this.X+(ndc.X+1)/2*this.Width,
+		this.Y+(y+1)/2*this.Height,
+		(ndc.Z-lo)/(hi-lo),
+	)
+	return
+}
+
+// Map the screen coordinate back into normalized device coordinates
+func (this Viewport) toNDC(screen Vec3, clip ClipSpace) (out Vec3) {
+	y := 2*(screen.Y-this.Y)/this.Height - 1
+	if !clip.FlipY {
+		y = -y
+	}
+	lo, hi := ndcDepth(clip)
+	out.Set(
+		2*(screen.X-this.X)/this.Width-1,
+		y,
+		lo+screen.Z*(hi-lo),
+	)
+	return
+}
+
+// Return the lower and upper bound of the normalized device depth.
+func ndcDepth(clip ClipSpace) (lo, hi float64) {
+	if clip.DepthZeroToOne {
+		return 0, 1
+	}
+	return -1, 1
+}
@@ -0,0 +1,148 @@
+package lmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProjectViewport(t *testing.T) {
+	vp := Viewport{10, 20, 800, 600}
+	var proj, view Mat4
+	proj.ToPerspective(math.Pi/2, 800.0/600, 1, 100)
+	view.ToLookAt(Vec3{0, 0, 5}, Vec3{0, 0, 0}, Vec3{0, 1, 0})
+	m := proj.Mult(view)
+
+	cases := []struct {
+		world, want Vec3
+	}{
+		// center of the screen, ndc depth is (f+n)/(f-n) - 2fn/((f-n)*5)
+		{Vec3{0, 0, 0}, Vec3{410, 320, 80.0 / 99}},
+		// on the near plane, the top left corner
+		{Vec3{-4.0 / 3, 1, 4}, Vec3{10, 20, 0}},
+		// on the far plane, the bottom right corner
+		{Vec3{400.0 / 3, -100, -95}, Vec3{810, 620, 1}},
+	}
+
+	for testIndex, c := range cases {
+		get := Project(c.world, m, vp)
+		if !get.CloseEq(c.want, 1e-7) {
+			t.Errorf("TestProjectViewport %d %v %v", testIndex, get, c.want)
+		}
+		if back := Unproject(get, m, vp); !back.CloseEq(c.world, 1e-6) {
+			t.Errorf("TestProjectViewport unproject %d %v", testIndex, back)
+		}
+	}
+}
+
+func TestUnprojectReverseZViewport(t *testing.T) {
+	vp := Viewport{10, 20, 800, 600}
+	clip := ClipSpace{DepthZeroToOne: true, ReverseZ: true}
+	var proj, view Mat4
+	proj.ToPerspectiveClip(math.Pi/2, 800.0/600, 1, 100, clip)
+	view.ToLookAt(Vec3{0, 0, 5}, Vec3{0, 0, 0}, Vec3{0, 1, 0})
+	m := proj.Mult(view)
+
+	cases := []struct {
+		screen, want Vec3
+	}{
+		// with reverse-Z the near plane has a depth of 1, the top left corner
+		{Vec3{10, 20, 1}, Vec3{-4.0 / 3, 1, 4}},
+		// and the far plane a depth of 0, the bottom right corner
+		{Vec3{810, 620, 0}, Vec3{400.0 / 3, -100, -95}},
+	}
+
+	for testIndex, c := range cases {
+		get := UnprojectClip(c.screen, m, vp, clip)
+		if !get.CloseEq(c.want, 1e-6) {
+			t.Errorf("TestUnprojectReverseZViewport %d %v %v", testIndex, get, c.want)
+		}
+		if back := ProjectClip(get, m, vp, clip); !back.CloseEq(c.screen, 1e-7) {
+			t.Errorf("TestUnprojectReverseZViewport project %d %v", testIndex, back)
+		}
+	}
+}
+
+func TestProjectClipViewport(t *testing.T) {
+	vp := Viewport{0, 0, 640, 480}
+	world := Vec3{1, 2, -7}
+	clips := []ClipSpace{
+		ClipSpaceOpenGL,
+		ClipSpaceVulkan,
+		ClipSpaceD3D,
+		{DepthZeroToOne: true, ReverseZ: true},
+		{DepthZeroToOne: true, ReverseZ: true, InfiniteFar: true},
+	}
+
+	var want Vec3
+	for clipIndex, clip := range clips {
+		var proj Mat4
+		proj.ToPerspectiveClip(Radians(60), 640.0/480, 0.5, 50, clip)
+		get := ProjectClip(world, proj, vp, clip)
+
+		// Every clip space sees the point at the same place on the screen
+		if clipIndex == 0 {
+			want = get
+		} else if !closeEq(get.X, want.X, 1e-7) || !closeEq(get.Y, want.Y, 1e-7) {
+			t.Errorf("TestProjectClipViewport %v %v %v", clip, get, want)
+		}
+		// above and right of center
+		if get.X <= 320 || get.Y >= 240 || get.Z < 0 || get.Z > 1 {
+			t.Errorf("TestProjectClipViewport screen %v %v", clip, get)
+		}
+		if back := UnprojectClip(get, proj, vp, clip); !back.CloseEq(world, 1e-6) {
+			t.Errorf("TestProjectClipViewport unproject %v %v", clip, back)
+		}
+
+		near := ProjectClip(Vec3{0, 0, -0.5}, proj, vp, clip)
+		wantNear := 0.0
+		if clip.ReverseZ {
+			wantNear = 1
+		}
+		if !closeEq(near.Z, wantNear, 1e-7) {
+			t.Errorf("TestProjectClipViewport near %v %v", clip, near)
+		}
+	}
+}
+
+func TestPickRayViewport(t *testing.T) {
+	vp := Viewport{0, 0, 800, 600}
+	eye := Vec3{3, 4, 10}
+	var view Mat4
+	view.ToLookAt(eye, Vec3{3, 4, 0}, Vec3{0, 1, 0})
+
+	clips := []ClipSpace{
+		ClipSpaceOpenGL,
+		ClipSpaceVulkan,
+		{DepthZeroToOne: true, ReverseZ: true, InfiniteFar: true},
+	}
+	for _, clip := range clips {
+		var proj Mat4
+		proj.ToPerspectiveClip(math.Pi/2, 800.0/600, 1, 100, clip)
+		m := proj.Mult(view)
+
+		// the center of the screen looks straight ahead
+		r := PickRayClip(400, 300, m, vp, clip)
+		if !r.Origin.CloseEq(Vec3{3, 4, 9}, 1e-7) || !r.Dir.CloseEq(Vec3{0, 0, -1}, 1e-7) {
+			t.Errorf("TestPickRayViewport center %v %v", clip, r)
+		}
+
+		// the ray must pass through a world point projected onto the screen
+		world := Vec3{5, 3, -2}
+		s := ProjectClip(world, m, vp, clip)
+		r = PickRayClip(s.X, s.Y, m, vp, clip)
+		if r.Distance(world) > 1e-6 {
+			t.Errorf("TestPickRayViewport %v %v %v", clip, r, r.Distance(world))
+		}
+		if !closeEq(r.Dir.Length(), 1, epsilon) {
+			t.Errorf("TestPickRayViewport length %v %v", clip, r.Dir)
+		}
+	}
+
+	// orthographic rays are parallel to the view direction
+	var ortho Mat4
+	ortho.ToOrtho(-4, 4, -3, 3, 1, 100)
+	r := PickRay(0, 600, ortho.Mult(view), vp)
+	if !r.Origin.CloseEq(Vec3{-1, 1, 9}, 1e-7) || !r.Dir.CloseEq(Vec3{0, 0, -1}, 1e-7) {
+		t.Errorf("TestPickRayViewport ortho %v", r)
+	}
+}